@@ -1,6 +1,6 @@
 //! Build script for fib-go
 //!
-//! This script compiles the Go code into a static library and links it with Rust.
+//! This script compiles the Go sources in `go/` into a static library and links it with Rust.
 //! If CGO is not available (e.g., no GCC on Windows), it uses a Rust-based stub.
 
 use std::env;
@@ -17,7 +17,7 @@ fn main() {
     let lib_name = "libfibgo.a";
     let lib_path = PathBuf::from(&out_dir).join(lib_name);
 
-    println!("cargo:rerun-if-changed=go/fib.go");
+    println!("cargo:rerun-if-changed=go");
     println!("cargo:rerun-if-changed=build.rs");
 
     // Check if Go is available
@@ -55,6 +55,12 @@ fn main() {
         return;
     }
 
+    // The deferred-runtime feature selects the fib_deferred build tag (see go/startup_deferred.go)
+    let mut tags = Vec::new();
+    if env::var_os("CARGO_FEATURE_DEFERRED_RUNTIME").is_some() {
+        tags.push("fib_deferred");
+    }
+
     // Build the Go library using CGO
     let status = Command::new("go")
         .current_dir(&go_dir)
@@ -62,9 +68,10 @@ fn main() {
         .args([
             "build",
             "-buildmode=c-archive",
+            &format!("-tags={}", tags.join(",")),
             "-o",
             lib_path.to_str().unwrap(),
-            "fib.go",
+            ".",
         ])
         .status();
 
@@ -0,0 +1,27 @@
+package main
+
+/*
+#include <stdint.h>
+#include "../include/fib.h"
+*/
+import "C"
+
+//go:generate go run genheader/main.go -o ../include/fib.h -vtable fib_vtable_init.h
+
+// GetABIVersion returns FIB_ABI_VERSION, (major << 16) | minor, of this build
+// Hosts should refuse to bind if the major number differs from the fib.h they were built against.
+//
+//export GetABIVersion
+func GetABIVersion() C.uint32_t {
+	defer recoverPanic()
+	return C.FIB_ABI_VERSION
+}
+
+// GetFibVTable returns the table of every export, so hosts that dlopen the library need one lookup
+// The table is static and read-only; check abi_version and size before using newer members.
+//
+//export GetFibVTable
+func GetFibVTable() *C.fib_vtable {
+	defer recoverPanic()
+	return fibVTable()
+}
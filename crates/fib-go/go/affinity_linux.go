@@ -0,0 +1,63 @@
+//go:build linux
+
+package main
+
+/*
+#define _GNU_SOURCE
+#include <errno.h>
+#include <sched.h>
+#include <stdlib.h>
+
+// fib_pin_thread restricts the calling thread to cpu, saving its previous mask in *saved.
+// Returns 0 or an errno value.
+static int fib_pin_thread(int cpu, cpu_set_t **saved) {
+	cpu_set_t *old = malloc(sizeof *old);
+	if (old == NULL) {
+		return ENOMEM;
+	}
+	if (sched_getaffinity(0, sizeof *old, old) != 0) {
+		int err = errno;
+		free(old);
+		return err;
+	}
+	cpu_set_t set;
+	CPU_ZERO(&set);
+	CPU_SET(cpu, &set);
+	if (sched_setaffinity(0, sizeof set, &set) != 0) {
+		int err = errno;
+		free(old);
+		return err;
+	}
+	*saved = old;
+	return 0;
+}
+
+// fib_unpin_thread restores and releases a mask saved by fib_pin_thread
+static void fib_unpin_thread(cpu_set_t *saved) {
+	sched_setaffinity(0, sizeof *saved, saved);
+	free(saved);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// maxPinCPU is the largest CPU number pinThread accepts (CPU_SETSIZE - 1)
+const maxPinCPU = C.CPU_SETSIZE - 1
+
+// pinThread restricts the calling OS thread to cpu with sched_setaffinity
+// The caller must hold runtime.LockOSThread and call unpin before releasing it,
+// so the thread goes back to the Go scheduler with its original mask.
+func pinThread(cpu int) (unpin func(), err error) {
+	if cpu < 0 || cpu > maxPinCPU {
+		return nil, fmt.Errorf("pin_cpu %d out of range [0, %d]", cpu, maxPinCPU)
+	}
+	var saved *C.cpu_set_t
+	if rc := C.fib_pin_thread(C.int(cpu), &saved); rc != 0 {
+		return nil, fmt.Errorf("sched_setaffinity cpu %d: %w", cpu, syscall.Errno(rc))
+	}
+	return func() { C.fib_unpin_thread(saved) }, nil
+}
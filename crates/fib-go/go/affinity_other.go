@@ -0,0 +1,10 @@
+//go:build !linux && !windows
+
+package main
+
+import "errors"
+
+// pinThread is unavailable on this platform (see affinity_linux.go and affinity_windows.go)
+func pinThread(cpu int) (unpin func(), err error) {
+	return nil, errors.New("pin_cpu needs Linux or Windows")
+}
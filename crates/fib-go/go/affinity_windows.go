@@ -0,0 +1,47 @@
+//go:build windows
+
+package main
+
+/*
+#include <stdint.h>
+#include <windows.h>
+
+// fib_pin_thread restricts the calling thread to cpu, saving its previous mask in *saved.
+// Returns 0 or a GetLastError code.
+static int fib_pin_thread(int cpu, uintptr_t *saved) {
+	DWORD_PTR old = SetThreadAffinityMask(GetCurrentThread(), (DWORD_PTR)1 << cpu);
+	if (old == 0) {
+		return (int)GetLastError();
+	}
+	*saved = old;
+	return 0;
+}
+
+// fib_unpin_thread restores a mask saved by fib_pin_thread
+static void fib_unpin_thread(uintptr_t saved) {
+	SetThreadAffinityMask(GetCurrentThread(), (DWORD_PTR)saved);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// maxPinCPU is the largest CPU number pinThread accepts, one bit of the affinity mask
+const maxPinCPU = 63
+
+// pinThread restricts the calling OS thread to cpu with SetThreadAffinityMask
+// The caller must hold runtime.LockOSThread and call unpin before releasing it,
+// so the thread goes back to the Go scheduler with its original mask.
+func pinThread(cpu int) (unpin func(), err error) {
+	if cpu < 0 || cpu > maxPinCPU {
+		return nil, fmt.Errorf("pin_cpu %d out of range [0, %d]", cpu, maxPinCPU)
+	}
+	var saved C.uintptr_t
+	if rc := C.fib_pin_thread(C.int(cpu), &saved); rc != 0 {
+		return nil, fmt.Errorf("SetThreadAffinityMask cpu %d: %w", cpu, syscall.Errno(rc))
+	}
+	return func() { C.fib_unpin_thread(saved) }, nil
+}
@@ -0,0 +1,198 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// Algorithm identifiers accepted by the dispatching exports (see fib_types.h)
+const (
+	AlgoIterative    C.fib_algorithm = C.FIB_ALGO_ITERATIVE
+	AlgoRecursive    C.fib_algorithm = C.FIB_ALGO_RECURSIVE
+	AlgoMemo         C.fib_algorithm = C.FIB_ALGO_MEMO
+	AlgoMatrix       C.fib_algorithm = C.FIB_ALGO_MATRIX
+	AlgoDoubling     C.fib_algorithm = C.FIB_ALGO_DOUBLING
+	AlgoDoublingIter C.fib_algorithm = C.FIB_ALGO_DOUBLING_ITER
+	AlgoMemoFast     C.fib_algorithm = C.FIB_ALGO_MEMO_FAST
+	AlgoLookup       C.fib_algorithm = C.FIB_ALGO_LOOKUP
+)
+
+// algorithmInfo describes one entry of the algorithm registry
+type algorithmInfo struct {
+	ID         C.fib_algorithm `json:"id"`
+	Name       string          `json:"name"`
+	Complexity string          `json:"complexity"`
+	MaxSafeN   uint64          `json:"max_safe_n"`
+	fn         func(uint64) uint64
+}
+
+// maxRegisteredAlgorithms bounds the entries RegisterAlgorithm may add
+const maxRegisteredAlgorithms = 256
+
+// registryMu guards algorithms, which grows through RegisterAlgorithm
+var registryMu sync.RWMutex
+
+// algorithms is the registry behind the dispatching exports, indexed by algorithm identifier
+var algorithms = []algorithmInfo{
+	AlgoIterative:    {AlgoIterative, "iterative", "O(n)", fib.MaxSafeN, fib.Iterative},
+	AlgoRecursive:    {AlgoRecursive, "recursive", "O(phi^n)", fib.MaxSafeN, fibRecursiveGuardedGo},
+	AlgoMemo:         {AlgoMemo, "memo", "O(n)", fib.MaxSafeN, fib.Memo},
+	AlgoMatrix:       {AlgoMatrix, "matrix", "O(log n)", fib.MaxSafeN, fib.Matrix},
+	AlgoDoubling:     {AlgoDoubling, "doubling", "O(log n)", fib.MaxSafeN, fib.Doubling},
+	AlgoDoublingIter: {AlgoDoublingIter, "doubling_iter", "O(log n)", fib.MaxSafeN, fib.DoublingIter},
+	AlgoMemoFast:     {AlgoMemoFast, "memo_fast", "O(n)", fib.MaxSafeN, fib.MemoFast},
+	AlgoLookup:       {AlgoLookup, "lookup", "O(1)", fib.MaxSafeN, fib.Lookup},
+}
+
+// algorithmFunc returns the implementation for id, or nil if id is unknown
+func algorithmFunc(id C.fib_algorithm) func(uint64) uint64 {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if id < 0 || int(id) >= len(algorithms) {
+		return nil
+	}
+	return algorithms[id].fn
+}
+
+// algorithmByID returns the registry entry for id
+func algorithmByID(id C.fib_algorithm) (algorithmInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if id < 0 || int(id) >= len(algorithms) {
+		return algorithmInfo{}, false
+	}
+	return algorithms[id], true
+}
+
+// registeredAlgorithms returns a snapshot of the registry
+func registeredAlgorithms() []algorithmInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return slices.Clone(algorithms)
+}
+
+// RegisterAlgorithm adds an external implementation of F(n) to the registry
+// The new entry is dispatched, timed and verified like the built-in algorithms;
+// its identifier is written to outID. fn must be callable from any thread and
+// remain valid for the life of the process. Returns StatusInvalidArg for NULL
+// arguments or a name already in use, StatusLimitExceeded once 256 were added.
+//
+//export RegisterAlgorithm
+func RegisterAlgorithm(name *C.char, fn C.fib_algorithm_fn, outID *C.fib_algorithm) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if name == nil || fn == nil || outID == nil {
+		return StatusInvalidArg
+	}
+	id, status := registerAlgorithmGo(C.GoString(name), func(n uint64) uint64 {
+		return callAlgorithmFn(fn, n)
+	})
+	if status == StatusOK {
+		*outID = id
+	}
+	return status
+}
+
+func registerAlgorithmGo(name string, fn func(uint64) uint64) (C.fib_algorithm, C.fib_status) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if name == "" || slices.ContainsFunc(algorithms, func(a algorithmInfo) bool { return a.Name == name }) {
+		return 0, StatusInvalidArg
+	}
+	if len(algorithms) >= int(AlgoLookup)+1+maxRegisteredAlgorithms {
+		return 0, StatusLimitExceeded
+	}
+	id := C.fib_algorithm(len(algorithms))
+	algorithms = append(algorithms, algorithmInfo{id, name, "external", fib.MaxSafeN, fn})
+	return id, StatusOK
+}
+
+// FibCompute calculates F(n) with the algorithm registered under algorithmID
+// Returns 0 and records StatusInvalidArg (unknown algorithm) or StatusLimitExceeded
+// (recursion cutoff) as the last error on failure.
+//
+//export FibCompute
+func FibCompute(algorithmID C.fib_algorithm, n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	info, ok := algorithmByID(algorithmID)
+	if !ok || info.fn == nil {
+		setLastError(C.int32_t(StatusInvalidArg), fmt.Sprintf("unknown algorithm %d", algorithmID))
+		return 0
+	}
+	span := startSpan("FibCompute", info.Name, uint64(n))
+	value, status := computeAlgorithm(algorithmID, info.fn, uint64(n))
+	span.end(bits.Len64(value), status)
+	if status != StatusOK {
+		setLastError(C.int32_t(status), statusText(status))
+	}
+	return C.uint64_t(value)
+}
+
+// ListAlgorithms returns the registry as a JSON array of {id, name, complexity, max_safe_n}
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export ListAlgorithms
+func ListAlgorithms() *C.char {
+	defer recoverPanic()
+	data, err := algorithmsJSON()
+	if err != nil {
+		return nil
+	}
+	return C.CString(string(data))
+}
+
+// algorithmsJSON encodes the registry returned by ListAlgorithms
+func algorithmsJSON() ([]byte, error) {
+	return json.Marshal(registeredAlgorithms())
+}
+
+// dispatchMaxN is the largest n accepted by the dispatching exports (max_n in the config)
+var dispatchMaxN atomic.Uint64
+
+func init() {
+	dispatchMaxN.Store(math.MaxUint64)
+}
+
+// computeAlgorithm evaluates fn, the implementation of id, at n
+// It applies the max_n limit, the recursion cutoff and the debug assertions of the
+// single-value exports, and feeds the metrics while StartMetricsServer is serving.
+func computeAlgorithm(id C.fib_algorithm, fn func(uint64) uint64, n uint64) (uint64, C.fib_status) {
+	if metricsEnabled.Load() {
+		start := time.Now()
+		value, status := dispatchAlgorithm(id, fn, n)
+		observeCall(id, time.Since(start), status)
+		return value, status
+	}
+	return dispatchAlgorithm(id, fn, n)
+}
+
+// dispatchAlgorithm is computeAlgorithm without the metrics
+func dispatchAlgorithm(id C.fib_algorithm, fn func(uint64) uint64, n uint64) (uint64, C.fib_status) {
+	if n > dispatchMaxN.Load() {
+		return 0, StatusLimitExceeded
+	}
+	if n > fib.MaxSafeN && debugEnabled() {
+		logger.Debug("result wraps modulo 2^64", "algorithm", int(id), "n", n, "max_safe_n", fib.MaxSafeN)
+	}
+	if id == AlgoRecursive {
+		value, status := fibRecursiveSafe(n)
+		if status != StatusOK {
+			return 0, status
+		}
+		return debugVerify(n, value), StatusOK
+	}
+	return debugVerify(n, fn(n)), StatusOK
+}
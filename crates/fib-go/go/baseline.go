@@ -0,0 +1,181 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// significanceZ is the |Welch t| above which a change counts as significant (two-sided 95%)
+const significanceZ = 1.96
+
+// cellKey identifies a (algorithm, n) cell across reports
+type cellKey struct {
+	algorithm string
+	n         uint64
+}
+
+// cellStats pools the repetitions of a cell
+type cellStats struct {
+	samples  float64
+	mean     float64
+	variance float64 // sample variance, ns^2
+}
+
+// comparison is the baseline-to-current verdict on one cell
+type comparison struct {
+	Algorithm      string  `json:"algorithm"`
+	N              uint64  `json:"n"`
+	BaselineMeanNS float64 `json:"baseline_mean_ns"`
+	CurrentMeanNS  float64 `json:"current_mean_ns"`
+	ChangePct      float64 `json:"change_pct"`
+	T              float64 `json:"t"`
+	Significant    bool    `json:"significant"`
+	Regression     bool    `json:"regression"`
+}
+
+// baselineReport is the document returned by CompareToBaseline
+type baselineReport struct {
+	ThresholdPct float64      `json:"threshold_pct"`
+	Passed       bool         `json:"passed"`
+	Regressions  int          `json:"regressions"`
+	Comparisons  []comparison `json:"comparisons"`
+}
+
+// CompareToBaseline reruns the matrix of a saved RunBenchmarkMatrix report and returns the regressions as JSON
+// baselinePath holds the JSON-format report; its algorithms, n values and options are
+// rerun. A cell regresses when it is slower by more than thresholdPct percent and
+// Welch's t-test finds the change significant at 95%; "passed" is false if any cell
+// regressed. Returns NULL and records StatusNotFound for a missing file and
+// StatusInvalidArg for an unreadable report. The string must be released with FreeCString.
+//
+//export CompareToBaseline
+func CompareToBaseline(baselinePath *C.char, thresholdPct C.double) *C.char {
+	defer recoverPanic()
+	return documentCString(baselineDocument(baselinePath, thresholdPct))
+}
+
+// baselineDocument loads the baseline of CompareToBaseline, reruns it and encodes the comparison
+func baselineDocument(baselinePath *C.char, thresholdPct C.double) (string, C.fib_status, error) {
+	if baselinePath == nil || thresholdPct < 0 || math.IsNaN(float64(thresholdPct)) {
+		return "", StatusInvalidArg, errors.New("CompareToBaseline: NULL path or negative threshold")
+	}
+	data, err := os.ReadFile(C.GoString(baselinePath))
+	if err != nil {
+		code := StatusInternal
+		if os.IsNotExist(err) {
+			code = StatusNotFound
+		}
+		return "", code, err
+	}
+	var baseline benchReport
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return "", StatusInvalidArg, fmt.Errorf("baseline: %v", err)
+	}
+	report, err := compareToBaselineGo(baseline, float64(thresholdPct))
+	if err != nil {
+		return "", StatusInvalidArg, fmt.Errorf("baseline: %v", err)
+	}
+	out, err := json.Marshal(report)
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(out), StatusOK, nil
+}
+
+// compareToBaselineGo reruns the cells of baseline and compares them
+func compareToBaselineGo(baseline benchReport, thresholdPct float64) (baselineReport, error) {
+	var (
+		names   []string
+		nValues []uint64
+		seenA   = map[string]bool{}
+		seenN   = map[uint64]bool{}
+	)
+	for _, rec := range baseline.Results {
+		if !seenA[rec.Algorithm] {
+			seenA[rec.Algorithm] = true
+			names = append(names, rec.Algorithm)
+		}
+		if !seenN[rec.N] {
+			seenN[rec.N] = true
+			nValues = append(nValues, rec.N)
+		}
+	}
+	opts := baseline.Options
+	opts.Format = "json"
+	current, err := benchmarkMatrixGo(names, nValues, opts)
+	if err != nil {
+		return baselineReport{}, err
+	}
+
+	before, after := poolCells(baseline.Results), poolCells(current.Results)
+	report := baselineReport{ThresholdPct: thresholdPct, Passed: true, Comparisons: []comparison{}}
+	for _, rec := range baseline.Results {
+		key := cellKey{rec.Algorithm, rec.N}
+		b, okB := before[key]
+		a, okA := after[key]
+		if !okB || !okA {
+			continue
+		}
+		delete(before, key) // one comparison per cell, in baseline order
+		c := comparison{
+			Algorithm:      key.algorithm,
+			N:              key.n,
+			BaselineMeanNS: b.mean,
+			CurrentMeanNS:  a.mean,
+			T:              welchT(b, a),
+		}
+		if b.mean > 0 {
+			c.ChangePct = 100 * (a.mean - b.mean) / b.mean
+		}
+		c.Significant = math.Abs(c.T) > significanceZ
+		c.Regression = c.Significant && c.ChangePct > thresholdPct
+		if c.Regression {
+			report.Regressions++
+			report.Passed = false
+		}
+		report.Comparisons = append(report.Comparisons, c)
+	}
+	return report, nil
+}
+
+// poolCells merges the successful repetitions of every cell into one mean and variance
+func poolCells(records []benchRecord) map[cellKey]cellStats {
+	cells := map[cellKey]cellStats{}
+	for _, rec := range records {
+		if rec.Status != int32(StatusOK) || rec.Iterations == 0 {
+			continue
+		}
+		key := cellKey{rec.Algorithm, rec.N}
+		x := cellStats{float64(rec.Iterations), rec.MeanNS, rec.StddevNS * rec.StddevNS}
+		c, ok := cells[key]
+		if !ok {
+			cells[key] = x
+			continue
+		}
+		// Combine the sums of squares around the pooled mean
+		total := c.samples + x.samples
+		mean := (c.samples*c.mean + x.samples*x.mean) / total
+		ss := (c.samples-1)*c.variance + (x.samples-1)*x.variance +
+			c.samples*(c.mean-mean)*(c.mean-mean) + x.samples*(x.mean-mean)*(x.mean-mean)
+		cells[key] = cellStats{total, mean, ss / (total - 1)}
+	}
+	return cells
+}
+
+// welchT is Welch's t statistic of the change from b to a, 0 when both are exact
+func welchT(b, a cellStats) float64 {
+	se := math.Sqrt(b.variance/b.samples + a.variance/a.samples)
+	if se == 0 {
+		return 0
+	}
+	return (a.mean - b.mean) / se
+}
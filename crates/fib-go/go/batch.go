@@ -0,0 +1,167 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibBatch calculates F(n) for count indices in a single FFI call
+// results[i] receives F(n_values[i]) with the same wrapping semantics as the single-value exports.
+// Returns StatusInvalidArg for an unknown algorithm or NULL buffers, and
+// StatusLimitExceeded when the recursion cutoff refuses an index.
+//
+//export FibBatch
+func FibBatch(algorithmID C.fib_algorithm, nValues *C.uint64_t, count C.size_t, results *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	info, ok := algorithmByID(algorithmID)
+	if !ok || info.fn == nil {
+		return StatusInvalidArg
+	}
+	if count == 0 {
+		return StatusOK
+	}
+	if nValues == nil || results == nil {
+		return StatusInvalidArg
+	}
+
+	// One span covers the batch; its n is the number of indices
+	span := startSpan("FibBatch", info.Name, uint64(count))
+	in := unsafe.Slice((*uint64)(unsafe.Pointer(nValues)), count)
+	out := unsafe.Slice((*uint64)(unsafe.Pointer(results)), count)
+	for i, n := range in {
+		value, status := computeAlgorithm(algorithmID, info.fn, n)
+		if status != StatusOK {
+			span.end(0, status)
+			return status
+		}
+		out[i] = value
+	}
+	span.end(0, StatusOK)
+	return StatusOK
+}
+
+// parallelBatchChunk is the number of indices a FibBatchParallel worker claims at a time
+const parallelBatchChunk = 64
+
+// FibBatchParallel is FibBatch with the indices distributed over a pool of goroutines
+// workers 0 uses the `workers` config key. On failure results is partially filled
+// and the status of the lowest failing index is returned.
+//
+//export FibBatchParallel
+func FibBatchParallel(algorithmID C.fib_algorithm, nValues *C.uint64_t, count C.size_t, results *C.uint64_t, workers C.uint32_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	info, ok := algorithmByID(algorithmID)
+	if !ok || info.fn == nil {
+		return StatusInvalidArg
+	}
+	if count == 0 {
+		return StatusOK
+	}
+	if nValues == nil || results == nil {
+		return StatusInvalidArg
+	}
+	n := int(workers)
+	if n == 0 {
+		n = int(workerCount.Load())
+	}
+
+	in := unsafe.Slice((*uint64)(unsafe.Pointer(nValues)), count)
+	out := unsafe.Slice((*uint64)(unsafe.Pointer(results)), count)
+	span := startSpan("FibBatchParallel", info.Name, uint64(count))
+	status = batchParallelGo(context.Background(), algorithmID, info.fn, in, out, n)
+	span.end(0, status)
+	return status
+}
+
+// batchParallelGo computes out[i] = F(in[i]) on workers goroutines, checking ctx before every chunk
+// A done ctx fails the first unclaimed chunk with the status of ctx.Err().
+func batchParallelGo(ctx context.Context, id C.fib_algorithm, fn func(uint64) uint64, in, out []uint64, workers int) C.fib_status {
+	workers = min(workers, (len(in)+parallelBatchChunk-1)/parallelBatchChunk)
+	var (
+		wg   sync.WaitGroup
+		next atomic.Int64 // first unclaimed index
+		mu   sync.Mutex
+		// failAt is the lowest failing index seen, len(in) while none failed
+		failAt     = len(in)
+		failStatus = StatusOK
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				start := int(next.Add(parallelBatchChunk)) - parallelBatchChunk
+				mu.Lock()
+				stop := start >= failAt
+				if err := ctx.Err(); err != nil && !stop {
+					failAt, failStatus, stop = start, statusOf(err), true
+				}
+				mu.Unlock()
+				if stop {
+					return
+				}
+				for i := start; i < min(start+parallelBatchChunk, len(in)); i++ {
+					value, status := computeAlgorithm(id, fn, in[i])
+					if status != StatusOK {
+						mu.Lock()
+						if i < failAt {
+							failAt, failStatus = i, status
+						}
+						mu.Unlock()
+						return
+					}
+					out[i] = value
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return failStatus
+}
+
+// FibRange fills out with F(a), F(a+1), ..., F(b) in a single pass - O(log a + (b-a))
+// out must hold b-a+1 values. Returns StatusInvalidArg if a > b or out is NULL, and
+// StatusLimitExceeded if b-a+1 values cannot fit in memory, e.g. for a = 0 and b = UINT64_MAX.
+//
+//export FibRange
+func FibRange(a, b C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if a > b || out == nil {
+		return StatusInvalidArg
+	}
+	count, status := rangeCount(uint64(a), uint64(b))
+	if status != StatusOK {
+		return status
+	}
+
+	dst := unsafe.Slice((*uint64)(unsafe.Pointer(out)), count)
+	pair := fib.Pair(uint64(a))
+	fk, fk1 := pair[0], pair[1]
+	for i := range dst {
+		dst[i] = fk
+		fk, fk1 = fk1, fk+fk1
+	}
+	return StatusOK
+}
+
+// rangeCount returns b-a+1, the number of values FibRange writes, for a <= b
+// The count would wrap to 0 for the full uint64 range, and past math.MaxInt/8
+// the buffer could not exist, so both are StatusLimitExceeded.
+func rangeCount(a, b uint64) (int, C.fib_status) {
+	if b-a >= math.MaxInt/8 {
+		return 0, StatusLimitExceeded
+	}
+	return int(b-a) + 1, StatusOK
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRangeCount(t *testing.T) {
+	for _, c := range []struct{ a, b uint64 }{{0, 0}, {5, 14}, {math.MaxUint64, math.MaxUint64}} {
+		if count, status := rangeCount(c.a, c.b); status != StatusOK || uint64(count) != c.b-c.a+1 {
+			t.Errorf("rangeCount(%d, %d) = %d, %d, want %d, StatusOK", c.a, c.b, count, status, c.b-c.a+1)
+		}
+	}
+	// b-a+1 wraps to 0 for the full range
+	for _, c := range []struct{ a, b uint64 }{{0, math.MaxUint64}, {1, math.MaxUint64}, {0, math.MaxInt / 8}} {
+		if count, status := rangeCount(c.a, c.b); status != StatusLimitExceeded {
+			t.Errorf("rangeCount(%d, %d) = %d, %d, want StatusLimitExceeded", c.a, c.b, count, status)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// benchSink keeps benchmarked results observable so the calls are not optimized away
+var benchSink atomic.Uint64
+
+// libraryLoaded is when the Go side of the library was initialized
+var libraryLoaded = time.Now()
+
+// benchmarkedAlgorithms records the algorithms already benchmarked by this process
+var benchmarkedAlgorithms sync.Map
+
+// benchStats summarizes the per-call timings of a benchmark run
+type benchStats struct {
+	samples []time.Duration
+	min     time.Duration
+	max     time.Duration
+	mean    float64 // nanoseconds
+	stddev  float64 // nanoseconds
+	// p50, p90, p99 and p999 are nearest-rank percentiles
+	p50, p90, p99, p999 time.Duration
+	// histogram[k] counts the samples in [2^(k-1), 2^k) ns, bucket 0 holding the 0 ns ones
+	histogram [65]uint64
+	// mem is the runtime.MemStats delta over the timed calls
+	mem memDelta
+	// cold is the first call of the run, timed apart from the samples
+	cold coldStart
+	// cpu is the CPU time of the timed calls, nil unless requested and available
+	cpu *cpuTime
+	// perf holds the hardware counters of the timed calls, nil unless requested;
+	// perfErr tells why they are missing when requested
+	perf    *perfReport
+	perfErr string
+	// pinErr tells why a requested pin_cpu could not be applied
+	pinErr string
+}
+
+// perfCounts are raw hardware counter values: instructions, cycles, branch misses, cache misses
+type perfCounts [4]uint64
+
+// perfReport is the per-call view of the perfCounts of a benchmark run
+// Counters include the timing calls around every sample, like the wall time does.
+type perfReport struct {
+	InstructionsPerOp float64 `json:"instructions_per_op"`
+	CyclesPerOp       float64 `json:"cycles_per_op"`
+	BranchMissesPerOp float64 `json:"branch_misses_per_op"`
+	CacheMissesPerOp  float64 `json:"cache_misses_per_op"`
+	IPC               float64 `json:"ipc"`
+}
+
+// newPerfReport divides counts over ops calls
+func newPerfReport(counts perfCounts, ops int) *perfReport {
+	r := &perfReport{
+		InstructionsPerOp: float64(counts[0]) / float64(ops),
+		CyclesPerOp:       float64(counts[1]) / float64(ops),
+		BranchMissesPerOp: float64(counts[2]) / float64(ops),
+		CacheMissesPerOp:  float64(counts[3]) / float64(ops),
+	}
+	if counts[1] > 0 {
+		r.IPC = float64(counts[0]) / float64(counts[1])
+	}
+	return r
+}
+
+// cpuTime is the CPU time the benchmarking thread spent in the timed calls
+// Unlike wall time it excludes the time the thread was descheduled, but it also
+// misses work done for the calls on other threads, such as concurrent GC marking.
+type cpuTime struct {
+	TotalNS int64   `json:"total_ns"`
+	PerOpNS float64 `json:"per_op_ns"`
+}
+
+// coldStart is the timing of the first call of a benchmark run, before any warm-up
+type coldStart struct {
+	FirstCallNS int64 `json:"first_call_ns"`
+	// FirstUse is true when no earlier benchmark of this process ran the algorithm,
+	// so the call also paid for lazy initialization and cold caches.
+	FirstUse bool `json:"first_use"`
+	// SinceLoadNS is the time from the library initialization to that call
+	SinceLoadNS int64 `json:"since_load_ns"`
+}
+
+// memDelta is the change of the process-wide runtime.MemStats over a benchmark run
+// Allocations of other goroutines running meanwhile are included.
+type memDelta struct {
+	AllocsPerOp float64 `json:"allocs_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+	GCCycles    uint32  `json:"gc_cycles"`
+	GCPauseNS   uint64  `json:"gc_pause_ns"`
+}
+
+// memStatsDelta returns the change from before to after over ops calls
+func memStatsDelta(before, after *runtime.MemStats, ops int) memDelta {
+	return memDelta{
+		AllocsPerOp: float64(after.Mallocs-before.Mallocs) / float64(ops),
+		BytesPerOp:  float64(after.TotalAlloc-before.TotalAlloc) / float64(ops),
+		GCCycles:    after.NumGC - before.NumGC,
+		GCPauseNS:   after.PauseTotalNs - before.PauseTotalNs,
+	}
+}
+
+// maxBenchIters bounds warmupIters and measureIters of the C-facing benchmarks, like maxRPCBenchIters
+// The timed calls keep one sample each, so measureIters also sizes an allocation.
+const maxBenchIters = 1 << 20
+
+// RunBenchmark times measureIters calls of algorithmID at n after warmupIters untimed ones
+// Every call is timed on its own inside the library, so no FFI crossing is
+// included. One untimed call always runs first to validate n. result.status is
+// StatusInvalidArg for an unknown algorithm or measureIters 0, StatusLimitExceeded
+// for warmupIters or measureIters above 2^20, and the failure of that first call
+// otherwise (e.g. StatusLimitExceeded).
+//
+//export RunBenchmark
+func RunBenchmark(algorithmID C.fib_algorithm, n, warmupIters, measureIters C.uint64_t) (result C.fib_bench_result) {
+	defer recoverStatus(&result.status)
+	algo, ok := algorithmByID(algorithmID)
+	if !ok || measureIters == 0 {
+		result.status = StatusInvalidArg
+		return result
+	}
+	if warmupIters > maxBenchIters || measureIters > maxBenchIters {
+		result.status = StatusLimitExceeded
+		return result
+	}
+	stats, status := runBenchmarkGo(algo, uint64(n), benchOptions{WarmupIters: uint64(warmupIters), MeasureIters: uint64(measureIters)})
+	if status != StatusOK {
+		result.status = status
+		return result
+	}
+
+	result.status = StatusOK
+	result.iterations = C.uint64_t(len(stats.samples))
+	result.min_ns = C.uint64_t(stats.min.Nanoseconds())
+	result.max_ns = C.uint64_t(stats.max.Nanoseconds())
+	result.mean_ns = C.double(stats.mean)
+	result.stddev_ns = C.double(stats.stddev)
+	return result
+}
+
+// runBenchmarkGo runs max(opts.WarmupIters, 1) untimed calls then times opts.MeasureIters calls of algo at n
+// The samples are kept for ExportResultsCSV and ExportResultsNDJSON. With
+// opts.CPUTime or opts.PerfCounters the run is locked to one OS thread whose CPU
+// time or hardware counters are read around the timed calls; opts.PinCPU also
+// binds that thread to one core for the whole run.
+func runBenchmarkGo(algo algorithmInfo, n uint64, opts benchOptions) (benchStats, C.fib_status) {
+	id, fn := algo.ID, algo.fn
+	var pinErr error
+	if opts.CPUTime || opts.PerfCounters || opts.PinCPU != nil {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+	}
+	if opts.PinCPU != nil {
+		var unpin func()
+		if unpin, pinErr = pinThread(*opts.PinCPU); pinErr == nil {
+			defer unpin()
+		}
+	}
+	_, seen := benchmarkedAlgorithms.LoadOrStore(algo.Name, true)
+	start := time.Now()
+	value, status := computeAlgorithm(id, fn, n)
+	cold := coldStart{time.Since(start).Nanoseconds(), !seen, start.Sub(libraryLoaded).Nanoseconds()}
+	if status != StatusOK {
+		return benchStats{}, status
+	}
+	acc := value
+	for i := uint64(1); i < opts.WarmupIters; i++ {
+		value, _ := computeAlgorithm(id, fn, n)
+		acc ^= value
+	}
+
+	samples := make([]time.Duration, opts.MeasureIters)
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	var (
+		perf    *perfSession
+		perfErr error
+	)
+	if opts.PerfCounters {
+		perf, perfErr = startPerf()
+	}
+	cpuStart, cpuOK := threadCPUTime()
+	for i := range samples {
+		start := time.Now()
+		value, _ := computeAlgorithm(id, fn, n)
+		samples[i] = time.Since(start)
+		acc ^= value
+	}
+	cpuEnd, _ := threadCPUTime()
+	var counts perfCounts
+	if perf != nil {
+		counts, perfErr = perf.stop()
+	}
+	runtime.ReadMemStats(&after)
+	benchSink.Add(acc)
+	recordSamples(algo.Name, n, samples)
+	stats := summarize(samples)
+	stats.mem = memStatsDelta(&before, &after, len(samples))
+	stats.cold = cold
+	if opts.CPUTime && cpuOK {
+		total := cpuEnd - cpuStart
+		stats.cpu = &cpuTime{total.Nanoseconds(), float64(total) / float64(len(samples))}
+	}
+	if pinErr != nil {
+		stats.pinErr = pinErr.Error()
+	}
+	if perfErr != nil {
+		stats.perfErr = perfErr.Error()
+	} else if perf != nil {
+		stats.perf = newPerfReport(counts, len(samples))
+	}
+	return stats, StatusOK
+}
+
+// summarize computes the statistics of a non-empty set of samples
+func summarize(samples []time.Duration) benchStats {
+	s := benchStats{samples: samples, min: samples[0], max: samples[0]}
+	var sum float64
+	for _, d := range samples {
+		s.min, s.max = min(s.min, d), max(s.max, d)
+		sum += float64(d)
+		s.histogram[bits.Len64(uint64(max(d, 0)))]++
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	s.p50, s.p90 = percentile(sorted, 0.50), percentile(sorted, 0.90)
+	s.p99, s.p999 = percentile(sorted, 0.99), percentile(sorted, 0.999)
+	s.mean = sum / float64(len(samples))
+	if len(samples) > 1 {
+		var sq float64
+		for _, d := range samples {
+			sq += (float64(d) - s.mean) * (float64(d) - s.mean)
+		}
+		s.stddev = math.Sqrt(sq / float64(len(samples)-1))
+	}
+	return s
+}
+
+// percentile returns the nearest-rank p-quantile of ascending samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// histogramBucket is a non-empty bucket of a reported latency histogram
+type histogramBucket struct {
+	BelowNS uint64 `json:"below_ns"` // exclusive upper bound, a power of two
+	Count   uint64 `json:"count"`
+}
+
+// buckets lists the non-empty histogram buckets in ascending order
+func (s benchStats) buckets() []histogramBucket {
+	var out []histogramBucket
+	for k, count := range s.histogram {
+		if count == 0 {
+			continue
+		}
+		below := uint64(math.MaxUint64)
+		if k < 64 {
+			below = 1 << k
+		}
+		out = append(out, histogramBucket{below, count})
+	}
+	return out
+}
+
+// RunBenchmarkJSON is RunBenchmark reporting percentiles and a latency histogram as a JSON object
+// The object has the fields of a RunBenchmarkMatrix result, including p50_ns,
+// p90_ns, p99_ns, p999_ns, power-of-two histogram buckets, which expose GC
+// pauses a mean hides, and the allocations and GC cycles of the timed calls.
+// Returns NULL and records StatusInvalidArg for an unknown algorithm or
+// measureIters 0, and StatusLimitExceeded for iterations above 2^20. The string
+// must be released with FreeCString.
+//
+//export RunBenchmarkJSON
+func RunBenchmarkJSON(algorithmID C.fib_algorithm, n, warmupIters, measureIters C.uint64_t) *C.char {
+	defer recoverPanic()
+	return documentCString(benchmarkDocument(algorithmID, n, warmupIters, measureIters))
+}
+
+// benchmarkDocument runs the benchmark of RunBenchmarkJSON and encodes its cell
+func benchmarkDocument(algorithmID C.fib_algorithm, n, warmupIters, measureIters C.uint64_t) (string, C.fib_status, error) {
+	info, ok := algorithmByID(algorithmID)
+	if !ok || measureIters == 0 {
+		return "", StatusInvalidArg, errors.New(statusText(StatusInvalidArg))
+	}
+	if warmupIters > maxBenchIters || measureIters > maxBenchIters {
+		return "", StatusLimitExceeded, fmt.Errorf("warmup_iters and measure_iters must be <= %d", maxBenchIters)
+	}
+	data, err := json.Marshal(benchmarkCell(info, uint64(n), benchOptions{WarmupIters: uint64(warmupIters), MeasureIters: uint64(measureIters)}))
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(data), StatusOK, nil
+}
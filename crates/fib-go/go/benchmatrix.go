@@ -0,0 +1,245 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// benchOptions are the options_json settings of RunBenchmarkMatrix
+type benchOptions struct {
+	WarmupIters  uint64 `json:"warmup_iters"`
+	MeasureIters uint64 `json:"measure_iters"`
+	// Count repeats every cell, each repetition being reported on its own
+	Count uint64 `json:"count"`
+	// Format is "json" or "benchstat", the text format of `go test -bench -benchmem`
+	Format string `json:"format"`
+	// ColdStart adds the timing of each run's first call, kept apart from the steady state
+	ColdStart bool `json:"cold_start"`
+	// CPUTime adds the thread CPU time of the timed calls next to their wall time
+	CPUTime bool `json:"cpu_time"`
+	// PerfCounters adds Linux hardware counters (perf_event_open) of the timed calls
+	PerfCounters bool `json:"perf_counters"`
+	// PinCPU binds the measuring thread to this core (Linux and Windows), nil to let it migrate
+	PinCPU *int `json:"pin_cpu,omitempty"`
+}
+
+// maxBenchCells bounds the records of one RunBenchmarkMatrix report: algorithms x n values x count
+const maxBenchCells = 1 << 16
+
+// errBenchLimit marks the options of RunBenchmarkMatrix refused for their size rather than their form
+var errBenchLimit = errors.New("options exceed the benchmark limits")
+
+// defaultBenchOptions apply to the keys missing from options_json
+var defaultBenchOptions = benchOptions{WarmupIters: 10, MeasureIters: 100, Count: 1, Format: "json"}
+
+// benchRecord is the result of one (algorithm, n) cell of RunBenchmarkMatrix
+type benchRecord struct {
+	Algorithm  string  `json:"algorithm"`
+	ID         int32   `json:"id"`
+	N          uint64  `json:"n"`
+	Status     int32   `json:"status"`
+	Iterations int     `json:"iterations"`
+	MinNS      int64   `json:"min_ns"`
+	MaxNS      int64   `json:"max_ns"`
+	MeanNS     float64 `json:"mean_ns"`
+	StddevNS   float64 `json:"stddev_ns"`
+	P50NS      int64   `json:"p50_ns"`
+	P90NS      int64   `json:"p90_ns"`
+	P99NS      int64   `json:"p99_ns"`
+	P999NS     int64   `json:"p999_ns"`
+	// Histogram lists the non-empty power-of-two latency buckets
+	Histogram []histogramBucket `json:"histogram,omitempty"`
+	memDelta
+	ColdStart    *coldStart  `json:"cold_start,omitempty"`
+	CPUTime      *cpuTime    `json:"cpu_time,omitempty"`
+	PerfCounters *perfReport `json:"perf_counters,omitempty"`
+	// PerfError tells why requested perf_counters are missing, e.g. a refused perf_event_open
+	PerfError string `json:"perf_error,omitempty"`
+	// PinError tells why a requested pin_cpu was not applied
+	PinError string `json:"pin_error,omitempty"`
+}
+
+// benchReport is the document returned by RunBenchmarkMatrix
+type benchReport struct {
+	Options benchOptions  `json:"options"`
+	Results []benchRecord `json:"results"`
+}
+
+// RunBenchmarkMatrix benchmarks every (algorithm, n) combination and returns the results as one JSON document
+// algorithmsJSON is an array of registry names (NULL for every algorithm), nValuesJSON
+// an array of indices and optionsJSON an object with warmup_iters (default 10),
+// measure_iters (default 100), count (default 1), format, cold_start, cpu_time,
+// perf_counters and pin_cpu, or NULL. A refused cell keeps its failing status and no timings.
+// With format "benchstat" the document is instead `go test -bench -benchmem`
+// text, one line per repetition and refused cells left out. Returns NULL and
+// records StatusInvalidArg for malformed input, and StatusLimitExceeded for
+// iterations above 2^20 or more than 2^16 cells times count.
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export RunBenchmarkMatrix
+func RunBenchmarkMatrix(algorithmsJSON, nValuesJSON, optionsJSON *C.char) *C.char {
+	defer recoverPanic()
+	return documentCString(benchmarkMatrixDocument(algorithmsJSON, nValuesJSON, optionsJSON))
+}
+
+// benchmarkMatrixDocument decodes the arguments of RunBenchmarkMatrix, runs the matrix and renders it
+func benchmarkMatrixDocument(algorithmsJSON, nValuesJSON, optionsJSON *C.char) (string, C.fib_status, error) {
+	var names []string
+	if algorithmsJSON != nil {
+		if err := decodeStrict(C.GoString(algorithmsJSON), &names); err != nil {
+			return "", StatusInvalidArg, fmt.Errorf("algorithms: %v", err)
+		}
+	}
+	var nValues []uint64
+	if nValuesJSON == nil {
+		return "", StatusInvalidArg, errors.New("n_values: NULL")
+	}
+	if err := decodeStrict(C.GoString(nValuesJSON), &nValues); err != nil {
+		return "", StatusInvalidArg, fmt.Errorf("n_values: %v", err)
+	}
+	opts := defaultBenchOptions
+	if optionsJSON != nil {
+		if err := decodeStrict(C.GoString(optionsJSON), &opts); err != nil {
+			return "", StatusInvalidArg, fmt.Errorf("options: %v", err)
+		}
+	}
+
+	report, err := benchmarkMatrixGo(names, nValues, opts)
+	if errors.Is(err, errBenchLimit) {
+		return "", StatusLimitExceeded, err
+	}
+	if err != nil {
+		return "", StatusInvalidArg, err
+	}
+	if opts.Format == "benchstat" {
+		return report.benchstat(), StatusOK, nil
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(data), StatusOK, nil
+}
+
+// benchmarkMatrixGo runs the cells of RunBenchmarkMatrix, algorithm by algorithm
+func benchmarkMatrixGo(names []string, nValues []uint64, opts benchOptions) (benchReport, error) {
+	switch {
+	case opts.MeasureIters == 0:
+		return benchReport{}, errors.New("options: measure_iters must be >= 1")
+	case opts.Count == 0:
+		return benchReport{}, errors.New("options: count must be >= 1")
+	case opts.WarmupIters > maxBenchIters || opts.MeasureIters > maxBenchIters:
+		return benchReport{}, fmt.Errorf("%w: warmup_iters and measure_iters must be <= %d", errBenchLimit, maxBenchIters)
+	case opts.Format != "json" && opts.Format != "benchstat":
+		return benchReport{}, fmt.Errorf("options: format must be \"json\" or \"benchstat\", got %q", opts.Format)
+	}
+	registry := registeredAlgorithms()
+	selected := registry
+	if names != nil {
+		selected = make([]algorithmInfo, 0, len(names))
+		for _, name := range names {
+			i := slices.IndexFunc(registry, func(a algorithmInfo) bool { return a.Name == name })
+			if i < 0 {
+				return benchReport{}, fmt.Errorf("algorithms: unknown algorithm %q", name)
+			}
+			selected = append(selected, registry[i])
+		}
+	}
+
+	// Divided rather than multiplied, so the product cannot overflow
+	cells := uint64(len(selected)) * uint64(len(nValues))
+	if opts.Count > maxBenchCells || cells > maxBenchCells/opts.Count {
+		return benchReport{}, fmt.Errorf("%w: algorithms x n_values x count must be <= %d", errBenchLimit, maxBenchCells)
+	}
+	report := benchReport{Options: opts, Results: make([]benchRecord, 0, cells*opts.Count)}
+	for _, algo := range selected {
+		for _, n := range nValues {
+			for range opts.Count {
+				report.Results = append(report.Results, benchmarkCell(algo, n, opts))
+			}
+		}
+	}
+	return report, nil
+}
+
+// benchstat renders the report in the text format of `go test -bench -benchmem`
+// Each line reads e.g. "BenchmarkFibDoubling/92-8  100  95.20 ns/op  0 B/op  0 allocs/op",
+// the -8 suffix being GOMAXPROCS as in go test, which omits it for 1, so
+// benchstat can diff it against native runs.
+func (r benchReport) benchstat() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "goos: %s\ngoarch: %s\npkg: github.com/agbru/FibBenchmark/crates/fib-go/go\n", runtime.GOOS, runtime.GOARCH)
+	procs := ""
+	if p := runtime.GOMAXPROCS(0); p > 1 {
+		procs = fmt.Sprintf("-%d", p)
+	}
+	for _, rec := range r.Results {
+		if rec.Status != int32(StatusOK) {
+			continue
+		}
+		fmt.Fprintf(&b, "BenchmarkFib%s/%d%s\t%8d\t%12.2f ns/op\t%8.0f B/op\t%8.0f allocs/op\n",
+			benchName(rec.Algorithm), rec.N, procs, rec.Iterations, rec.MeanNS, rec.BytesPerOp, rec.AllocsPerOp)
+	}
+	return b.String()
+}
+
+// benchName turns a registry name such as doubling_iter into DoublingIter
+func benchName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// benchmarkCell benchmarks algo at n; a refused cell keeps its status and no timings
+func benchmarkCell(algo algorithmInfo, n uint64, opts benchOptions) benchRecord {
+	rec := benchRecord{Algorithm: algo.Name, ID: int32(algo.ID), N: n}
+	stats, status := runBenchmarkGo(algo, n, opts)
+	rec.Status = int32(status)
+	if status != StatusOK {
+		return rec
+	}
+	rec.Iterations = len(stats.samples)
+	rec.MinNS = stats.min.Nanoseconds()
+	rec.MaxNS = stats.max.Nanoseconds()
+	rec.MeanNS = stats.mean
+	rec.StddevNS = stats.stddev
+	rec.P50NS = stats.p50.Nanoseconds()
+	rec.P90NS = stats.p90.Nanoseconds()
+	rec.P99NS = stats.p99.Nanoseconds()
+	rec.P999NS = stats.p999.Nanoseconds()
+	rec.Histogram = stats.buckets()
+	rec.memDelta = stats.mem
+	if opts.ColdStart {
+		rec.ColdStart = &stats.cold
+	}
+	rec.CPUTime = stats.cpu
+	rec.PerfCounters, rec.PerfError = stats.perf, stats.perfErr
+	rec.PinError = stats.pinErr
+	return rec
+}
+
+// decodeStrict decodes a JSON document into v, rejecting unknown object keys and trailing data
+func decodeStrict(doc string, v any) error {
+	dec := json.NewDecoder(bytes.NewReader([]byte(doc)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("trailing data after the JSON value")
+	}
+	return nil
+}
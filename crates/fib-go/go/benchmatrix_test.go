@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBenchmarkMatrixLimits(t *testing.T) {
+	opts := defaultBenchOptions
+	opts.WarmupIters, opts.MeasureIters = 1, 3
+	report, err := benchmarkMatrixGo([]string{"doubling", "iterative"}, []uint64{10, 90}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Results) != 4 {
+		t.Errorf("%d records, want 4", len(report.Results))
+	}
+
+	for name, change := range map[string]func(*benchOptions){
+		"measure_iters":  func(o *benchOptions) { o.MeasureIters = maxBenchIters + 1 },
+		"warmup_iters":   func(o *benchOptions) { o.WarmupIters = maxBenchIters + 1 },
+		"count":          func(o *benchOptions) { o.Count = math.MaxUint64 },
+		"count x cells":  func(o *benchOptions) { o.Count = maxBenchCells/2 + 1 },
+		"count overflow": func(o *benchOptions) { o.Count = 1 << 62 },
+	} {
+		o := opts
+		change(&o)
+		if _, err := benchmarkMatrixGo([]string{"doubling", "iterative"}, []uint64{10}, o); !errors.Is(err, errBenchLimit) {
+			t.Errorf("%s: error %v, want errBenchLimit", name, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// FibBigIterative calculates Fibonacci with math/big using iterative method - O(n)
+// Returns the decimal representation as a C string owned by the caller;
+// release it with FreeCString.
+//
+//export FibBigIterative
+func FibBigIterative(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	return C.CString(tracedBig("FibBigIterative", "big_iterative", uint64(n), fib.BigIterative).String())
+}
+
+// FibBigMatrix calculates Fibonacci with math/big using matrix exponentiation - O(log n)
+// Returns a decimal C string owned by the caller; release it with FreeCString.
+//
+//export FibBigMatrix
+func FibBigMatrix(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	return C.CString(tracedBig("FibBigMatrix", "big_matrix", uint64(n), fib.BigMatrix).String())
+}
+
+// FibBigDoubling calculates Fibonacci with math/big using the doubling method - O(log n)
+// Returns a decimal C string owned by the caller; release it with FreeCString.
+//
+//export FibBigDoubling
+func FibBigDoubling(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	return C.CString(tracedBig("FibBigDoubling", "big_doubling", uint64(n), bigDoublingReported).String())
+}
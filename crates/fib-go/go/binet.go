@@ -0,0 +1,47 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"math/big"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibBinet calculates F(n) with Binet's formula round(phi^n / sqrt(5)) in float64 - O(1)
+// Stores the result into out. Returns StatusOK while it is exact (n <= 75),
+// StatusInexact past that accuracy cliff, where out holds the nearest float64
+// approximation, StatusOverflow once F(n) exceeds the float64 range (n > 1476)
+// and StatusInvalidArg for a NULL out.
+//
+//export FibBinet
+func FibBinet(n C.uint64_t, out *C.double) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if out == nil {
+		return StatusInvalidArg
+	}
+	if uint64(n) > fib.MaxBinetN {
+		return StatusOverflow
+	}
+	*out = C.double(fib.Binet(uint64(n)))
+	if uint64(n) > fib.MaxExactBinetN {
+		return StatusInexact
+	}
+	return StatusOK
+}
+
+// FibBinetBig calculates F(n) with Binet's formula in math/big.Float at precisionBits of mantissa
+// The result is exact from about 0.7n + 64 bits; precisionBits == 0 selects
+// enough for every n, while lower values show how the rounded approximation
+// drifts from F(n). Returns a decimal C string owned by the caller; release it with FreeCString.
+//
+//export FibBinetBig
+func FibBinetBig(n, precisionBits C.uint64_t) *C.char {
+	defer recoverPanic()
+	prec := uint(min(uint64(precisionBits), big.MaxPrec))
+	return C.CString(tracedBig("FibBinetBig", "big_binet", uint64(n), func(n uint64) *big.Int { return fib.BigBinet(n, prec) }).String())
+}
@@ -0,0 +1,427 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+#include <stdlib.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"runtime"
+	"unsafe"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// Every char* export has a *Buf twin following the two-call protocol: call it with
+// a NULL buffer to learn the required size (including the NUL terminator), then
+// again with a caller-allocated buffer of at least that size. Nothing is written
+// when the buffer is too small, and 0 means there is no result. The buffer
+// variants never hand memory across the FFI boundary, so no FreeCString is needed.
+// Results are otherwise recomputed on each call: a snapshot may need more room the
+// second time, which the size returned then reports. The twins of benchmarks and
+// long searches instead keep the document of a sizing call for the follow-up
+// call (see pendingBuffer), so the work is not done twice. genheader refuses a
+// char* export without its twin, named after it unless listed in bufferTwins.
+
+// copyToBuffer writes s NUL-terminated into buf if it fits and returns the size required
+func copyToBuffer(s string, buf *C.char, length C.size_t) C.size_t {
+	needed := C.size_t(len(s) + 1)
+	if buf != nil && length >= needed {
+		dst := unsafe.Slice((*byte)(unsafe.Pointer(buf)), needed)
+		dst[copy(dst, s)] = 0
+	}
+	return needed
+}
+
+// documentCString hands doc to the caller as a C string, or records err under status and returns NULL
+func documentCString(doc string, status C.fib_status, err error) *C.char {
+	if status != StatusOK {
+		setLastError(C.int32_t(status), err.Error())
+		return nil
+	}
+	return C.CString(doc)
+}
+
+// documentBuffer is documentCString for the *Buf twins: doc goes to buf, a failure returns 0
+func documentBuffer(doc string, status C.fib_status, err error, buf *C.char, length C.size_t) C.size_t {
+	if status != StatusOK {
+		setLastError(C.int32_t(status), err.Error())
+		return 0
+	}
+	return copyToBuffer(doc, buf, length)
+}
+
+// FibDecimalStringBuf is the caller-allocated variant of FibDecimalString
+//
+//export FibDecimalStringBuf
+func FibDecimalStringBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(tracedBig("FibDecimalStringBuf", "big_doubling", uint64(n), bigDoublingReported).String(), buf, length)
+}
+
+// FibBigIterativeBuf is the caller-allocated variant of FibBigIterative
+//
+//export FibBigIterativeBuf
+func FibBigIterativeBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(tracedBig("FibBigIterativeBuf", "big_iterative", uint64(n), fib.BigIterative).String(), buf, length)
+}
+
+// FibBigMatrixBuf is the caller-allocated variant of FibBigMatrix
+//
+//export FibBigMatrixBuf
+func FibBigMatrixBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(tracedBig("FibBigMatrixBuf", "big_matrix", uint64(n), fib.BigMatrix).String(), buf, length)
+}
+
+// FibBigDoublingBuf is the caller-allocated variant of FibBigDoubling
+//
+//export FibBigDoublingBuf
+func FibBigDoublingBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(tracedBig("FibBigDoublingBuf", "big_doubling", uint64(n), bigDoublingReported).String(), buf, length)
+}
+
+// FibBigKBuf is the caller-allocated variant of FibBigK; returns 0 for k == 0 or k > 256
+//
+//export FibBigKBuf
+func FibBigKBuf(k, n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	if k == 0 || k > fib.MaxK {
+		return 0
+	}
+	return copyToBuffer(tracedBig("FibBigKBuf", "big_k", uint64(n), func(n uint64) *big.Int { return fib.BigK(int(k), n) }).String(), buf, length)
+}
+
+// BigToDecimalBuf is the caller-allocated variant of BigToDecimalString; returns 0 for an invalid handle
+//
+//export BigToDecimalBuf
+func BigToDecimalBuf(h C.uintptr_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	x := bigFromHandle(h)
+	if x == nil {
+		return 0
+	}
+	return copyToBuffer(x.String(), buf, length)
+}
+
+// FibLeadingDigitsBuf is the caller-allocated variant of FibLeadingDigits; returns 0 where it returns NULL
+//
+//export FibLeadingDigitsBuf
+func FibLeadingDigitsBuf(n, k C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	doc, status, err := leadingDigitsDocument(n, k)
+	return documentBuffer(doc, status, err, buf, length)
+}
+
+// FibLastDigitsBuf is the caller-allocated variant of FibLastDigits; returns 0 where it returns NULL
+//
+//export FibLastDigitsBuf
+func FibLastDigitsBuf(n, k C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	doc, status, err := lastDigitsDocument(n, k)
+	return documentBuffer(doc, status, err, buf, length)
+}
+
+// GetGoVersionBuf is the caller-allocated variant of GetGoVersion
+//
+//export GetGoVersionBuf
+func GetGoVersionBuf(buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(runtime.Version(), buf, length)
+}
+
+// GetBuildInfoBuf is the caller-allocated variant of GetBuildInfo
+//
+//export GetBuildInfoBuf
+func GetBuildInfoBuf(buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	data, err := buildInfoJSON()
+	if err != nil {
+		return 0
+	}
+	return copyToBuffer(string(data), buf, length)
+}
+
+// GetLastErrorStringBuf is the caller-allocated variant of GetLastErrorString; returns 0 if there is no error
+//
+//export GetLastErrorStringBuf
+func GetLastErrorStringBuf(buf *C.char, length C.size_t) C.size_t {
+	message := lastErrorMessage()
+	if message == nil {
+		return 0
+	}
+	defer C.free(unsafe.Pointer(message))
+	return copyToBuffer(C.GoString(message), buf, length)
+}
+
+// ListAlgorithmsBuf is the caller-allocated variant of ListAlgorithms
+//
+//export ListAlgorithmsBuf
+func ListAlgorithmsBuf(buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	data, err := algorithmsJSON()
+	if err != nil {
+		return 0
+	}
+	return copyToBuffer(string(data), buf, length)
+}
+
+// GetEffectiveConfigBuf is the caller-allocated variant of GetEffectiveConfig
+//
+//export GetEffectiveConfigBuf
+func GetEffectiveConfigBuf(buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	data, err := effectiveConfigJSON()
+	if err != nil {
+		return 0
+	}
+	return copyToBuffer(string(data), buf, length)
+}
+
+// RunBenchmarkJSONBuf is the caller-allocated variant of RunBenchmarkJSON; returns 0 where it returns NULL
+// The sizing call runs the benchmark and keeps its document for the follow-up
+// call with the same arguments on the same thread, so the samples are recorded once.
+//
+//export RunBenchmarkJSONBuf
+func RunBenchmarkJSONBuf(algorithmID C.fib_algorithm, n, warmupIters, measureIters C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	key := fmt.Sprintf("RunBenchmarkJSON %d %d %d %d", algorithmID, n, warmupIters, measureIters)
+	return pendingBuffer(key, buf, length, func() (string, C.fib_status, error) {
+		return benchmarkDocument(algorithmID, n, warmupIters, measureIters)
+	})
+}
+
+// RunBenchmarkMatrixBuf is the caller-allocated variant of RunBenchmarkMatrix; returns 0 where it returns NULL
+// The sizing call runs the matrix and keeps its document for the follow-up call
+// with the same arguments on the same thread, so the samples are recorded once.
+//
+//export RunBenchmarkMatrixBuf
+func RunBenchmarkMatrixBuf(algorithmsJSON, nValuesJSON, optionsJSON *C.char, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	key := fmt.Sprintf("RunBenchmarkMatrix %q %q %q", C.GoString(algorithmsJSON), C.GoString(nValuesJSON), C.GoString(optionsJSON))
+	return pendingBuffer(key, buf, length, func() (string, C.fib_status, error) {
+		return benchmarkMatrixDocument(algorithmsJSON, nValuesJSON, optionsJSON)
+	})
+}
+
+// QueryRunsBuf is the caller-allocated variant of QueryRuns; returns 0 where it returns NULL
+//
+//export QueryRunsBuf
+func QueryRunsBuf(path, revision, host *C.char, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	doc, status, err := queryRunsDocument(path, revision, host)
+	return documentBuffer(doc, status, err, buf, length)
+}
+
+// CompareToBaselineBuf is the caller-allocated variant of CompareToBaseline; returns 0 where it returns NULL
+// The sizing call reruns the baseline and keeps its document for the follow-up
+// call with the same arguments on the same thread.
+//
+//export CompareToBaselineBuf
+func CompareToBaselineBuf(baselinePath *C.char, thresholdPct C.double, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	key := fmt.Sprintf("CompareToBaseline %q %v", C.GoString(baselinePath), float64(thresholdPct))
+	return pendingBuffer(key, buf, length, func() (string, C.fib_status, error) {
+		return baselineDocument(baselinePath, thresholdPct)
+	})
+}
+
+// GetHostFingerprintBuf is the caller-allocated variant of GetHostFingerprint
+//
+//export GetHostFingerprintBuf
+func GetHostFingerprintBuf(buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	data, err := json.Marshal(hostFingerprintGo())
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return 0
+	}
+	return copyToBuffer(string(data), buf, length)
+}
+
+// GetGCStatsBuf is the caller-allocated variant of GetGCStats
+// Each call takes a new snapshot, so the size needed may change between the two calls.
+//
+//export GetGCStatsBuf
+func GetGCStatsBuf(buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	data, err := json.Marshal(readGCStats())
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return 0
+	}
+	return copyToBuffer(string(data), buf, length)
+}
+
+// GetTelemetryJSONBuf is the caller-allocated variant of GetTelemetryJSON
+// Each call takes a new snapshot, so the size needed may change between the two calls.
+//
+//export GetTelemetryJSONBuf
+func GetTelemetryJSONBuf(buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	data, err := json.Marshal(telemetry())
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return 0
+	}
+	return copyToBuffer(string(data), buf, length)
+}
+
+// GetHealthJSONBuf is the caller-allocated variant of GetHealthJSON
+// Each call runs the health check again, so the size needed may change between the two calls.
+//
+//export GetHealthJSONBuf
+func GetHealthJSONBuf(buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	data, err := json.Marshal(health())
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return 0
+	}
+	return copyToBuffer(string(data), buf, length)
+}
+
+// RenderReportBuf is the caller-allocated variant of RenderReport; returns 0 where it returns NULL
+//
+//export RenderReportBuf
+func RenderReportBuf(path, revision, host, format *C.char, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	doc, status, err := reportDocument(path, revision, host, format)
+	return documentBuffer(doc, status, err, buf, length)
+}
+
+// VerifyVectorsBuf is the caller-allocated variant of VerifyVectors; returns 0 where it returns NULL
+//
+//export VerifyVectorsBuf
+func VerifyVectorsBuf(algorithm *C.char, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	doc, status, err := vectorsDocument(algorithm)
+	return documentBuffer(doc, status, err, buf, length)
+}
+
+// SelfTestBuf is the caller-allocated variant of SelfTest; returns 0 where it returns NULL
+// The sizing call runs the self-test and keeps its document for the follow-up
+// call with the same arguments on the same thread.
+//
+//export SelfTestBuf
+func SelfTestBuf(iterations, seed C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	key := fmt.Sprintf("SelfTest %d %d", iterations, seed)
+	return pendingBuffer(key, buf, length, func() (string, C.fib_status, error) {
+		return selfTestDocument(iterations, seed)
+	})
+}
+
+// VerifyIdentitiesBuf is the caller-allocated variant of VerifyIdentities; returns 0 where it returns NULL
+//
+//export VerifyIdentitiesBuf
+func VerifyIdentitiesBuf(maxN C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	doc, status, err := identitiesDocument(maxN)
+	return documentBuffer(doc, status, err, buf, length)
+}
+
+// FibBigSumBuf is the caller-allocated variant of FibBigSum
+//
+//export FibBigSumBuf
+func FibBigSumBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(tracedBig("FibBigSumBuf", "big_sum", uint64(n), fib.BigSum).String(), buf, length)
+}
+
+// FibBigSumEvenBuf is the caller-allocated variant of FibBigSumEven
+//
+//export FibBigSumEvenBuf
+func FibBigSumEvenBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(tracedBig("FibBigSumEvenBuf", "big_sum_even", uint64(n), fib.BigSumEven).String(), buf, length)
+}
+
+// FibBigSumOddBuf is the caller-allocated variant of FibBigSumOdd
+//
+//export FibBigSumOddBuf
+func FibBigSumOddBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(tracedBig("FibBigSumOddBuf", "big_sum_odd", uint64(n), fib.BigSumOdd).String(), buf, length)
+}
+
+// FibBigSumSquaresBuf is the caller-allocated variant of FibBigSumSquares
+//
+//export FibBigSumSquaresBuf
+func FibBigSumSquaresBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	return copyToBuffer(tracedBig("FibBigSumSquaresBuf", "big_sum_squares", uint64(n), fib.BigSumSquares).String(), buf, length)
+}
+
+// FibBigGCDBuf is the caller-allocated variant of FibBigGCD; returns 0 where it returns NULL
+//
+//export FibBigGCDBuf
+func FibBigGCDBuf(m, n C.uint64_t, verify C.int32_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	doc, status, err := bigGCDDocument("FibBigGCDBuf", m, n, verify)
+	return documentBuffer(doc, status, err, buf, length)
+}
+
+// FibBinetBigBuf is the caller-allocated variant of FibBinetBig
+//
+//export FibBinetBigBuf
+func FibBinetBigBuf(n, precisionBits C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	prec := uint(min(uint64(precisionBits), big.MaxPrec))
+	return copyToBuffer(tracedBig("FibBinetBigBuf", "big_binet", uint64(n), func(n uint64) *big.Int { return fib.BigBinet(n, prec) }).String(), buf, length)
+}
+
+// GoldenRatioDigitsBuf is the caller-allocated variant of GoldenRatioDigits; returns 0 where it returns NULL
+//
+//export GoldenRatioDigitsBuf
+func GoldenRatioDigitsBuf(k C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	if k > maxGoldenRatioDigits {
+		setLastError(C.int32_t(StatusLimitExceeded), fmt.Sprintf("GoldenRatioDigitsBuf: k must be <= %d", maxGoldenRatioDigits))
+		return 0
+	}
+	return copyToBuffer(fib.GoldenRatioDigits(uint64(k)), buf, length)
+}
+
+// FibContinuedFractionBuf is the caller-allocated variant of FibContinuedFraction; returns 0 where it returns NULL
+//
+//export FibContinuedFractionBuf
+func FibContinuedFractionBuf(depth C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	doc, status, err := continuedFractionDocument(depth)
+	return documentBuffer(doc, status, err, buf, length)
+}
+
+// FibonacciPrimalityWitnessBuf is the caller-allocated variant of FibonacciPrimalityWitness
+//
+//export FibonacciPrimalityWitnessBuf
+func FibonacciPrimalityWitnessBuf(n C.uint64_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	data, err := json.Marshal(primalityWitnessGo(uint64(n)))
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return 0
+	}
+	return copyToBuffer(string(data), buf, length)
+}
+
+// SearchWallSunSunBuf is the caller-allocated variant of SearchWallSunSun; returns 0 where it returns NULL
+// The sizing call searches the range and keeps its document for the follow-up
+// call with the same range on the same thread.
+//
+//export SearchWallSunSunBuf
+func SearchWallSunSunBuf(startPrime, endPrime C.uint64_t, workers C.uint32_t, buf *C.char, length C.size_t) C.size_t {
+	defer recoverPanic()
+	key := fmt.Sprintf("SearchWallSunSun %d %d %d", startPrime, endPrime, workers)
+	return pendingBuffer(key, buf, length, func() (string, C.fib_status, error) {
+		return wallSunSunDocument(startPrime, endPrime, workers)
+	})
+}
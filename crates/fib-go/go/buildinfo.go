@@ -0,0 +1,73 @@
+package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"runtime"
+	"runtime/debug"
+)
+
+// BuildInfo describes the toolchain and sources this library was built from
+type BuildInfo struct {
+	GoVersion   string            `json:"go_version"`
+	GOOS        string            `json:"goos"`
+	GOARCH      string            `json:"goarch"`
+	Compiler    string            `json:"compiler"`
+	Module      string            `json:"module,omitempty"`
+	VCSRevision string            `json:"vcs_revision,omitempty"`
+	VCSTime     string            `json:"vcs_time,omitempty"`
+	VCSModified bool              `json:"vcs_modified"`
+	Settings    map[string]string `json:"settings"`
+}
+
+// collectBuildInfo gathers runtime facts plus the build settings embedded by the toolchain
+// (-buildmode, CGO_ENABLED, CGO_CFLAGS, GOAMD64, vcs.* ...)
+func collectBuildInfo() BuildInfo {
+	info := BuildInfo{
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		Compiler:  runtime.Compiler,
+		Settings:  map[string]string{},
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Module = bi.Main.Path
+	for _, s := range bi.Settings {
+		info.Settings[s.Key] = s.Value
+		switch s.Key {
+		case "vcs.revision":
+			info.VCSRevision = s.Value
+		case "vcs.time":
+			info.VCSTime = s.Value
+		case "vcs.modified":
+			info.VCSModified = s.Value == "true"
+		}
+	}
+	return info
+}
+
+// GetBuildInfo returns a JSON document describing the toolchain, platform, cgo flags and VCS revision
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export GetBuildInfo
+func GetBuildInfo() *C.char {
+	defer recoverPanic()
+	data, err := buildInfoJSON()
+	if err != nil {
+		return nil
+	}
+	return C.CString(string(data))
+}
+
+// buildInfoJSON encodes the build metadata returned by GetBuildInfo
+func buildInfoJSON() ([]byte, error) {
+	return json.Marshal(collectBuildInfo())
+}
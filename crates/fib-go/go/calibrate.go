@@ -0,0 +1,62 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// CalibrateThresholds times iteration, matrices and doubling on this host and installs the crossovers for FibAuto
+// It takes well under a second. out, if not NULL, receives the measured crossovers.
+//
+//export CalibrateThresholds
+func CalibrateThresholds(out *C.fib_thresholds) (status C.fib_status) {
+	defer recoverStatus(&status)
+	t := fib.Calibrate()
+	fib.SetAutoThresholds(t)
+	if out != nil {
+		*out = cThresholds(t)
+	}
+	return StatusOK
+}
+
+// GetThresholds stores the crossovers in force for FibAuto in out
+//
+//export GetThresholds
+func GetThresholds(out *C.fib_thresholds) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if out == nil {
+		return StatusInvalidArg
+	}
+	*out = cThresholds(fib.AutoThresholds())
+	return StatusOK
+}
+
+// SetThresholds installs crossovers for FibAuto, e.g. ones saved from an earlier CalibrateThresholds
+//
+//export SetThresholds
+func SetThresholds(in *C.fib_thresholds) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if in == nil {
+		return StatusInvalidArg
+	}
+	fib.SetAutoThresholds(fib.Thresholds{
+		Iterative128MaxN: uint64(in.iterative128_max_n),
+		Matrix128MaxN:    uint64(in.matrix128_max_n),
+		BigIterativeMaxN: uint64(in.big_iterative_max_n),
+		BigMatrixMaxN:    uint64(in.big_matrix_max_n),
+	})
+	return StatusOK
+}
+
+// cThresholds converts t to its C layout
+func cThresholds(t fib.Thresholds) C.fib_thresholds {
+	return C.fib_thresholds{
+		iterative128_max_n:  C.uint64_t(t.Iterative128MaxN),
+		matrix128_max_n:     C.uint64_t(t.Matrix128MaxN),
+		big_iterative_max_n: C.uint64_t(t.BigIterativeMaxN),
+		big_matrix_max_n:    C.uint64_t(t.BigMatrixMaxN),
+	}
+}
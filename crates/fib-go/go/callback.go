@@ -0,0 +1,50 @@
+package main
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+#include "fib_types.h"
+
+// Go cannot call C function pointers directly; these trampolines do it.
+// They live in a file without exports, where the preamble may hold definitions.
+
+static uint64_t fib_call_algorithm(fib_algorithm_fn fn, uint64_t n) {
+	return fn(n);
+}
+
+static void fib_call_progress(fib_progress_fn fn, uint64_t done, uint64_t total, void *userdata) {
+	fn(done, total, userdata);
+}
+
+static void fib_call_job(fib_job_callback fn, uint64_t id, fib_status status, uint64_t result, void *userdata) {
+	fn(id, status, result, userdata);
+}
+
+static void fib_call_log(fib_log_fn fn, fib_log_level level, char *line, void *userdata) {
+	fn(level, line, userdata);
+	free(line);
+}
+*/
+import "C"
+
+import "unsafe"
+
+// callAlgorithmFn invokes an algorithm supplied by RegisterAlgorithm
+func callAlgorithmFn(fn C.fib_algorithm_fn, n uint64) uint64 {
+	return uint64(C.fib_call_algorithm(fn, C.uint64_t(n)))
+}
+
+// callProgressFn invokes a callback supplied by SetProgressCallback
+func callProgressFn(fn C.fib_progress_fn, done, total int, userdata unsafe.Pointer) {
+	C.fib_call_progress(fn, C.uint64_t(done), C.uint64_t(total), userdata)
+}
+
+// callLogFn invokes a sink supplied by SetLogCallback
+func callLogFn(fn C.fib_log_fn, level int, line string, userdata unsafe.Pointer) {
+	C.fib_call_log(fn, C.fib_log_level(level), C.CString(line), userdata)
+}
+
+// callJobFn invokes a completion callback supplied by FibSubmit
+func callJobFn(fn C.fib_job_callback, id uint64, status C.fib_status, result uint64, userdata unsafe.Pointer) {
+	C.fib_call_job(fn, C.uint64_t(id), status, C.uint64_t(result), userdata)
+}
@@ -0,0 +1,321 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"runtime/cgo"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// cancelToken is the value behind a handle returned by CancelTokenNew
+type cancelToken struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// tokenFromHandle resolves a handle returned by CancelTokenNew, or nil for an invalid one
+func tokenFromHandle(token C.uintptr_t) *cancelToken {
+	if token == 0 {
+		return nil
+	}
+	t, _ := cgo.Handle(token).Value().(*cancelToken)
+	return t
+}
+
+// callTimeoutMS is the deadline applied to every cancellable call, set by timeout_ms; 0 disables it
+var callTimeoutMS atomic.Uint64
+
+// millis converts a millisecond count to a Duration, saturating instead of overflowing
+func millis(ms uint64) time.Duration {
+	return time.Duration(min(ms, math.MaxInt64/uint64(time.Millisecond))) * time.Millisecond
+}
+
+// callContext resolves the optional cancel token of a call (0 never cancels) and applies timeout_ms
+// ok is false for a non-zero handle that is not a cancel token; otherwise cancel must be called.
+func callContext(token C.uintptr_t) (ctx context.Context, cancel context.CancelFunc, ok bool) {
+	ctx = context.Background()
+	if token != 0 {
+		t := tokenFromHandle(token)
+		if t == nil {
+			return nil, nil, false
+		}
+		ctx = t.ctx
+	}
+	if ms := callTimeoutMS.Load(); ms > 0 {
+		ctx, cancel = context.WithTimeout(ctx, millis(ms))
+		return ctx, cancel, true
+	}
+	return ctx, func() {}, true
+}
+
+// CancelTokenNew returns a cancel token for the *WithCancel exports
+// Release it with CancelTokenFree once no call uses it any more.
+//
+//export CancelTokenNew
+func CancelTokenNew() C.uintptr_t {
+	defer recoverPanic()
+	ctx, cancel := context.WithCancel(context.Background())
+	return C.uintptr_t(cgo.NewHandle(&cancelToken{ctx, cancel}))
+}
+
+// CancelTokenNewWithTimeout returns a cancel token that also expires timeoutMs milliseconds from now
+// Calls using it return StatusTimeout past the deadline, or StatusCancelled if it is cancelled first.
+//
+//export CancelTokenNewWithTimeout
+func CancelTokenNewWithTimeout(timeoutMs C.uint64_t) C.uintptr_t {
+	defer recoverPanic()
+	ctx, cancel := context.WithTimeout(context.Background(), millis(uint64(timeoutMs)))
+	return C.uintptr_t(cgo.NewHandle(&cancelToken{ctx, cancel}))
+}
+
+// CancelTokenCancel cancels a token; calls using it return StatusCancelled at their next check
+// It may be called from any thread, including while a call using the token is running.
+// Returns StatusInvalidArg for an invalid token.
+//
+//export CancelTokenCancel
+func CancelTokenCancel(token C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	t := tokenFromHandle(token)
+	if t == nil {
+		return StatusInvalidArg
+	}
+	t.cancel()
+	return StatusOK
+}
+
+// CancelTokenFree releases a token returned by CancelTokenNew
+//
+//export CancelTokenFree
+func CancelTokenFree(token C.uintptr_t) {
+	defer recoverPanic()
+	t := tokenFromHandle(token)
+	if t == nil {
+		return
+	}
+	t.cancel()
+	cgo.Handle(token).Delete()
+}
+
+// FibBigComputeWithCancel is FibBigCompute checking a cancel token between doubling steps
+// result receives the handle to release with BigFree. token may be 0.
+// Returns StatusCancelled once the token is cancelled, StatusTimeout past its deadline or
+// timeout_ms, and StatusInvalidArg for an invalid token or NULL result.
+//
+//export FibBigComputeWithCancel
+func FibBigComputeWithCancel(n C.uint64_t, token C.uintptr_t, result *C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return bigWithCancel("FibBigComputeWithCancel", "big_doubling", uint64(n), token, result, func(ctx context.Context) (*big.Int, error) {
+		return bigDoublingGo(ctx, uint64(n))
+	})
+}
+
+// FibBigStepWithCancel is FibBigStep checking a cancel token before every step
+// A cancelled computation keeps the steps already taken and can be checkpointed or resumed.
+//
+//export FibBigStepWithCancel
+func FibBigStepWithCancel(h C.uintptr_t, steps C.uint64_t, token C.uintptr_t, result *C.uintptr_t, remaining *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	ch := computationFromHandle(h)
+	if ch == nil || result == nil {
+		return StatusInvalidArg
+	}
+	ctx, cancel, ok := callContext(token)
+	if !ok {
+		return StatusInvalidArg
+	}
+	defer cancel()
+	ch.Lock()
+	defer ch.Unlock()
+	*result = 0
+	done, err := ch.c.RunContext(ctx, int(min(steps, 64)))
+	if remaining != nil {
+		*remaining = C.uint64_t(ch.c.Remaining())
+	}
+	if err != nil {
+		return statusOf(err)
+	}
+	if done {
+		*result = C.uintptr_t(cgo.NewHandle(ch.c.Result()))
+	}
+	return StatusOK
+}
+
+// batchCheckInterval is the number of indices FibBatchWithCancel computes between token checks
+const batchCheckInterval = 256
+
+// FibBatchWithCancel is FibBatch checking a cancel token every 256 indices
+// On StatusCancelled the results computed so far are left in place.
+//
+//export FibBatchWithCancel
+func FibBatchWithCancel(algorithmID C.fib_algorithm, nValues *C.uint64_t, count C.size_t, results *C.uint64_t, token C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	fn := algorithmFunc(algorithmID)
+	if fn == nil {
+		return StatusInvalidArg
+	}
+	if count == 0 {
+		return StatusOK
+	}
+	if nValues == nil || results == nil {
+		return StatusInvalidArg
+	}
+	ctx, cancel, ok := callContext(token)
+	if !ok {
+		return StatusInvalidArg
+	}
+	defer cancel()
+
+	in := unsafe.Slice((*uint64)(unsafe.Pointer(nValues)), count)
+	out := unsafe.Slice((*uint64)(unsafe.Pointer(results)), count)
+	for i, n := range in {
+		if i%batchCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return statusOf(err)
+			}
+		}
+		value, status := computeAlgorithm(algorithmID, fn, n)
+		if status != StatusOK {
+			return status
+		}
+		out[i] = value
+	}
+	return StatusOK
+}
+
+// bigWithCancel runs compute under the context of token and hands its result out as a handle
+// It is the shared body of the big-integer *WithCancel exports, and times the
+// call in the span of export.
+func bigWithCancel(export, algorithm string, n uint64, token C.uintptr_t, result *C.uintptr_t, compute func(context.Context) (*big.Int, error)) C.fib_status {
+	if result == nil {
+		return StatusInvalidArg
+	}
+	ctx, cancel, ok := callContext(token)
+	if !ok {
+		return StatusInvalidArg
+	}
+	defer cancel()
+	span := startSpan(export, algorithm, n)
+	x, err := compute(ctx)
+	if err != nil {
+		span.end(0, statusOf(err))
+		return statusOf(err)
+	}
+	span.end(x.BitLen(), StatusOK)
+	*result = C.uintptr_t(cgo.NewHandle(x))
+	return StatusOK
+}
+
+// FibBigIterativeWithCancel is FibBigIterative checking a cancel token every 1024 additions
+// result receives a handle to release with BigFree instead of a string; token may be 0.
+// Statuses are those of FibBigComputeWithCancel.
+//
+//export FibBigIterativeWithCancel
+func FibBigIterativeWithCancel(n C.uint64_t, token C.uintptr_t, result *C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return bigWithCancel("FibBigIterativeWithCancel", "big_iterative", uint64(n), token, result, func(ctx context.Context) (*big.Int, error) {
+		return fib.BigIterativeContext(ctx, uint64(n))
+	})
+}
+
+// FibBigMatrixWithCancel is FibBigMatrix checking a cancel token between matrix squarings
+// result receives a handle to release with BigFree instead of a string; token may be 0.
+// Statuses are those of FibBigComputeWithCancel.
+//
+//export FibBigMatrixWithCancel
+func FibBigMatrixWithCancel(n C.uint64_t, token C.uintptr_t, result *C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return bigWithCancel("FibBigMatrixWithCancel", "big_matrix", uint64(n), token, result, func(ctx context.Context) (*big.Int, error) {
+		return fib.BigMatrixContext(ctx, uint64(n))
+	})
+}
+
+// FibBigDoublingParallelWithCancel is FibBigDoublingParallel checking a cancel token between doubling steps
+// Statuses are those of FibBigComputeWithCancel.
+//
+//export FibBigDoublingParallelWithCancel
+func FibBigDoublingParallelWithCancel(n C.uint64_t, token C.uintptr_t, result *C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return bigWithCancel("FibBigDoublingParallelWithCancel", "big_doubling_parallel", uint64(n), token, result, func(ctx context.Context) (*big.Int, error) {
+		return fib.BigDoublingParallelContext(ctx, uint64(n))
+	})
+}
+
+// FibBigDoublingSquareWithCancel is FibBigDoublingSquare checking a cancel token between doubling steps
+// Statuses are those of FibBigComputeWithCancel.
+//
+//export FibBigDoublingSquareWithCancel
+func FibBigDoublingSquareWithCancel(n C.uint64_t, token C.uintptr_t, result *C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return bigWithCancel("FibBigDoublingSquareWithCancel", "big_doubling_square", uint64(n), token, result, func(ctx context.Context) (*big.Int, error) {
+		return fib.BigDoublingSquareContext(ctx, uint64(n))
+	})
+}
+
+// FibLimbDoublingWithCancel is FibLimbDoubling checking a cancel token between doubling steps
+// Statuses are those of FibBigComputeWithCancel.
+//
+//export FibLimbDoublingWithCancel
+func FibLimbDoublingWithCancel(n C.uint64_t, token C.uintptr_t, result *C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return bigWithCancel("FibLimbDoublingWithCancel", "limb_doubling", uint64(n), token, result, func(ctx context.Context) (*big.Int, error) {
+		return fib.LimbDoublingContext(ctx, uint64(n))
+	})
+}
+
+// FibBigKWithCancel is FibBigK checking a cancel token between matrix squarings
+// result receives a handle to release with BigFree instead of a string; token may be 0.
+// Returns StatusInvalidArg for k == 0 or k > 256, otherwise the statuses of FibBigComputeWithCancel.
+//
+//export FibBigKWithCancel
+func FibBigKWithCancel(k, n C.uint64_t, token C.uintptr_t, result *C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if k == 0 || k > fib.MaxK {
+		return StatusInvalidArg
+	}
+	return bigWithCancel("FibBigKWithCancel", "big_k", uint64(n), token, result, func(ctx context.Context) (*big.Int, error) {
+		return fib.BigKContext(ctx, int(k), uint64(n))
+	})
+}
+
+// FibBatchParallelWithCancel is FibBatchParallel checking a cancel token before every chunk of 64 indices
+// On StatusCancelled or StatusTimeout the results computed so far are left in place.
+//
+//export FibBatchParallelWithCancel
+func FibBatchParallelWithCancel(algorithmID C.fib_algorithm, nValues *C.uint64_t, count C.size_t, results *C.uint64_t, workers C.uint32_t, token C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	fn := algorithmFunc(algorithmID)
+	if fn == nil {
+		return StatusInvalidArg
+	}
+	if count == 0 {
+		return StatusOK
+	}
+	if nValues == nil || results == nil {
+		return StatusInvalidArg
+	}
+	ctx, cancel, ok := callContext(token)
+	if !ok {
+		return StatusInvalidArg
+	}
+	defer cancel()
+	n := int(workers)
+	if n == 0 {
+		n = int(workerCount.Load())
+	}
+
+	in := unsafe.Slice((*uint64)(unsafe.Pointer(nValues)), count)
+	out := unsafe.Slice((*uint64)(unsafe.Pointer(results)), count)
+	return batchParallelGo(ctx, algorithmID, fn, in, out, n)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+func TestBatchParallelCancelled(t *testing.T) {
+	algo := registeredAlgorithms()[0]
+	in := make([]uint64, 10*parallelBatchChunk)
+	for i := range in {
+		in[i] = uint64(i) % fib.MaxSafeN
+	}
+
+	out := make([]uint64, len(in))
+	if status := batchParallelGo(context.Background(), algo.ID, algo.fn, in, out, 4); status != StatusOK {
+		t.Fatalf("status %d, want StatusOK", status)
+	}
+	for i, n := range in {
+		if out[i] != fib.Iterative(n) {
+			t.Fatalf("%s(%d) = %d, want %d", algo.Name, n, out[i], fib.Iterative(n))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if status := batchParallelGo(ctx, algo.ID, algo.fn, in, make([]uint64, len(in)), 4); status != StatusCancelled {
+		t.Errorf("cancelled batch: status %d, want StatusCancelled", status)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	if status := batchParallelGo(ctx, algo.ID, algo.fn, in, make([]uint64, len(in)), 4); status != StatusTimeout {
+		t.Errorf("expired batch: status %d, want StatusTimeout", status)
+	}
+}
+
+func TestBigAlgorithmsCancellable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for name := range cancellableBigAlgorithms {
+		if _, err := bigAlgorithms[name](ctx, serverUncancellableMaxN); err != context.Canceled {
+			t.Errorf("%s on a cancelled context = %v, want context.Canceled", name, err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// checkedCompute validates the arguments, then stores fn(n) into out
+func checkedCompute(n C.uint64_t, out *C.uint64_t, fn func(uint64) uint64) C.fib_status {
+	if out == nil {
+		return StatusInvalidArg
+	}
+	if uint64(n) > fib.MaxSafeN {
+		return StatusOverflow
+	}
+	*out = C.uint64_t(fn(uint64(n)))
+	return StatusOK
+}
+
+// FibCheckedIterative calculates Fibonacci iteratively, detecting overflow with carry checks
+// Stores F(n) into out and returns StatusOK, StatusOverflow or StatusInvalidArg.
+//
+//export FibCheckedIterative
+func FibCheckedIterative(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if out == nil {
+		return StatusInvalidArg
+	}
+	value, ok := fib.CheckedIterative(uint64(n))
+	if !ok {
+		return StatusOverflow
+	}
+	*out = C.uint64_t(value)
+	return StatusOK
+}
+
+// FibCheckedRecursive is the checked variant of FibRecursive
+// Returns StatusLimitExceeded above the recursion cutoff when it is configured as an error.
+//
+//export FibCheckedRecursive
+func FibCheckedRecursive(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if out == nil {
+		return StatusInvalidArg
+	}
+	if uint64(n) > fib.MaxSafeN {
+		return StatusOverflow
+	}
+	value, status := fibRecursiveSafe(uint64(n))
+	if status != StatusOK {
+		return status
+	}
+	*out = C.uint64_t(value)
+	return StatusOK
+}
+
+// FibCheckedMemo is the checked variant of FibMemo
+//
+//export FibCheckedMemo
+func FibCheckedMemo(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return checkedCompute(n, out, fib.Memo)
+}
+
+// FibCheckedMatrix is the checked variant of FibMatrix
+//
+//export FibCheckedMatrix
+func FibCheckedMatrix(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return checkedCompute(n, out, fib.Matrix)
+}
+
+// FibCheckedDoubling is the checked variant of FibDoubling
+//
+//export FibCheckedDoubling
+func FibCheckedDoubling(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return checkedCompute(n, out, fib.Doubling)
+}
+
+// FibSigned calculates F(n) for any signed index, using F(-n) = (-1)^(n+1) F(n)
+// Stores the result into out and returns StatusOK, StatusOverflow or StatusInvalidArg.
+//
+//export FibSigned
+func FibSigned(n C.int64_t, out *C.int64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if out == nil {
+		return StatusInvalidArg
+	}
+	value, ok := fib.Signed(int64(n))
+	if !ok {
+		return StatusOverflow
+	}
+	*out = C.int64_t(value)
+	return StatusOK
+}
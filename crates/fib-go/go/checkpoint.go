@@ -0,0 +1,163 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// computationHandle is the value behind a handle returned by FibBigStart or FibBigResume
+type computationHandle struct {
+	sync.Mutex
+	c *fib.BigComputation
+}
+
+// computationFromHandle resolves a computation handle, or nil for an invalid one
+func computationFromHandle(h C.uintptr_t) *computationHandle {
+	if h == 0 {
+		return nil
+	}
+	ch, _ := cgo.Handle(h).Value().(*computationHandle)
+	return ch
+}
+
+// newComputationHandle wraps c in a handle owned by the caller
+func newComputationHandle(c *fib.BigComputation) C.uintptr_t {
+	return C.uintptr_t(cgo.NewHandle(&computationHandle{c: c}))
+}
+
+// FibBigStart begins a resumable big-integer doubling computation of F(n)
+// Nothing is computed until FibBigStep; release the handle with FibBigComputationFree.
+//
+//export FibBigStart
+func FibBigStart(n C.uint64_t) C.uintptr_t {
+	defer recoverPanic()
+	return newComputationHandle(fib.NewBigComputation(uint64(n)))
+}
+
+// FibBigStep runs up to steps doubling steps of a computation (all remaining ones if steps is 0)
+// One step is taken per bit of n, the last ones being the most expensive. Once the
+// computation is done result receives a handle to F(n), as returned by FibBigCompute,
+// and 0 before. remaining, if not NULL, receives the number of steps left.
+//
+//export FibBigStep
+func FibBigStep(h C.uintptr_t, steps C.uint64_t, result *C.uintptr_t, remaining *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	ch := computationFromHandle(h)
+	if ch == nil || result == nil {
+		return StatusInvalidArg
+	}
+	ch.Lock()
+	defer ch.Unlock()
+	*result = 0
+	// n has at most 64 bits, so larger budgets are equivalent
+	if ch.c.Run(int(min(steps, 64))) {
+		*result = C.uintptr_t(cgo.NewHandle(ch.c.Result()))
+	}
+	if remaining != nil {
+		*remaining = C.uint64_t(ch.c.Remaining())
+	}
+	return StatusOK
+}
+
+// FibBigCheckpoint serializes the state of a computation into buf
+// The snapshot holds n, the bit position and F(k), F(k+1), guarded by a CRC-32.
+// Returns the number of bytes required; nothing is written if length is smaller, 0 for an invalid handle.
+//
+//export FibBigCheckpoint
+func FibBigCheckpoint(h C.uintptr_t, buf *C.uint8_t, length C.size_t) C.size_t {
+	defer recoverPanic()
+	ch := computationFromHandle(h)
+	if ch == nil {
+		return 0
+	}
+	ch.Lock()
+	data, err := ch.c.MarshalBinary()
+	ch.Unlock()
+	if err != nil {
+		setLastError(C.int32_t(statusOf(err)), err.Error())
+		return 0
+	}
+	if buf != nil && int(length) >= len(data) {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(buf)), len(data)), data)
+	}
+	return C.size_t(len(data))
+}
+
+// FibBigResume rebuilds a computation from a snapshot written by FibBigCheckpoint
+// Returns 0 and records StatusInvalidArg as the last error for truncated or corrupted data.
+//
+//export FibBigResume
+func FibBigResume(data *C.uint8_t, length C.size_t) C.uintptr_t {
+	defer recoverPanic()
+	if data == nil {
+		setLastError(C.int32_t(StatusInvalidArg), "NULL checkpoint")
+		return 0
+	}
+	// C.GoBytes takes a C int, which would truncate a length past 2 GiB
+	snapshot := bytes.Clone(unsafe.Slice((*byte)(unsafe.Pointer(data)), uint64(length)))
+	c := &fib.BigComputation{}
+	if err := c.UnmarshalBinary(snapshot); err != nil {
+		setLastError(C.int32_t(statusOf(err)), err.Error())
+		return 0
+	}
+	return newComputationHandle(c)
+}
+
+// FibBigCheckpointSave writes a snapshot of a computation to the file at path
+// The file is replaced atomically, so a crash during the save keeps the previous checkpoint.
+// Returns StatusInvalidArg for an invalid handle or NULL path and StatusInternal for I/O errors.
+//
+//export FibBigCheckpointSave
+func FibBigCheckpointSave(h C.uintptr_t, path *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	ch := computationFromHandle(h)
+	if ch == nil || path == nil {
+		return StatusInvalidArg
+	}
+	ch.Lock()
+	defer ch.Unlock()
+	if err := ch.c.SaveFile(C.GoString(path)); err != nil {
+		return failWith(statusOf(err), "checkpoint: %v", err)
+	}
+	return StatusOK
+}
+
+// FibBigResumeFile rebuilds a computation from a file written by FibBigCheckpointSave
+// Returns 0 and records the failure as the last error if the file is missing or invalid.
+//
+//export FibBigResumeFile
+func FibBigResumeFile(path *C.char) C.uintptr_t {
+	defer recoverPanic()
+	if path == nil {
+		setLastError(C.int32_t(StatusInvalidArg), "NULL checkpoint path")
+		return 0
+	}
+	c, err := fib.LoadBigComputation(C.GoString(path))
+	if err != nil {
+		setLastError(C.int32_t(statusOf(err)), err.Error())
+		return 0
+	}
+	return newComputationHandle(c)
+}
+
+// FibBigComputationFree releases a handle returned by FibBigStart or FibBigResume
+//
+//export FibBigComputationFree
+func FibBigComputationFree(h C.uintptr_t) {
+	defer recoverPanic()
+	if computationFromHandle(h) == nil {
+		return
+	}
+	cgo.Handle(h).Delete()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// defaultAlgorithm is used when --algo is not given; it is exact for every n
+const defaultAlgorithm = "auto"
+
+// recursiveMaxN is the largest n accepted by recursive, keeping it under about a second
+const recursiveMaxN = 40
+
+// uint64Algorithms are the algorithms returning F(n) modulo 2^64, by the registry names of the library
+var uint64Algorithms = map[string]func(uint64) uint64{
+	"iterative":     fib.Iterative,
+	"recursive":     fib.Recursive,
+	"memo":          fib.Memo,
+	"matrix":        fib.Matrix,
+	"doubling":      fib.Doubling,
+	"doubling_iter": fib.DoublingIter,
+	"memo_fast":     fib.MemoFast,
+	"lookup":        fib.Lookup,
+}
+
+// bigAlgorithms are the exact big-integer algorithms, by the names of the library's server modes
+var bigAlgorithms = map[string]func(uint64) *big.Int{
+	"big_iterative":         fib.BigIterative,
+	"big_matrix":            fib.BigMatrix,
+	"big_doubling":          fib.BigDoubling,
+	"big_doubling_parallel": fib.BigDoublingParallel,
+	"big_doubling_square":   fib.BigDoublingSquare,
+	"limb_doubling":         fib.LimbDoubling,
+	"auto":                  fib.Auto,
+	"big_binet":             func(n uint64) *big.Int { return fib.BigBinet(n, 0) },
+}
+
+// algorithmNames returns every algorithm name, uint64 ones first, each group sorted
+func algorithmNames() []string {
+	var names []string
+	for name := range uint64Algorithms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	big := make([]string, 0, len(bigAlgorithms))
+	for name := range bigAlgorithms {
+		big = append(big, name)
+	}
+	slices.Sort(big)
+	return append(names, big...)
+}
+
+// checkAlgorithm validates name and n together
+// uint64 algorithms refuse n past fib.MaxSafeN instead of wrapping, and recursive past recursiveMaxN.
+func checkAlgorithm(name string, n uint64) error {
+	if _, ok := bigAlgorithms[name]; ok {
+		return nil
+	}
+	if _, ok := uint64Algorithms[name]; !ok {
+		return fmt.Errorf("unknown algorithm %q (one of %s)", name, strings.Join(algorithmNames(), ", "))
+	}
+	switch {
+	case name == "recursive" && n > recursiveMaxN:
+		return fmt.Errorf("%w: recursive is limited to n <= %d", fib.ErrInvalidInput, recursiveMaxN)
+	case n > fib.MaxSafeN:
+		return fmt.Errorf("%w: F(%d) does not fit in %s's uint64, use a big_* algorithm or auto", fib.ErrOverflow, n, name)
+	}
+	return nil
+}
+
+// compute calculates F(n) with the algorithm called name
+func compute(name string, n uint64) (*big.Int, error) {
+	if err := checkAlgorithm(name, n); err != nil {
+		return nil, err
+	}
+	if fn, ok := bigAlgorithms[name]; ok {
+		return fn(n), nil
+	}
+	return new(big.Int).SetUint64(uint64Algorithms[name](n)), nil
+}
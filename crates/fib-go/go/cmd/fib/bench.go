@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// benchResult is one (algorithm, n) cell of fib bench
+type benchResult struct {
+	Algorithm  string  `json:"algorithm"`
+	N          uint64  `json:"n"`
+	Iterations int     `json:"iterations"`
+	MinNS      int64   `json:"min_ns"`
+	MeanNS     float64 `json:"mean_ns"`
+	StddevNS   float64 `json:"stddev_ns"`
+	P50NS      int64   `json:"p50_ns"`
+	P99NS      int64   `json:"p99_ns"`
+	MaxNS      int64   `json:"max_ns"`
+	// Skipped tells why the cell was not run, e.g. a uint64 algorithm past F(93)
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// benchSink keeps benchmarked results observable so the calls are not optimized away
+var benchSink uint64
+
+// runBench implements fib bench
+func runBench(args []string) error {
+	fs := newFlagSet("bench")
+	matrix := fs.Bool("matrix", false, "time every algorithm (or those of --algo) at every --n")
+	algos := fs.String("algo", "", "comma-separated algorithms, doubling by default or every one with --matrix")
+	nList := fs.String("n", "", "comma-separated indices, 90 by default or 10,50,90 with --matrix")
+	iters := fs.Int("iters", 100, "timed calls per cell")
+	warmup := fs.Int("warmup", 10, "untimed calls per cell before timing")
+	asJSON := fs.Bool("json", false, "print the results as JSON instead of a table")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *iters < 1 {
+		return errors.New("--iters must be >= 1")
+	}
+
+	names := splitList(*algos)
+	values := splitList(*nList)
+	switch {
+	case *matrix && len(names) == 0:
+		names = algorithmNames()
+	case len(names) == 0:
+		names = []string{"doubling"}
+	}
+	switch {
+	case *matrix && len(values) == 0:
+		values = []string{"10", "50", "90"}
+	case len(values) == 0:
+		values = []string{"90"}
+	}
+	if !*matrix && (len(names) > 1 || len(values) > 1) {
+		return errors.New("several algorithms or indices need --matrix")
+	}
+	ns := make([]uint64, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("--n: %q is not an unsigned integer", v)
+		}
+		ns[i] = n
+	}
+
+	var results []benchResult
+	for _, name := range names {
+		for _, n := range ns {
+			r, err := benchCell(name, n, *warmup, *iters)
+			if err != nil && !*matrix {
+				return err
+			}
+			results = append(results, r)
+		}
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+	printBenchTable(results)
+	return nil
+}
+
+// benchCell times iters calls of the algorithm called name at n after warmup untimed ones
+// A cell the algorithm refuses is returned as skipped along with the reason.
+func benchCell(name string, n uint64, warmup, iters int) (benchResult, error) {
+	r := benchResult{Algorithm: name, N: n}
+	if err := checkAlgorithm(name, n); err != nil {
+		r.Skipped = err.Error()
+		return r, err
+	}
+	call := func() uint64 { return uint64Algorithms[name](n) }
+	if fn, ok := bigAlgorithms[name]; ok {
+		call = func() uint64 { return uint64(fn(n).BitLen()) }
+	}
+	acc := call()
+	for range warmup {
+		acc ^= call()
+	}
+	samples := make([]time.Duration, iters)
+	for i := range samples {
+		start := time.Now()
+		acc ^= call()
+		samples[i] = time.Since(start)
+	}
+	benchSink ^= acc
+
+	slices.Sort(samples)
+	var sum float64
+	for _, d := range samples {
+		sum += float64(d)
+	}
+	r.Iterations = iters
+	r.MinNS, r.MaxNS = samples[0].Nanoseconds(), samples[iters-1].Nanoseconds()
+	r.MeanNS = sum / float64(iters)
+	if iters > 1 {
+		var sq float64
+		for _, d := range samples {
+			sq += (float64(d) - r.MeanNS) * (float64(d) - r.MeanNS)
+		}
+		r.StddevNS = math.Sqrt(sq / float64(iters-1))
+	}
+	r.P50NS, r.P99NS = percentile(samples, 0.50).Nanoseconds(), percentile(samples, 0.99).Nanoseconds()
+	return r, nil
+}
+
+// percentile returns the nearest-rank p-quantile of ascending samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// printBenchTable writes the results as aligned columns, timings in nanoseconds
+func printBenchTable(results []benchResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ALGORITHM\tN\tITERS\tMIN\tMEAN\tSTDDEV\tP50\tP99\tMAX")
+	for _, r := range results {
+		if r.Skipped != "" {
+			fmt.Fprintf(w, "%s\t%d\t-\t-\t-\t-\t-\t-\t-\n", r.Algorithm, r.N)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.1f\t%.1f\t%d\t%d\t%d\n",
+			r.Algorithm, r.N, r.Iterations, r.MinNS, r.MeanNS, r.StddevNS, r.P50NS, r.P99NS, r.MaxNS)
+	}
+	w.Flush()
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// runCompute implements fib compute
+func runCompute(args []string) error {
+	fs := newFlagSet("compute")
+	algo := fs.String("algo", defaultAlgorithm, "algorithm name")
+	n := fs.Uint64("n", 0, "index of the Fibonacci number")
+	timed := fs.Bool("time", false, "print the computation time on stderr")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	start := time.Now()
+	value, err := compute(*algo, *n)
+	elapsed := time.Since(start)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	if *timed {
+		fmt.Fprintf(os.Stderr, "%s F(%d): %v\n", *algo, *n, elapsed)
+	}
+	return nil
+}
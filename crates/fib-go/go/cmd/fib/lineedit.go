@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// lineEditor reads lines from a terminal in raw mode
+// It supports typing and Backspace at the end of the line, Tab completion of
+// the word before the cursor, Up/Down through the history of the session,
+// Ctrl-C to discard the line and Ctrl-D to leave on an empty line.
+type lineEditor struct {
+	in       *os.File
+	reader   *bufio.Reader
+	out      io.Writer
+	complete func(prefix string) []string
+	history  []string
+}
+
+// Control bytes of a terminal in raw mode
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyTab       = 9
+	keyLF        = 10
+	keyCR        = 13
+	keyEscape    = 27
+	keyBackspace = 127
+	keyCtrlH     = 8
+)
+
+// readLine prints prompt and returns the edited line, or io.EOF on Ctrl-D
+func (ed *lineEditor) readLine(prompt string) (string, error) {
+	restore, err := makeRaw(ed.in)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	var line []byte
+	recall := len(ed.history)
+	redraw := func() { fmt.Fprintf(ed.out, "\r\x1b[K%s%s", prompt, line) }
+	redraw()
+	for {
+		c, err := ed.reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch c {
+		case keyCR, keyLF:
+			fmt.Fprint(ed.out, "\r\n")
+			if s := strings.TrimSpace(string(line)); s != "" {
+				ed.history = append(ed.history, s)
+			}
+			return string(line), nil
+		case keyCtrlC:
+			fmt.Fprint(ed.out, "^C\r\n")
+			line = line[:0]
+			recall = len(ed.history)
+			redraw()
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(ed.out, "\r\n")
+				return "", io.EOF
+			}
+		case keyBackspace, keyCtrlH:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				redraw()
+			}
+		case keyTab:
+			line = ed.completeLine(line, prompt)
+			redraw()
+		case keyEscape:
+			// CSI sequences: ESC [ A is Up, ESC [ B is Down; others are ignored
+			if b, _ := ed.reader.ReadByte(); b != '[' {
+				continue
+			}
+			switch b, _ := ed.reader.ReadByte(); {
+			case b == 'A' && recall > 0:
+				recall--
+			case b == 'B' && recall < len(ed.history):
+				recall++
+			default:
+				continue
+			}
+			line = line[:0]
+			if recall < len(ed.history) {
+				line = append(line, ed.history[recall]...)
+			}
+			redraw()
+		default:
+			if c >= ' ' {
+				line = append(line, c)
+				fmt.Fprintf(ed.out, "%c", c)
+			}
+		}
+	}
+}
+
+// completeLine extends the word before the cursor
+// A unique match is completed; several matches are extended to their common
+// prefix and listed under the line.
+func (ed *lineEditor) completeLine(line []byte, prompt string) []byte {
+	start := len(line)
+	for start > 0 && isWordByte(line[start-1]) {
+		start--
+	}
+	prefix := string(line[start:])
+	matches := ed.complete(prefix)
+	switch len(matches) {
+	case 0:
+		return line
+	case 1:
+		return append(line[:start], matches[0]...)
+	}
+	common := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	if len(common) > len(prefix) {
+		return append(line[:start], common...)
+	}
+	fmt.Fprintf(ed.out, "\r\n%s\r\n", strings.Join(matches, "  "))
+	return line
+}
+
+// isWordByte reports whether c can be part of a completed name
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,104 @@
+// Command fib runs the Go Fibonacci implementations from the command line.
+//
+// It calls the pure-Go package fib directly, without cgo or the C interface,
+// so the algorithms can be tried and compared locally:
+//
+//	fib compute --algo doubling --n 1000000
+//	fib bench --matrix --n 10,50,90
+//	fib verify
+//	fib serve --addr 127.0.0.1:8080
+//	fib repl
+//
+// Build it from the go/ directory with go build ./cmd/fib.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// command is one subcommand of fib
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+// commands are the subcommands of fib, by name
+var commands = map[string]command{
+	"compute": {"print F(n) computed with one algorithm", runCompute},
+	"bench":   {"time algorithms, one cell or a whole matrix", runBench},
+	"verify":  {"cross-check every algorithm against reference values", runVerify},
+	"serve":   {"serve GET /fib/{n} over HTTP", runServe},
+	"repl":    {"evaluate expressions such as fib(123) % 1000000007 interactively", runREPL},
+}
+
+// errUsage reports invalid arguments once the flag set has printed its usage
+var errUsage = errors.New("usage")
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	cmd, ok := commands[name]
+	if !ok {
+		if name != "help" && name != "-h" && name != "--help" {
+			fmt.Fprintf(os.Stderr, "fib: unknown command %q\n", name)
+		}
+		usage()
+		os.Exit(2)
+	}
+	switch err := cmd.run(os.Args[2:]); {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "fib %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+// usage lists the subcommands on stderr
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: fib <command> [flags]\n\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun fib <command> -h for its flags.")
+}
+
+// newFlagSet returns the flag set of a subcommand, reporting errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("fib "+name, flag.ContinueOnError)
+}
+
+// parseFlags parses args, turning a parse failure into errUsage
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected argument %q\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(s string) []string {
+	return slices.DeleteFunc(strings.Split(s, ","), func(item string) bool { return strings.TrimSpace(item) == "" })
+}
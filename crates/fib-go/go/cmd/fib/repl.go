@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// maxBenchRange bounds the number of cells of one bench command in the REPL
+const maxBenchRange = 10000
+
+// replHelp is printed by the help command
+const replHelp = `expressions, on exact integers:
+  fib(100)                    F(n), computed by auto
+  lucas(50)                   the Lucas number L(n)
+  doubling(90), big_matrix(n) F(n) with one algorithm (see algorithms)
+  fib(123) % 1000000007       + - * / % and parentheses; _ is the last result
+commands:
+  bench doubling 1..90        time an algorithm at every n of a range (or one n)
+  algorithms                  list the algorithm names
+  help, exit
+Tab completes names.`
+
+// replCommands are the words the REPL understands besides the functions
+var replCommands = []string{"algorithms", "bench", "exit", "help", "quit"}
+
+// replFunctions are the functions of REPL expressions: fib, lucas and every algorithm
+func replFunctions() []string {
+	return append([]string{"fib", "lucas"}, algorithmNames()...)
+}
+
+// runREPL implements fib repl
+func runREPL(args []string) error {
+	fs := newFlagSet("repl")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	words := append(replFunctions(), replCommands...)
+	slices.Sort(words)
+	complete := func(prefix string) []string {
+		var out []string
+		for _, w := range words {
+			if strings.HasPrefix(w, prefix) {
+				out = append(out, w)
+			}
+		}
+		return out
+	}
+
+	readLine, interactive := newLineReader(os.Stdin, os.Stdout, complete)
+	if interactive {
+		fmt.Println("fib repl: type help for the syntax, exit or Ctrl-D to leave")
+	}
+	var last *big.Int
+	for {
+		line, err := readLine("fib> ")
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch fields := strings.Fields(line); {
+		case line == "":
+		case line == "exit" || line == "quit":
+			return nil
+		case line == "help":
+			fmt.Println(replHelp)
+		case line == "algorithms":
+			fmt.Println(strings.Join(algorithmNames(), " "))
+		case fields[0] == "bench":
+			if err := replBench(fields[1:]); err != nil {
+				fmt.Println("error:", err)
+			}
+		default:
+			value, err := evaluate(line, last)
+			if err != nil {
+				fmt.Println("error:", err)
+				continue
+			}
+			last = value
+			fmt.Println(value)
+		}
+	}
+}
+
+// replBench runs "bench <algorithm> <n>|<from>..<to>"
+func replBench(args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: bench <algorithm> <n> or bench <algorithm> <from>..<to>")
+	}
+	from, to, err := parseRange(args[1])
+	if err != nil {
+		return err
+	}
+	if err := checkAlgorithm(args[0], 0); err != nil {
+		return err
+	}
+	if to-from >= maxBenchRange {
+		return fmt.Errorf("at most %d values per bench", maxBenchRange)
+	}
+	var results []benchResult
+	for n := from; ; n++ {
+		r, _ := benchCell(args[0], n, 10, 100)
+		results = append(results, r)
+		if n == to {
+			break
+		}
+	}
+	printBenchTable(results)
+	return nil
+}
+
+// parseRange parses "n" or "from..to"
+func parseRange(s string) (from, to uint64, err error) {
+	lo, hi, isRange := strings.Cut(s, "..")
+	if from, err = strconv.ParseUint(lo, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("%q is not an index or a range from..to", s)
+	}
+	if !isRange {
+		return from, from, nil
+	}
+	if to, err = strconv.ParseUint(hi, 10, 64); err != nil || to < from {
+		return 0, 0, fmt.Errorf("%q is not an index or a range from..to", s)
+	}
+	return from, to, nil
+}
+
+// evaluate computes an expression of the REPL; last is the value of _, nil before the first result
+func evaluate(line string, last *big.Int) (*big.Int, error) {
+	p := &exprParser{tokens: tokenize(line), last: last}
+	v, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return v, nil
+}
+
+// tokenize splits an expression into numbers, names and single-character operators
+func tokenize(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(s) && (unicode.IsDigit(rune(s[j])) || unicode.IsLetter(rune(s[j])) || s[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			tokens = append(tokens, s[i:i+1])
+			i++
+		}
+	}
+	return tokens
+}
+
+// exprParser evaluates tokens by recursive descent
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = factor { ("*" | "/" | "%") factor }
+//	factor = number | "_" | name "(" expr ")" | "(" expr ")" | "-" factor
+type exprParser struct {
+	tokens []string
+	pos    int
+	last   *big.Int
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *exprParser) expect(tok string) error {
+	if p.peek() != tok {
+		if p.peek() == "" {
+			return fmt.Errorf("expected %q at the end", tok)
+		}
+		return fmt.Errorf("expected %q, got %q", tok, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+func (p *exprParser) expr() (*big.Int, error) {
+	v, err := p.term()
+	for err == nil && (p.peek() == "+" || p.peek() == "-") {
+		op := p.tokens[p.pos]
+		p.pos++
+		var rhs *big.Int
+		if rhs, err = p.term(); err == nil {
+			if op == "+" {
+				v.Add(v, rhs)
+			} else {
+				v.Sub(v, rhs)
+			}
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) term() (*big.Int, error) {
+	v, err := p.factor()
+	for err == nil && (p.peek() == "*" || p.peek() == "/" || p.peek() == "%") {
+		op := p.tokens[p.pos]
+		p.pos++
+		var rhs *big.Int
+		if rhs, err = p.factor(); err != nil {
+			break
+		}
+		switch {
+		case op == "*":
+			v.Mul(v, rhs)
+		case rhs.Sign() == 0:
+			err = errors.New("division by zero")
+		case op == "/":
+			v.Quo(v, rhs)
+		default:
+			v.Rem(v, rhs)
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) factor() (*big.Int, error) {
+	tok := p.peek()
+	p.pos++
+	switch {
+	case tok == "":
+		return nil, errors.New("unexpected end of expression")
+	case tok == "-":
+		v, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return v.Neg(v), nil
+	case tok == "(":
+		v, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		return v, p.expect(")")
+	case tok == "_":
+		if p.last == nil {
+			return nil, errors.New("_ has no value yet")
+		}
+		return new(big.Int).Set(p.last), nil
+	case unicode.IsDigit(rune(tok[0])):
+		v, ok := new(big.Int).SetString(tok, 10)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a number", tok)
+		}
+		return v, nil
+	case unicode.IsLetter(rune(tok[0])):
+		return p.call(tok)
+	}
+	return nil, fmt.Errorf("unexpected %q", tok)
+}
+
+// call evaluates name(expr), the argument being an index
+func (p *exprParser) call(name string) (*big.Int, error) {
+	if !slices.Contains(replFunctions(), name) {
+		return nil, fmt.Errorf("unknown function %q", name)
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	arg, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if arg.Sign() < 0 || !arg.IsUint64() {
+		return nil, fmt.Errorf("%s: index %v is not an unsigned 64-bit integer", name, arg)
+	}
+	n := arg.Uint64()
+	switch name {
+	case "fib":
+		return fib.Auto(n), nil
+	case "lucas":
+		// L(n) = 2 F(n+1) - F(n)
+		fn, fn1 := fib.BigPair(n)
+		return fn1.Lsh(fn1, 1).Sub(fn1, fn), nil
+	}
+	return compute(name, n)
+}
+
+// newLineReader returns a prompt-and-read function over in
+// On a terminal each line is edited in raw mode, with Tab completion through
+// complete and Up/Down history, the terminal being restored between lines so
+// Ctrl-C still interrupts a long computation. Otherwise lines are read as they
+// come, without prompt.
+func newLineReader(in *os.File, out io.Writer, complete func(prefix string) []string) (readLine func(prompt string) (string, error), interactive bool) {
+	if !isTerminal(in) {
+		scanner := bufio.NewScanner(in)
+		return func(string) (string, error) {
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return "", err
+				}
+				return "", io.EOF
+			}
+			return scanner.Text(), nil
+		}, false
+	}
+	ed := &lineEditor{in: in, reader: bufio.NewReader(in), out: out, complete: complete}
+	return ed.readLine, true
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// serveMaxN bounds n in fib serve, whose computations run to completion once started
+const serveMaxN = 1_000_000
+
+// serveResult is the body of a successful GET /fib/{n}, as in the library's StartHTTPServer
+type serveResult struct {
+	N         uint64 `json:"n"`
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"` // decimal, exact
+	ElapsedNS int64  `json:"elapsed_ns"`
+}
+
+// runServe implements fib serve
+// GET /fib/{n}?algo= answers like the library's StartHTTPServer, refusing n
+// past serveMaxN, and GET /algorithms lists the names. The server stops on SIGINT or SIGTERM.
+func runServe(args []string) error {
+	fs := newFlagSet("serve")
+	addr := fs.String("addr", "127.0.0.1:8080", "address to listen on")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /fib/{n}", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.ParseUint(r.PathValue("n"), 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "n must be an unsigned 64-bit integer"})
+			return
+		}
+		if n > serveMaxN {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": fmt.Sprintf("n must be <= %d", serveMaxN)})
+			return
+		}
+		algo := r.URL.Query().Get("algo")
+		if algo == "" {
+			algo = defaultAlgorithm
+		}
+		start := time.Now()
+		value, err := compute(algo, n)
+		elapsed := time.Since(start)
+		if err != nil {
+			code := http.StatusBadRequest
+			if errors.Is(err, fib.ErrOverflow) {
+				code = http.StatusUnprocessableEntity
+			}
+			writeJSON(w, code, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, serveResult{n, algo, value.String(), elapsed.Nanoseconds()})
+	})
+	mux.HandleFunc("GET /algorithms", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, algorithmNames())
+	})
+
+	srv := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+	fmt.Fprintf(os.Stderr, "serving on http://%s\n", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// writeJSON sends v with the given HTTP status
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
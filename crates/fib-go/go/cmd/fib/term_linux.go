@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// getTermios reads the terminal attributes of f, failing when f is not a terminal
+func getTermios(f *os.File) (syscall.Termios, error) {
+	var t syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&t))); errno != 0 {
+		return t, errno
+	}
+	return t, nil
+}
+
+func setTermios(f *os.File, t *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// isTerminal reports whether f is a terminal
+func isTerminal(f *os.File) bool {
+	_, err := getTermios(f)
+	return err == nil
+}
+
+// makeRaw switches the terminal f to byte-at-a-time input without echo or signals
+// Output processing is kept, so "\n" still starts a new line.
+func makeRaw(f *os.File) (restore func(), err error) {
+	saved, err := getTermios(f)
+	if err != nil {
+		return nil, err
+	}
+	raw := saved
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON | syscall.INLCR | syscall.IGNCR
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cc[syscall.VMIN], raw.Cc[syscall.VTIME] = 1, 0
+	if err := setTermios(f, &raw); err != nil {
+		return nil, err
+	}
+	return func() { setTermios(f, &saved) }, nil
+}
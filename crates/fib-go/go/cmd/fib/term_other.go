@@ -0,0 +1,18 @@
+//go:build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// isTerminal reports false: without raw mode the REPL reads plain lines
+func isTerminal(*os.File) bool {
+	return false
+}
+
+// makeRaw is only implemented on Linux
+func makeRaw(*os.File) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// verifyRecursiveMaxN bounds the checks of recursive, whose cost doubles at every n
+const verifyRecursiveMaxN = 30
+
+// runVerify implements fib verify
+// uint64 algorithms are checked against the golden table for every n up to
+// fib.MaxSafeN and big-integer algorithms against F(n) computed by plain
+// additions, for every n up to 200 and then at powers of ten up to --max-n.
+func runVerify(args []string) error {
+	fs := newFlagSet("verify")
+	maxN := fs.Uint64("max-n", 10000, "largest n checked for the big-integer algorithms")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	failures := 0
+	report := func(name string, checks int, mismatches []uint64) {
+		if len(mismatches) == 0 {
+			fmt.Printf("ok    %-22s %d values\n", name, checks)
+			return
+		}
+		failures += len(mismatches)
+		fmt.Printf("FAIL  %-22s wrong at n = %v\n", name, mismatches)
+	}
+
+	for _, name := range algorithmNames() {
+		fn, ok := uint64Algorithms[name]
+		if !ok {
+			continue
+		}
+		last := uint64(fib.MaxSafeN)
+		if name == "recursive" {
+			last = verifyRecursiveMaxN
+		}
+		var mismatches []uint64
+		for n := uint64(0); n <= last; n++ {
+			if fn(n) != fib.Lookup(n) {
+				mismatches = append(mismatches, n)
+			}
+		}
+		report(name, int(last+1), mismatches)
+	}
+
+	points := verifyPoints(*maxN)
+	want := referenceValues(points)
+	for _, name := range algorithmNames() {
+		fn, ok := bigAlgorithms[name]
+		if !ok {
+			continue
+		}
+		var mismatches []uint64
+		for i, n := range points {
+			if fn(n).Cmp(want[i]) != 0 {
+				mismatches = append(mismatches, n)
+			}
+		}
+		report(name, len(points), mismatches)
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d mismatches", failures)
+	}
+	return nil
+}
+
+// verifyPoints returns 0..200, the powers of ten past it up to maxN, and maxN, in ascending order
+func verifyPoints(maxN uint64) []uint64 {
+	var points []uint64
+	for n := uint64(0); n <= min(maxN, 200); n++ {
+		points = append(points, n)
+	}
+	for p := uint64(1000); p <= maxN && p > 200; p *= 10 {
+		points = append(points, p)
+	}
+	if maxN > 200 && !slices.Contains(points, maxN) {
+		points = append(points, maxN)
+	}
+	return points
+}
+
+// referenceValues computes F(n) at the ascending points by repeated addition, independently of package fib
+func referenceValues(points []uint64) []*big.Int {
+	values := make([]*big.Int, 0, len(points))
+	a, b := big.NewInt(0), big.NewInt(1)
+	n := uint64(0)
+	for _, p := range points {
+		for ; n < p; n++ {
+			a.Add(a, b)
+			a, b = b, a
+		}
+		values = append(values, new(big.Int).Set(a))
+	}
+	return values
+}
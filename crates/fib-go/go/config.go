@@ -0,0 +1,236 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// maxMemoPrecompute bounds the memo_precompute setting accepted by FibInit
+const maxMemoPrecompute = fib.DefaultMemoPoolMaxEntries
+
+// maxMemoMaxN bounds the memo_max_n setting, keeping the shared memo under a few hundred MB
+const maxMemoMaxN = 1 << 24
+
+// libraryConfig is the configuration accepted by FibInit and reported by GetEffectiveConfig
+// Keys missing from the JSON keep their current values; each key can also be
+// overridden by the environment variable FIB_<KEY>, e.g. FIB_MAX_N.
+type libraryConfig struct {
+	MaxN               uint64 `json:"max_n"`
+	TimeoutMS          uint64 `json:"timeout_ms"`
+	RecursiveMaxN      uint64 `json:"recursive_max_n"`
+	RecursiveMode      string `json:"recursive_mode"`
+	MemoMaxN           uint64 `json:"memo_max_n"`
+	MemoPoolMaxEntries uint64 `json:"memo_pool_max_entries"`
+	MemoPrecompute     uint64 `json:"memo_precompute"`
+	Workers            int    `json:"workers"`
+	ParallelMulBits    uint64 `json:"parallel_mul_threshold"`
+	AutoIterative128   uint64 `json:"auto_iterative128_max_n"`
+	AutoMatrix128      uint64 `json:"auto_matrix128_max_n"`
+	AutoBigIterative   uint64 `json:"auto_big_iterative_max_n"`
+	AutoBigMatrix      uint64 `json:"auto_big_matrix_max_n"`
+	MaxProcs           int    `json:"max_procs"`
+	GCPercent          int    `json:"gc_percent"`
+	MemoryLimit        int64  `json:"memory_limit"`
+	LogLevel           string `json:"log_level"`
+	WarmUp             bool   `json:"warm_up"`
+	Calibrate          bool   `json:"calibrate"`
+	Profiling          bool   `json:"profiling"`
+	Telemetry          bool   `json:"telemetry"`
+	OTLPEndpoint       string `json:"otlp_endpoint"`
+	OTLPService        string `json:"otlp_service_name"`
+	PublishQueue       string `json:"publish_queue"`
+}
+
+// workerCount is the size of the worker pools, set by the workers config key
+var workerCount atomic.Int64
+
+func init() {
+	workerCount.Store(int64(runtime.GOMAXPROCS(0)))
+}
+
+// recursiveModeNames maps the recursive_mode values to RecursiveMode* constants
+var recursiveModeNames = map[string]C.int32_t{
+	"fallback": RecursiveModeFallback,
+	"error":    RecursiveModeError,
+}
+
+// currentConfig reads the settings in force
+func currentConfig() libraryConfig {
+	mode := "fallback"
+	if C.int32_t(recursiveMode.Load()) == RecursiveModeError {
+		mode = "error"
+	}
+	thresholds := fib.AutoThresholds()
+	otlpEndpoint, otlpService := tracingSettings()
+	return libraryConfig{
+		MaxN:               dispatchMaxN.Load(),
+		TimeoutMS:          callTimeoutMS.Load(),
+		RecursiveMaxN:      recursiveMaxN.Load(),
+		RecursiveMode:      mode,
+		MemoMaxN:           fib.MemoMaxN(),
+		MemoPoolMaxEntries: fib.MemoPoolMaxEntries(),
+		Workers:            int(workerCount.Load()),
+		ParallelMulBits:    fib.ParallelMulThreshold(),
+		AutoIterative128:   thresholds.Iterative128MaxN,
+		AutoMatrix128:      thresholds.Matrix128MaxN,
+		AutoBigIterative:   thresholds.BigIterativeMaxN,
+		AutoBigMatrix:      thresholds.BigMatrixMaxN,
+		MaxProcs:           runtime.GOMAXPROCS(0),
+		GCPercent:          int(gcPercentSetting.Load()),
+		MemoryLimit:        debug.SetMemoryLimit(-1),
+		LogLevel:           logLevelName(logLevel.Level()),
+		WarmUp:             true,
+		Profiling:          profilingEnabled.Load(),
+		Telemetry:          telemetryEnabled.Load(),
+		OTLPEndpoint:       otlpEndpoint,
+		OTLPService:        otlpService,
+		PublishQueue:       publishQueuePath(),
+	}
+}
+
+// resolveConfig overlays the JSON document and then the FIB_* environment on the current settings
+func resolveConfig(data []byte) (libraryConfig, error) {
+	cfg := currentConfig()
+	if len(bytes.TrimSpace(data)) != 0 {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&cfg); err != nil {
+			return cfg, err
+		}
+	}
+	if err := applyEnvOverrides(&cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, validateConfig(cfg)
+}
+
+// applyEnvOverrides sets every field whose FIB_<JSON KEY> variable is present
+func applyEnvOverrides(cfg *libraryConfig) error {
+	v := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Tag.Get("json")
+		name := "FIB_" + strings.ToUpper(key)
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		field := v.Field(i)
+		var err error
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(raw)
+		case reflect.Bool:
+			var b bool
+			b, err = strconv.ParseBool(raw)
+			field.SetBool(b)
+		case reflect.Int, reflect.Int64:
+			var x int64
+			x, err = strconv.ParseInt(raw, 0, 64)
+			field.SetInt(x)
+		case reflect.Uint64:
+			var x uint64
+			x, err = strconv.ParseUint(raw, 0, 64)
+			field.SetUint(x)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validateConfig rejects settings applyConfig cannot install
+func validateConfig(cfg libraryConfig) error {
+	if _, ok := recursiveModeNames[cfg.RecursiveMode]; !ok {
+		return fmt.Errorf("recursive_mode must be \"fallback\" or \"error\", got %q", cfg.RecursiveMode)
+	}
+	if _, err := parseLogLevel(cfg.LogLevel); err != nil {
+		return fmt.Errorf("log_level: %w", err)
+	}
+	if err := validateOTLPEndpoint(cfg.OTLPEndpoint); err != nil {
+		return fmt.Errorf("otlp_endpoint: %w", err)
+	}
+	switch {
+	case cfg.Workers < 1:
+		return errors.New("workers must be >= 1")
+	case cfg.MaxProcs < 1:
+		return errors.New("max_procs must be >= 1")
+	case cfg.MemoryLimit < 0:
+		return errors.New("memory_limit must be >= 0")
+	case cfg.MemoMaxN > maxMemoMaxN:
+		return fmt.Errorf("memo_max_n must be <= %d", maxMemoMaxN)
+	case cfg.MemoPrecompute > maxMemoPrecompute:
+		return fmt.Errorf("memo_precompute must be <= %d", maxMemoPrecompute)
+	case cfg.OTLPService == "":
+		return errors.New("otlp_service_name must not be empty")
+	}
+	return nil
+}
+
+// applyConfig installs a validated configuration
+func applyConfig(cfg libraryConfig) {
+	level, _ := parseLogLevel(cfg.LogLevel)
+	logLevel.Set(level)
+	dispatchMaxN.Store(cfg.MaxN)
+	callTimeoutMS.Store(cfg.TimeoutMS)
+	recursiveMaxN.Store(cfg.RecursiveMaxN)
+	recursiveMode.Store(int32(recursiveModeNames[cfg.RecursiveMode]))
+	fib.SetMemoMaxN(cfg.MemoMaxN)
+	fib.SetMemoPoolMaxEntries(cfg.MemoPoolMaxEntries)
+	workerCount.Store(int64(cfg.Workers))
+	fib.SetParallelMulThreshold(cfg.ParallelMulBits)
+	fib.SetAutoThresholds(fib.Thresholds{
+		Iterative128MaxN: cfg.AutoIterative128,
+		Matrix128MaxN:    cfg.AutoMatrix128,
+		BigIterativeMaxN: cfg.AutoBigIterative,
+		BigMatrixMaxN:    cfg.AutoBigMatrix,
+	})
+	runtime.GOMAXPROCS(cfg.MaxProcs)
+	setGCPercent(cfg.GCPercent)
+	debug.SetMemoryLimit(cfg.MemoryLimit)
+	profilingEnabled.Store(cfg.Profiling)
+	setTelemetry(cfg.Telemetry)
+	configureTracing(cfg.OTLPEndpoint, cfg.OTLPService)
+	setPublishQueuePath(cfg.PublishQueue)
+}
+
+// GetEffectiveConfig returns the resolved settings in force as a JSON object
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export GetEffectiveConfig
+func GetEffectiveConfig() *C.char {
+	defer recoverPanic()
+	data, err := effectiveConfigJSON()
+	if err != nil {
+		return nil
+	}
+	return C.CString(string(data))
+}
+
+// effectiveConfigJSON encodes the settings returned by GetEffectiveConfig
+func effectiveConfigJSON() ([]byte, error) {
+	lifecycle.Lock()
+	defer lifecycle.Unlock()
+	cfg := currentConfig()
+	cfg.MemoPrecompute = lifecycle.memoPrecompute
+	cfg.WarmUp = lifecycle.warmUp
+	cfg.Calibrate = lifecycle.calibrate
+	return json.Marshal(cfg)
+}
@@ -0,0 +1,82 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// maxContinuedFractionDepth bounds FibContinuedFraction, whose document lists O(depth²) digits
+const maxContinuedFractionDepth = 1000
+
+// convergentJSON is one convergent p/q, both decimal
+type convergentJSON struct {
+	P string `json:"p"`
+	Q string `json:"q"`
+}
+
+// continuedFraction is the FibContinuedFraction document
+type continuedFraction struct {
+	Depth       uint64           `json:"depth"`
+	Terms       []uint64         `json:"terms"`
+	Convergents []convergentJSON `json:"convergents"`
+	P           string           `json:"p"`
+	Q           string           `json:"q"`
+	Value       float64          `json:"value"`
+}
+
+// continuedFractionGo expands [1; 1, ..., 1] to depth, checking the big.Rat evaluation against the last convergent
+func continuedFractionGo(depth uint64) (continuedFraction, error) {
+	doc := continuedFraction{Depth: depth, Terms: make([]uint64, depth+1)}
+	for i := range doc.Terms {
+		doc.Terms[i] = 1
+	}
+	for _, c := range fib.GoldenConvergents(depth) {
+		doc.Convergents = append(doc.Convergents, convergentJSON{c.P.String(), c.Q.String()})
+	}
+	x := fib.GoldenContinuedFraction(depth)
+	doc.P, doc.Q = x.Num().String(), x.Denom().String()
+	doc.Value, _ = x.Float64()
+	if last := doc.Convergents[depth]; last.P != doc.P || last.Q != doc.Q {
+		return doc, fmt.Errorf("rational evaluation %s/%s contradicts the convergent %s/%s", doc.P, doc.Q, last.P, last.Q)
+	}
+	return doc, nil
+}
+
+// FibContinuedFraction expands phi = [1; 1, 1, ...] as a continued fraction to depth ones after the semicolon
+// The fraction is evaluated in big.Rat arithmetic from the innermost term, a
+// rational workload with a gcd per step, and its convergents by the p(k) =
+// p(k-1) + p(k-2) recurrence; the k-th convergent is F(k+2)/F(k+1). Returns
+// {"depth", "terms", "convergents": [{"p", "q"}], "p", "q", "value"} with decimal
+// p and q and value the double nearest p/q; the string is owned by the caller
+// and must be released with FreeCString. Returns NULL with StatusLimitExceeded if
+// depth exceeds 1000.
+//
+//export FibContinuedFraction
+func FibContinuedFraction(depth C.uint64_t) *C.char {
+	defer recoverPanic()
+	return documentCString(continuedFractionDocument(depth))
+}
+
+// continuedFractionDocument checks the depth of FibContinuedFraction and encodes its expansion
+func continuedFractionDocument(depth C.uint64_t) (string, C.fib_status, error) {
+	if depth > maxContinuedFractionDepth {
+		return "", StatusLimitExceeded, fmt.Errorf("FibContinuedFraction: depth must be <= %d", maxContinuedFractionDepth)
+	}
+	doc, err := continuedFractionGo(uint64(depth))
+	if err != nil {
+		return "", StatusInternal, fmt.Errorf("FibContinuedFraction: %v", err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(data), StatusOK, nil
+}
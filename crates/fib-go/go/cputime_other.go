@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "time"
+
+// threadCPUTime reports that per-thread CPU time is unavailable on this platform (see cputime_unix.go)
+func threadCPUTime() (time.Duration, bool) {
+	return 0, false
+}
@@ -0,0 +1,26 @@
+//go:build unix
+
+package main
+
+/*
+#include <stdint.h>
+#include <time.h>
+
+static int64_t fib_thread_cpu_ns(void) {
+	struct timespec ts;
+	if (clock_gettime(CLOCK_THREAD_CPUTIME_ID, &ts) != 0) {
+		return -1;
+	}
+	return (int64_t)ts.tv_sec * 1000000000 + ts.tv_nsec;
+}
+*/
+import "C"
+
+import "time"
+
+// threadCPUTime returns the CPU time consumed by the calling OS thread
+// The caller must hold runtime.LockOSThread for two readings to be comparable.
+func threadCPUTime() (time.Duration, bool) {
+	ns := C.fib_thread_cpu_ns()
+	return time.Duration(ns), ns >= 0
+}
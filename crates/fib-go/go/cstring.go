@@ -0,0 +1,37 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+*/
+import "C"
+
+import "unsafe"
+
+// FibDecimalString returns the full decimal expansion of F(n), computed with big-integer doubling
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export FibDecimalString
+func FibDecimalString(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	return C.CString(tracedBig("FibDecimalString", "big_doubling", uint64(n), bigDoublingReported).String())
+}
+
+// FreeCString releases a string returned by the library
+// Every export returning char* transfers ownership to the caller, who must hand
+// the pointer back here rather than to its own allocator. NULL is ignored.
+//
+//export FreeCString
+func FreeCString(s *C.char) {
+	if s == nil {
+		return
+	}
+	C.free(unsafe.Pointer(s))
+}
+
+// FreeString is an alias of FreeCString
+//
+//export FreeString
+func FreeString(s *C.char) {
+	FreeCString(s)
+}
@@ -0,0 +1,65 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"fmt"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// maxDigitsK bounds the k of FibLeadingDigits and FibLastDigits, which sizes both the string and the work
+const maxDigitsK = 1_000_000
+
+// FibDigitCount returns the number of decimal digits of F(n) without materializing it
+// Uses the Binet logarithm, falling back to the exact value near digit boundaries.
+//
+//export FibDigitCount
+func FibDigitCount(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(fib.DigitCount(uint64(n)))
+}
+
+// FibLeadingDigits returns the first k decimal digits of F(n) as a C string
+// Only O(k + log n) bits of precision are used, so n may be arbitrarily large.
+// Returns all digits if F(n) has fewer than k; the caller must release it with FreeCString.
+// Returns NULL with StatusLimitExceeded if k exceeds 1000000.
+//
+//export FibLeadingDigits
+func FibLeadingDigits(n, k C.uint64_t) *C.char {
+	defer recoverPanic()
+	doc, status, err := leadingDigitsDocument(n, k)
+	return documentCString(doc, status, err)
+}
+
+// leadingDigitsDocument computes the string of FibLeadingDigits and its twin
+func leadingDigitsDocument(n, k C.uint64_t) (string, C.fib_status, error) {
+	if k > maxDigitsK {
+		return "", StatusLimitExceeded, fmt.Errorf("FibLeadingDigits: k must be <= %d", maxDigitsK)
+	}
+	return fib.LeadingDigits(uint64(n), uint64(k)), StatusOK, nil
+}
+
+// FibLastDigits returns F(n) mod 10^k as a k-digit zero-padded C string
+// Uses modular matrix power (uint64 for k <= 19, math/big beyond), so n may be arbitrarily large.
+// The caller must release the string with FreeCString. Returns NULL with
+// StatusLimitExceeded if k exceeds 1000000.
+//
+//export FibLastDigits
+func FibLastDigits(n, k C.uint64_t) *C.char {
+	defer recoverPanic()
+	doc, status, err := lastDigitsDocument(n, k)
+	return documentCString(doc, status, err)
+}
+
+// lastDigitsDocument computes the string of FibLastDigits and its twin
+func lastDigitsDocument(n, k C.uint64_t) (string, C.fib_status, error) {
+	if k > maxDigitsK {
+		return "", StatusLimitExceeded, fmt.Errorf("FibLastDigits: k must be <= %d", maxDigitsK)
+	}
+	return fib.LastDigits(uint64(n), uint64(k)), StatusOK, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+func TestLeadingDigitsDocument(t *testing.T) {
+	if doc, status, err := leadingDigitsDocument(1000, 10); status != StatusOK || doc != fib.LeadingDigits(1000, 10) {
+		t.Errorf("leadingDigitsDocument(1000, 10) = %q, %d, %v", doc, status, err)
+	}
+	if _, status, _ := leadingDigitsDocument(1000, maxDigitsK+1); status != StatusLimitExceeded {
+		t.Errorf("k = %d: status %d, want StatusLimitExceeded", maxDigitsK+1, status)
+	}
+	if _, status, _ := leadingDigitsDocument(1<<62, 1<<63); status != StatusLimitExceeded {
+		t.Errorf("k = 2^63: status %d, want StatusLimitExceeded", status)
+	}
+}
+
+func TestLastDigitsDocument(t *testing.T) {
+	if doc, status, err := lastDigitsDocument(1000, 25); status != StatusOK || doc != fib.LastDigits(1000, 25) {
+		t.Errorf("lastDigitsDocument(1000, 25) = %q, %d, %v", doc, status, err)
+	}
+	if _, status, _ := lastDigitsDocument(1000, maxDigitsK+1); status != StatusLimitExceeded {
+		t.Errorf("k = %d: status %d, want StatusLimitExceeded", maxDigitsK+1, status)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// Every export defers recoverStatus or recoverPanic, so a Go panic never unwinds
+// into the foreign caller: it is turned into StatusInternal (or a zero result)
+// and kept as the last error for diagnostics. Failing statuses are recorded too.
+// The last error is tracked per foreign thread (see lasterror.go) and, like errno,
+// is only changed by failures and ClearLastError.
+
+// statusText describes a failing status code
+func statusText(code C.fib_status) string {
+	switch code {
+	case StatusOverflow:
+		return "overflow: the result does not fit in the requested type"
+	case StatusInvalidArg:
+		return "invalid argument"
+	case StatusLimitExceeded:
+		return "limit exceeded: refused by a configured cutoff"
+	case StatusNotFound:
+		return "not found"
+	case StatusBufferTooSmall:
+		return "buffer too small: the required size was reported"
+	case StatusInternal:
+		return "internal error"
+	case StatusInvalidState:
+		return "invalid state: the library lifecycle does not allow this call"
+	case StatusCancelled:
+		return "cancelled: the cancel token was cancelled"
+	case StatusTimeout:
+		return "timeout: the deadline of the call passed"
+	case StatusInexact:
+		return "inexact: an approximation of the result was stored"
+	}
+	return fmt.Sprintf("status %d", code)
+}
+
+// statusOf maps an error of package fib to its status code
+func statusOf(err error) C.fib_status {
+	switch {
+	case err == nil:
+		return StatusOK
+	case errors.Is(err, fib.ErrOverflow):
+		return StatusOverflow
+	case errors.Is(err, fib.ErrInvalidInput):
+		return StatusInvalidArg
+	case errors.Is(err, context.Canceled):
+		return StatusCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		return StatusTimeout
+	}
+	return StatusInternal
+}
+
+// recoverStatus is deferred by exports returning a fib_status: a panic becomes StatusInternal
+// A message recorded with failWith is kept; other failures get the generic statusText.
+func recoverStatus(status *C.fib_status) {
+	detailed := takeLastErrorDetail()
+	if r := recover(); r != nil {
+		*status = StatusInternal
+		setLastError(C.int32_t(StatusInternal), fmt.Sprint("panic: ", r))
+		return
+	}
+	if *status != StatusOK && !detailed {
+		setLastError(C.int32_t(*status), statusText(*status))
+	}
+}
+
+// failWith records a call-specific message for code and returns code
+// It is meant for exports deferring recoverStatus, which then keeps the message.
+func failWith(code C.fib_status, format string, args ...any) C.fib_status {
+	setLastErrorDetail(C.int32_t(code), fmt.Sprintf(format, args...))
+	return code
+}
+
+// recoverPanic is deferred by exports without a status: a panic yields their zero result
+func recoverPanic() {
+	if r := recover(); r != nil {
+		setLastError(C.int32_t(StatusInternal), fmt.Sprint("panic: ", r))
+	}
+}
+
+// GetLastErrorCode returns the status code of the calling thread's last error, StatusOK if none
+//
+//export GetLastErrorCode
+func GetLastErrorCode() C.fib_status {
+	return C.fib_status(lastErrorCode())
+}
+
+// GetLastErrorString describes the calling thread's last error, or returns NULL if none
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export GetLastErrorString
+func GetLastErrorString() *C.char {
+	return lastErrorMessage()
+}
+
+// ClearLastError resets the calling thread's last error to StatusOK
+//
+//export ClearLastError
+func ClearLastError() {
+	clearLastError()
+}
+
+// GetLastError is an alias of GetLastErrorCode
+//
+//export GetLastError
+func GetLastError() C.fib_status {
+	return GetLastErrorCode()
+}
+
+// GetLastErrorMessage is an alias of GetLastErrorString
+//
+//export GetLastErrorMessage
+func GetLastErrorMessage() *C.char {
+	return GetLastErrorString()
+}
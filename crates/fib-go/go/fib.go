@@ -5,105 +5,53 @@ package main
 */
 import "C"
 
-// Matrix2x2 represents a 2x2 matrix for Fibonacci calculation
-type Matrix2x2 struct {
-	a, b, c, d uint64
-}
+import (
+	"math/bits"
+	"os"
+	"runtime"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
 
 // FibIterative calculates Fibonacci using iterative method - O(n)
 //
 //export FibIterative
 func FibIterative(n C.uint64_t) C.uint64_t {
-	if n <= 1 {
-		return C.uint64_t(n)
-	}
-
-	var a, b uint64 = 0, 1
-	for i := uint64(2); i <= uint64(n); i++ {
-		a, b = b, a+b
-	}
-	return C.uint64_t(b)
+	defer recoverPanic()
+	return C.uint64_t(debugVerify(uint64(n), tracedUint64("FibIterative", "iterative", uint64(n), fib.Iterative)))
 }
 
 // FibRecursive calculates Fibonacci using naive recursive method - O(2^n)
-// WARNING: Very slow for n > 35
+// WARNING: Very slow for n > 35; above the SetRecursiveMaxN cutoff it falls back
+// to memoization, or returns 0 when the cutoff is configured as an error.
 //
 //export FibRecursive
 func FibRecursive(n C.uint64_t) C.uint64_t {
-	return C.uint64_t(fibRecursiveGo(uint64(n)))
-}
-
-func fibRecursiveGo(n uint64) uint64 {
-	if n <= 1 {
-		return n
+	defer recoverPanic()
+	span := startSpan("FibRecursive", "recursive", uint64(n))
+	value, status := fibRecursiveSafe(uint64(n))
+	span.end(bits.Len64(value), status)
+	if status != StatusOK {
+		return 0
 	}
-	return fibRecursiveGo(n-1) + fibRecursiveGo(n-2)
+	return C.uint64_t(debugVerify(uint64(n), value))
 }
 
 // FibMemo calculates Fibonacci with memoization - O(n)
+// The memo is shared by all calls (see fib/memo.go), so repeated calls are O(1).
 //
 //export FibMemo
 func FibMemo(n C.uint64_t) C.uint64_t {
-	memo := make(map[uint64]uint64)
-	return C.uint64_t(fibMemoGo(uint64(n), memo))
-}
-
-func fibMemoGo(n uint64, memo map[uint64]uint64) uint64 {
-	if n <= 1 {
-		return n
-	}
-	if val, ok := memo[n]; ok {
-		return val
-	}
-	result := fibMemoGo(n-1, memo) + fibMemoGo(n-2, memo)
-	memo[n] = result
-	return result
-}
-
-// matrixMultiply multiplies two 2x2 matrices
-func matrixMultiply(m1, m2 Matrix2x2) Matrix2x2 {
-	return Matrix2x2{
-		a: m1.a*m2.a + m1.b*m2.c,
-		b: m1.a*m2.b + m1.b*m2.d,
-		c: m1.c*m2.a + m1.d*m2.c,
-		d: m1.c*m2.b + m1.d*m2.d,
-	}
-}
-
-// matrixPower calculates matrix power using fast exponentiation
-func matrixPower(m Matrix2x2, n uint64) Matrix2x2 {
-	if n == 0 {
-		return Matrix2x2{a: 1, b: 0, c: 0, d: 1} // Identity matrix
-	}
-	if n == 1 {
-		return m
-	}
-
-	result := Matrix2x2{a: 1, b: 0, c: 0, d: 1} // Identity
-	base := m
-
-	for n > 0 {
-		if n%2 == 1 {
-			result = matrixMultiply(result, base)
-		}
-		base = matrixMultiply(base, base)
-		n /= 2
-	}
-
-	return result
+	defer recoverPanic()
+	return C.uint64_t(debugVerify(uint64(n), tracedUint64("FibMemo", "memo", uint64(n), fib.Memo)))
 }
 
 // FibMatrix calculates Fibonacci using matrix exponentiation - O(log n)
 //
 //export FibMatrix
 func FibMatrix(n C.uint64_t) C.uint64_t {
-	if n == 0 {
-		return 0
-	}
-
-	fibMatrix := Matrix2x2{a: 1, b: 1, c: 1, d: 0}
-	result := matrixPower(fibMatrix, uint64(n))
-	return C.uint64_t(result.b)
+	defer recoverPanic()
+	return C.uint64_t(debugVerify(uint64(n), tracedUint64("FibMatrix", "matrix", uint64(n), fib.Matrix)))
 }
 
 // FibDoubling uses the doubling method - O(log n)
@@ -112,43 +60,30 @@ func FibMatrix(n C.uint64_t) C.uint64_t {
 //
 //export FibDoubling
 func FibDoubling(n C.uint64_t) C.uint64_t {
-	return C.uint64_t(fibDoublingGo(uint64(n)))
-}
-
-func fibDoublingGo(n uint64) uint64 {
-	if n == 0 {
-		return 0
-	}
-	return fibDoublingHelper(n)[0]
+	defer recoverPanic()
+	return C.uint64_t(debugVerify(uint64(n), tracedUint64("FibDoubling", "doubling", uint64(n), fib.Doubling)))
 }
 
-// Returns (F(n), F(n+1))
-func fibDoublingHelper(n uint64) [2]uint64 {
-	if n == 0 {
-		return [2]uint64{0, 1}
-	}
-
-	pair := fibDoublingHelper(n / 2)
-	fk := pair[0]
-	fk1 := pair[1]
-
-	// F(2k) = F(k) * (2*F(k+1) - F(k))
-	f2k := fk * (2*fk1 - fk)
-	// F(2k+1) = F(k)^2 + F(k+1)^2
-	f2k1 := fk*fk + fk1*fk1
-
-	if n%2 == 0 {
-		return [2]uint64{f2k, f2k1}
-	}
-	return [2]uint64{f2k1, f2k + f2k1}
+// FibDoublingIter uses the doubling method without recursion - O(log n)
+// Walks the bits of n from the most significant one, keeping (F(k), F(k+1)).
+//
+//export FibDoublingIter
+func FibDoublingIter(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(debugVerify(uint64(n), tracedUint64("FibDoublingIter", "doubling_iter", uint64(n), fib.DoublingIter)))
 }
 
-// GetGoVersion returns the Go version as a string
+// GetGoVersion returns the version of the Go runtime the library was built with
+// The string is owned by the caller and must be released with FreeCString.
 //
 //export GetGoVersion
 func GetGoVersion() *C.char {
-	return C.CString("go1.25.5")
+	defer recoverPanic()
+	return C.CString(runtime.Version())
 }
 
-// main is required for CGO but won't be called
-func main() {}
+// main runs the JSON-lines protocol when the package is built as a program
+// It is never called in the c-archive and c-shared builds of the library.
+func main() {
+	os.Exit(runProgram(os.Args[1:]))
+}
@@ -0,0 +1,75 @@
+package fib
+
+import (
+	"math/big"
+	"sync/atomic"
+)
+
+// Thresholds are the crossover points Auto uses to pick an algorithm
+type Thresholds struct {
+	// Iterative128MaxN is the largest n computed by Iterative128
+	Iterative128MaxN uint64 `json:"iterative128_max_n"`
+	// Matrix128MaxN is the largest n computed by Matrix128; Doubling128 takes the rest
+	Matrix128MaxN uint64 `json:"matrix128_max_n"`
+	// BigIterativeMaxN is the largest n computed by BigIterative
+	BigIterativeMaxN uint64 `json:"big_iterative_max_n"`
+	// BigMatrixMaxN is the largest n computed by BigMatrix; BigDoubling takes the rest
+	BigMatrixMaxN uint64 `json:"big_matrix_max_n"`
+}
+
+// DefaultThresholds are the crossovers measured on the reference machine
+// There doubling already beats iteration and matrices right above the lookup
+// table, so only doubling is used until a host calibrates otherwise.
+var DefaultThresholds = Thresholds{
+	Iterative128MaxN: MaxSafeN,
+	Matrix128MaxN:    MaxSafeN,
+	BigIterativeMaxN: MaxSafeN128,
+	BigMatrixMaxN:    MaxSafeN128,
+}
+
+// autoThresholds holds the Thresholds in force
+var autoThresholds atomic.Pointer[Thresholds]
+
+func init() {
+	t := DefaultThresholds
+	autoThresholds.Store(&t)
+}
+
+// SetAutoThresholds installs the crossover points used by Auto
+func SetAutoThresholds(t Thresholds) {
+	autoThresholds.Store(&t)
+}
+
+// AutoThresholds returns the crossover points used by Auto
+func AutoThresholds() Thresholds {
+	return *autoThresholds.Load()
+}
+
+// Auto calculates F(n) with the fastest safe algorithm for n
+// The lookup table up to MaxSafeN, 128-bit arithmetic up to MaxSafeN128, then
+// math/big; AutoThresholds decides between iteration, matrices and
+// doubling in each range.
+func Auto(n uint64) *big.Int {
+	t := AutoThresholds()
+	switch {
+	case n <= MaxSafeN:
+		return new(big.Int).SetUint64(Lookup(n))
+	case n <= MaxSafeN128:
+		var x Uint128
+		switch {
+		case n <= t.Iterative128MaxN:
+			x = Iterative128(n)
+		case n <= t.Matrix128MaxN:
+			x = Matrix128(n)
+		default:
+			x = Doubling128(n)
+		}
+		z := new(big.Int).SetUint64(x.Hi)
+		return z.Lsh(z, 64).Or(z, new(big.Int).SetUint64(x.Lo))
+	case n <= t.BigIterativeMaxN:
+		return BigIterative(n)
+	case n <= t.BigMatrixMaxN:
+		return BigMatrix(n)
+	}
+	return BigDoubling(n)
+}
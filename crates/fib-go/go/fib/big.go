@@ -0,0 +1,148 @@
+package fib
+
+import (
+	"context"
+	"math/big"
+)
+
+// BigMatrix2x2 represents a 2x2 matrix of arbitrary-precision integers
+type BigMatrix2x2 struct {
+	a, b, c, d *big.Int
+}
+
+// BigIterative calculates F(n) with math/big using the iterative method - O(n) additions
+func BigIterative(n uint64) *big.Int {
+	x, _ := BigIterativeContext(context.Background(), n)
+	return x
+}
+
+// iterativeCheckInterval is the number of additions BigIterativeContext makes between ctx checks
+const iterativeCheckInterval = 1 << 10
+
+// BigIterativeContext is BigIterative checking ctx every 1024 additions
+// It returns ctx.Err() once ctx is done.
+func BigIterativeContext(ctx context.Context, n uint64) (*big.Int, error) {
+	a, b := big.NewInt(0), big.NewInt(1)
+	if n == 0 {
+		return a, nil
+	}
+	for i := uint64(2); i <= n; i++ {
+		if i%iterativeCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return b, nil
+}
+
+// bigMatrixMultiply multiplies two 2x2 big-integer matrices
+func bigMatrixMultiply(m1, m2 BigMatrix2x2) BigMatrix2x2 {
+	mul := func(x, y, z, w *big.Int) *big.Int {
+		var t big.Int
+		r := new(big.Int).Mul(x, y)
+		return r.Add(r, t.Mul(z, w))
+	}
+	return BigMatrix2x2{
+		a: mul(m1.a, m2.a, m1.b, m2.c),
+		b: mul(m1.a, m2.b, m1.b, m2.d),
+		c: mul(m1.c, m2.a, m1.d, m2.c),
+		d: mul(m1.c, m2.b, m1.d, m2.d),
+	}
+}
+
+// bigMatrixPower calculates big-integer matrix power using fast exponentiation
+// ctx is checked before every squaring; it returns ctx.Err() once ctx is done.
+func bigMatrixPower(ctx context.Context, m BigMatrix2x2, n uint64) (BigMatrix2x2, error) {
+	result := BigMatrix2x2{a: big.NewInt(1), b: big.NewInt(0), c: big.NewInt(0), d: big.NewInt(1)} // Identity
+	base := m
+
+	for n > 0 {
+		if err := ctx.Err(); err != nil {
+			return BigMatrix2x2{}, err
+		}
+		if n%2 == 1 {
+			result = bigMatrixMultiply(result, base)
+		}
+		n /= 2
+		if n > 0 {
+			base = bigMatrixMultiply(base, base)
+		}
+	}
+
+	return result, nil
+}
+
+// BigMatrix calculates F(n) with math/big using matrix exponentiation - O(log n) multiplications
+func BigMatrix(n uint64) *big.Int {
+	x, _ := BigMatrixContext(context.Background(), n)
+	return x
+}
+
+// BigMatrixContext is BigMatrix checking ctx between matrix squarings
+// It returns ctx.Err() once ctx is done; a single product is never interrupted.
+func BigMatrixContext(ctx context.Context, n uint64) (*big.Int, error) {
+	if n == 0 {
+		return big.NewInt(0), nil
+	}
+
+	fibMatrix := BigMatrix2x2{a: big.NewInt(1), b: big.NewInt(1), c: big.NewInt(1), d: big.NewInt(0)}
+	m, err := bigMatrixPower(ctx, fibMatrix, n)
+	if err != nil {
+		return nil, err
+	}
+	return m.b, nil
+}
+
+// BigDoubling calculates F(n) with math/big using the doubling method - O(log n) multiplications
+func BigDoubling(n uint64) *big.Int {
+	fk, _ := BigPair(n)
+	return fk
+}
+
+// BigPair returns (F(n), F(n+1)) from a single big-integer doubling computation
+func BigPair(n uint64) (*big.Int, *big.Int) {
+	if n == 0 {
+		return big.NewInt(0), big.NewInt(1)
+	}
+
+	fk, fk1 := BigPair(n / 2)
+
+	// F(2k) = F(k) * (2*F(k+1) - F(k))
+	f2k := new(big.Int).Lsh(fk1, 1)
+	f2k.Sub(f2k, fk)
+	f2k.Mul(f2k, fk)
+	// F(2k+1) = F(k)^2 + F(k+1)^2
+	f2k1 := new(big.Int).Mul(fk, fk)
+	f2k1.Add(f2k1, fk1.Mul(fk1, fk1))
+
+	if n%2 == 0 {
+		return f2k, f2k1
+	}
+	return f2k1, f2k.Add(f2k, f2k1)
+}
+
+// BigDoublingContext is BigDoubling checking ctx between doubling steps
+// It returns ctx.Err() once ctx is done; a single step is never interrupted.
+func BigDoublingContext(ctx context.Context, n uint64) (*big.Int, error) {
+	return BigDoublingProgress(ctx, n, nil)
+}
+
+// BigDoublingProgress is BigDoublingContext calling progress, if not nil, after every step
+// progress receives the steps done and the total, one step per bit of n.
+func BigDoublingProgress(ctx context.Context, n uint64, progress func(done, total int)) (*big.Int, error) {
+	c := NewBigComputation(n)
+	total := c.Remaining()
+	for !c.Done() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		c.Step()
+		if progress != nil {
+			progress(total-c.Remaining(), total)
+		}
+	}
+	return c.Result(), nil
+}
@@ -0,0 +1,95 @@
+package fib
+
+import "math/big"
+
+// Arbitrary-precision elementary functions on big.Float, which math/big does not provide.
+// Every function works with guardBits of extra precision and rounds the result to prec.
+
+const guardBits = 64
+
+// bigAtanh returns atanh(y) = y + y^3/3 + y^5/5 + ... for |y| < 1
+func bigAtanh(y *big.Float, prec uint) *big.Float {
+	y2 := new(big.Float).SetPrec(prec).Mul(y, y)
+	power := new(big.Float).SetPrec(prec).Set(y)
+	sum := new(big.Float).SetPrec(prec).Set(y)
+	term := new(big.Float).SetPrec(prec)
+	for k := int64(3); ; k += 2 {
+		power.Mul(power, y2)
+		term.Quo(power, big.NewFloat(float64(k)))
+		if term.Sign() == 0 || term.MantExp(nil)-sum.MantExp(nil) < -int(prec) {
+			return sum
+		}
+		sum.Add(sum, term)
+	}
+}
+
+// bigLn2 returns ln 2 = 2 atanh(1/3)
+func bigLn2(prec uint) *big.Float {
+	third := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3))
+	ln2 := bigAtanh(third, prec)
+	return ln2.Mul(ln2, big.NewFloat(2))
+}
+
+// bigLn returns the natural logarithm of x > 0
+func bigLn(x *big.Float, prec uint) *big.Float {
+	work := prec + guardBits
+
+	// x = mant * 2^exp with mant in [sqrt(1/2), sqrt(2)) keeps the series argument small
+	mant := new(big.Float).SetPrec(work)
+	exp := x.MantExp(mant)
+	if mant.Cmp(big.NewFloat(0.7071067811865476)) < 0 {
+		mant.SetMantExp(mant, 1)
+		exp--
+	}
+
+	// ln(mant) = 2 atanh((mant-1)/(mant+1))
+	num := new(big.Float).SetPrec(work).Sub(mant, big.NewFloat(1))
+	den := new(big.Float).SetPrec(work).Add(mant, big.NewFloat(1))
+	result := bigAtanh(num.Quo(num, den), work)
+	result.Mul(result, big.NewFloat(2))
+
+	if exp != 0 {
+		scaled := bigLn2(work)
+		scaled.Mul(scaled, new(big.Float).SetInt64(int64(exp)))
+		result.Add(result, scaled)
+	}
+	return result.SetPrec(prec)
+}
+
+// bigExp returns e^x
+func bigExp(x *big.Float, prec uint) *big.Float {
+	const halvings = 16
+	work := prec + guardBits + halvings
+
+	// x = k*ln2 + r, then e^r = (e^(r/2^halvings))^(2^halvings)
+	ln2 := bigLn2(work)
+	kf := new(big.Float).SetPrec(work).Quo(x, ln2)
+	k, _ := kf.Int64()
+	r := new(big.Float).SetPrec(work).Mul(ln2, new(big.Float).SetInt64(k))
+	r.Sub(x, r)
+	r.SetMantExp(r, -halvings)
+
+	// Taylor series of e^r
+	sum := new(big.Float).SetPrec(work).SetInt64(1)
+	term := new(big.Float).SetPrec(work).SetInt64(1)
+	for i := int64(1); ; i++ {
+		term.Mul(term, r)
+		term.Quo(term, new(big.Float).SetInt64(i))
+		if term.Sign() == 0 || term.MantExp(nil) < -int(work) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for i := 0; i < halvings; i++ {
+		sum.Mul(sum, sum)
+	}
+	return sum.SetMantExp(sum, int(k)).SetPrec(prec)
+}
+
+// bigPhi returns the golden ratio (1 + sqrt(5)) / 2
+func bigPhi(prec uint) *big.Float {
+	phi := new(big.Float).SetPrec(prec).SetInt64(5)
+	phi.Sqrt(phi)
+	phi.Add(phi, big.NewFloat(1))
+	return phi.Quo(phi, big.NewFloat(2))
+}
@@ -0,0 +1,80 @@
+package fib
+
+import (
+	"context"
+	"math/big"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultParallelMulThreshold is the operand size, in bits, from which BigDoublingParallel multiplies concurrently
+// Below it the goroutine handoff costs more than the products it overlaps.
+const DefaultParallelMulThreshold = 1 << 15
+
+// parallelMulThreshold is the configured threshold of BigDoublingParallel
+var parallelMulThreshold atomic.Uint64
+
+func init() {
+	parallelMulThreshold.Store(DefaultParallelMulThreshold)
+}
+
+// SetParallelMulThreshold sets the operand size, in bits, from which BigDoublingParallel goes concurrent
+func SetParallelMulThreshold(bits uint64) {
+	parallelMulThreshold.Store(bits)
+}
+
+// ParallelMulThreshold returns the operand size, in bits, from which BigDoublingParallel goes concurrent
+func ParallelMulThreshold() uint64 {
+	return parallelMulThreshold.Load()
+}
+
+// BigDoublingParallel is BigDoubling running the three products of each step on separate goroutines - O(log n) multiplications
+// Steps whose operands are smaller than ParallelMulThreshold bits stay sequential.
+func BigDoublingParallel(n uint64) *big.Int {
+	x, _ := BigDoublingParallelContext(context.Background(), n)
+	return x
+}
+
+// BigDoublingParallelContext is BigDoublingParallel checking ctx between doubling steps
+// It returns ctx.Err() once ctx is done; a single step is never interrupted.
+func BigDoublingParallelContext(ctx context.Context, n uint64) (*big.Int, error) {
+	fk, fk1 := big.NewInt(0), big.NewInt(1)
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// F(2k) = F(k) * (2*F(k+1) - F(k))
+		f2k := new(big.Int).Lsh(fk1, 1)
+		f2k.Sub(f2k, fk)
+		// F(2k+1) = F(k)^2 + F(k+1)^2
+		sq, sq1 := new(big.Int), new(big.Int)
+
+		if uint64(fk1.BitLen()) >= parallelMulThreshold.Load() {
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				f2k.Mul(f2k, fk)
+			}()
+			go func() {
+				defer wg.Done()
+				sq.Mul(fk, fk)
+			}()
+			sq1.Mul(fk1, fk1)
+			wg.Wait()
+		} else {
+			f2k.Mul(f2k, fk)
+			sq.Mul(fk, fk)
+			sq1.Mul(fk1, fk1)
+		}
+		f2k1 := sq.Add(sq, sq1)
+
+		if (n>>uint(i))&1 == 0 {
+			fk, fk1 = f2k, f2k1
+		} else {
+			fk, fk1 = f2k1, f2k.Add(f2k, f2k1)
+		}
+	}
+	return fk, nil
+}
@@ -0,0 +1,47 @@
+package fib
+
+import (
+	"context"
+	"math/big"
+	"math/bits"
+)
+
+// square sets z = x^2 and returns z
+// Passing the same operand twice lets math/big pick its squaring kernel,
+// which is cheaper than a general multiplication of the same size.
+func square(z, x *big.Int) *big.Int {
+	return z.Mul(x, x)
+}
+
+// BigDoublingSquare calculates F(n) with math/big using doubling steps built from squarings only - O(log n) squarings
+// F(2k) = F(k+1)^2 - F(k-1)^2, with F(k-1) = F(k+1) - F(k)
+// F(2k+1) = F(k)^2 + F(k+1)^2
+// Three squarings replace the general product and two squarings of BigDoubling.
+func BigDoublingSquare(n uint64) *big.Int {
+	x, _ := BigDoublingSquareContext(context.Background(), n)
+	return x
+}
+
+// BigDoublingSquareContext is BigDoublingSquare checking ctx between doubling steps
+// It returns ctx.Err() once ctx is done; a single step is never interrupted.
+func BigDoublingSquareContext(ctx context.Context, n uint64) (*big.Int, error) {
+	fk, fk1 := big.NewInt(0), big.NewInt(1)
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fkm1 := new(big.Int).Sub(fk1, fk)
+		sq := square(new(big.Int), fk)
+		sq1 := square(new(big.Int), fk1)
+		f2k := square(fkm1, fkm1)
+		f2k.Sub(sq1, f2k)
+		f2k1 := sq.Add(sq, sq1)
+
+		if (n>>uint(i))&1 == 0 {
+			fk, fk1 = f2k, f2k1
+		} else {
+			fk, fk1 = f2k1, f2k.Add(f2k, f2k1)
+		}
+	}
+	return fk, nil
+}
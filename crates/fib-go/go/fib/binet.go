@@ -0,0 +1,62 @@
+package fib
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// MaxExactBinetN is the largest n for which Binet rounds to F(n) exactly
+// Past it float64's 53-bit mantissa can no longer hold the error of phi^n.
+const MaxExactBinetN = 75
+
+// MaxBinetN is the largest n for which F(n) is a finite float64
+const MaxBinetN = 1476
+
+// Binet evaluates Binet's formula round(phi^n / sqrt(5)) in float64 - O(1)
+// The result is F(n) up to MaxExactBinetN, a rounded approximation up to
+// MaxBinetN and +Inf beyond.
+func Binet(n uint64) float64 {
+	if n > MaxBinetN {
+		return math.Inf(1)
+	}
+	phi := (1 + math.Sqrt(5)) / 2
+	if n < MaxBinetN-4 {
+		return math.Round(math.Pow(phi, float64(n)) / math.Sqrt(5))
+	}
+	// phi^n itself overflows at the end of the range
+	half := float64(n / 2)
+	return math.Round(math.Pow(phi, half) * (math.Pow(phi, float64(n)-half) / math.Sqrt(5)))
+}
+
+// BinetPrecision returns the big.Float precision with which BigBinet is exact for n
+// F(n) has about n*log2(phi) bits, extended by guard bits for the rounding error
+// of the log n squarings.
+func BinetPrecision(n uint64) uint {
+	return uint(float64(n)*math.Log2(math.Phi)) + guardBits + 2*uint(bits.Len64(n))
+}
+
+// BigBinet evaluates Binet's formula round(phi^n / sqrt(5)) with math/big.Float at prec bits
+// It is F(n) when prec >= BinetPrecision(n) and an approximation below; prec == 0
+// selects BinetPrecision(n). phi^n is taken by repeated squaring - O(log n)
+// multiplications of prec-bit numbers.
+func BigBinet(n uint64, prec uint) *big.Int {
+	if prec == 0 {
+		prec = BinetPrecision(n)
+	}
+	phi := bigPhi(prec)
+	power := new(big.Float).SetPrec(prec).SetInt64(1)
+	for k := n; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			power.Mul(power, phi)
+		}
+		if k > 1 {
+			phi.Mul(phi, phi)
+		}
+	}
+	sqrt5 := new(big.Float).SetPrec(prec).SetInt64(5)
+	power.Quo(power, sqrt5.Sqrt(sqrt5))
+	power.Add(power, big.NewFloat(0.5))
+	z, _ := power.Int(nil)
+	return z
+}
@@ -0,0 +1,81 @@
+package fib
+
+import (
+	"slices"
+	"time"
+)
+
+// calibrationTrials is the number of timings per algorithm and index; the fastest is kept
+const calibrationTrials = 3
+
+// calibrationBudget is the minimum duration of one timing, repeating the call as needed
+const calibrationBudget = 200 * time.Microsecond
+
+// calibrationGrid128 and calibrationGridBig are the indices timed by Calibrate in each range
+var (
+	calibrationGrid128 = []uint64{94, 100, 110, 120, 135, 150, 165, 186}
+	calibrationGridBig = []uint64{187, 256, 384, 512, 768, 1024, 1536, 2048, 4096, 8192}
+)
+
+// Calibrate times iteration, matrices and doubling on this host and returns the crossovers for Auto
+// It takes well under a second and does not install the result; see SetAutoThresholds.
+func Calibrate() Thresholds {
+	iter128, mat128 := crossovers(calibrationGrid128, MaxSafeN,
+		func(n uint64) { Iterative128(n) },
+		func(n uint64) { Matrix128(n) },
+		func(n uint64) { Doubling128(n) })
+	iterBig, matBig := crossovers(calibrationGridBig, MaxSafeN128,
+		func(n uint64) { BigIterative(n) },
+		func(n uint64) { BigMatrix(n) },
+		func(n uint64) { BigDoubling(n) })
+	return Thresholds{
+		Iterative128MaxN: iter128,
+		Matrix128MaxN:    mat128,
+		BigIterativeMaxN: iterBig,
+		BigMatrixMaxN:    matBig,
+	}
+}
+
+// crossovers returns the largest grid indices up to which iterative, then matrix, stays the fastest
+// Both start at floor, the last index of the previous range, when the method never wins.
+func crossovers(grid []uint64, floor uint64, iterative, matrix, doubling func(uint64)) (iterMaxN, matMaxN uint64) {
+	iterMaxN = floor
+	i := 0
+	for ; i < len(grid); i++ {
+		n := grid[i]
+		t := timeCall(iterative, n)
+		if t > timeCall(matrix, n) || t > timeCall(doubling, n) {
+			break
+		}
+		iterMaxN = n
+	}
+	matMaxN = iterMaxN
+	for ; i < len(grid); i++ {
+		n := grid[i]
+		if timeCall(matrix, n) > timeCall(doubling, n) {
+			break
+		}
+		matMaxN = n
+	}
+	return iterMaxN, matMaxN
+}
+
+// timeCall returns the fastest of calibrationTrials timings of fn(n)
+func timeCall(fn func(uint64), n uint64) time.Duration {
+	trials := make([]time.Duration, calibrationTrials)
+	for t := range trials {
+		reps := 1
+		for {
+			start := time.Now()
+			for r := 0; r < reps; r++ {
+				fn(n)
+			}
+			if elapsed := time.Since(start); elapsed >= calibrationBudget {
+				trials[t] = elapsed / time.Duration(reps)
+				break
+			}
+			reps *= 2
+		}
+	}
+	return slices.Min(trials)
+}
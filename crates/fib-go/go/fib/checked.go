@@ -0,0 +1,42 @@
+package fib
+
+import "math/bits"
+
+// CheckedIterative calculates F(n) iteratively, detecting overflow with carry checks
+// Returns (F(n), true), or (0, false) as soon as an addition carries out of 64 bits.
+func CheckedIterative(n uint64) (uint64, bool) {
+	if n <= 1 {
+		return n, true
+	}
+
+	var a, b uint64 = 0, 1
+	for i := uint64(2); i <= n; i++ {
+		sum, carry := bits.Add64(a, b, 0)
+		if carry != 0 {
+			return 0, false
+		}
+		a, b = b, sum
+	}
+	return b, true
+}
+
+// MaxSafeSignedN is the largest |n| for which F(n) fits in an int64
+const MaxSafeSignedN = 92
+
+// Signed calculates F(n) for any signed index, using F(-n) = (-1)^(n+1) F(n)
+// Returns (F(n), true), or (0, false) if F(n) does not fit in an int64.
+func Signed(n int64) (int64, bool) {
+	if n < -MaxSafeSignedN || n > MaxSafeSignedN {
+		return 0, false
+	}
+	if n >= 0 {
+		return int64(Doubling(uint64(n))), true
+	}
+
+	value := int64(Doubling(uint64(-n)))
+	if n%2 == 0 {
+		// (-1)^(|n|+1) is negative for even |n|
+		value = -value
+	}
+	return value, true
+}
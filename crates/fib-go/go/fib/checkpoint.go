@@ -0,0 +1,191 @@
+package fib
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"math/bits"
+	"os"
+	"path/filepath"
+)
+
+// BigComputation is a resumable big-integer fast-doubling computation of F(n)
+// It processes the bits of n from the most significant one, keeping (F(k), F(k+1))
+// for the prefix k read so far, and can be serialized between steps so that a
+// long computation survives a crash or a restart.
+type BigComputation struct {
+	n       uint64
+	bit     int // next bit of n to process; -1 once done
+	fk, fk1 *big.Int
+}
+
+// NewBigComputation returns a computation of F(n) positioned before its first step
+func NewBigComputation(n uint64) *BigComputation {
+	return &BigComputation{n: n, bit: bits.Len64(n) - 1, fk: big.NewInt(0), fk1: big.NewInt(1)}
+}
+
+// N returns the index being computed
+func (c *BigComputation) N() uint64 {
+	return c.n
+}
+
+// Remaining returns the number of doubling steps left, one per remaining bit of n
+func (c *BigComputation) Remaining() int {
+	return c.bit + 1
+}
+
+// Done reports whether every step has run
+func (c *BigComputation) Done() bool {
+	return c.bit < 0
+}
+
+// Step runs one doubling step; it does nothing once the computation is done
+func (c *BigComputation) Step() {
+	if c.Done() {
+		return
+	}
+	// F(2k) = F(k) * (2*F(k+1) - F(k))
+	f2k := new(big.Int).Lsh(c.fk1, 1)
+	f2k.Sub(f2k, c.fk)
+	f2k.Mul(f2k, c.fk)
+	// F(2k+1) = F(k)^2 + F(k+1)^2
+	f2k1 := new(big.Int).Mul(c.fk, c.fk)
+	f2k1.Add(f2k1, new(big.Int).Mul(c.fk1, c.fk1))
+
+	if (c.n>>uint(c.bit))&1 == 0 {
+		c.fk, c.fk1 = f2k, f2k1
+	} else {
+		c.fk, c.fk1 = f2k1, f2k.Add(f2k, f2k1)
+	}
+	c.bit--
+}
+
+// Run runs up to steps doubling steps, or all of them if steps <= 0, and reports whether it is done
+func (c *BigComputation) Run(steps int) bool {
+	for i := 0; !c.Done() && (steps <= 0 || i < steps); i++ {
+		c.Step()
+	}
+	return c.Done()
+}
+
+// RunContext is Run checking ctx before every step
+// It returns ctx.Err() as soon as ctx is done, leaving the computation resumable.
+func (c *BigComputation) RunContext(ctx context.Context, steps int) (bool, error) {
+	for i := 0; !c.Done() && (steps <= 0 || i < steps); i++ {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		c.Step()
+	}
+	return c.Done(), nil
+}
+
+// Result returns F(n) once the computation is done, nil before
+func (c *BigComputation) Result() *big.Int {
+	if !c.Done() {
+		return nil
+	}
+	return new(big.Int).Set(c.fk)
+}
+
+// checkpointMagic starts every serialized BigComputation; the last byte is the format version
+var checkpointMagic = []byte("FIBCKPT\x01")
+
+// MarshalBinary serializes the state of the computation
+// Layout: magic, n and bit (big-endian int64), the byte lengths and big-endian
+// magnitudes of F(k) and F(k+1), then a CRC-32 of everything before it.
+func (c *BigComputation) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(checkpointMagic)
+	binary.Write(&buf, binary.BigEndian, c.n)
+	binary.Write(&buf, binary.BigEndian, int64(c.bit))
+	for _, x := range []*big.Int{c.fk, c.fk1} {
+		magnitude := x.Bytes()
+		binary.Write(&buf, binary.BigEndian, uint64(len(magnitude)))
+		buf.Write(magnitude)
+	}
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(buf.Bytes()))
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a state written by MarshalBinary
+// It returns an error wrapping ErrInvalidInput for truncated, corrupted or inconsistent data.
+func (c *BigComputation) UnmarshalBinary(data []byte) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: checkpoint %s", ErrInvalidInput, reason)
+	}
+	if len(data) < len(checkpointMagic)+4 || !bytes.Equal(data[:len(checkpointMagic)], checkpointMagic) {
+		return invalid("has an unknown format")
+	}
+	body, sum := data[:len(data)-4], binary.BigEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return invalid("is corrupted")
+	}
+
+	r := bytes.NewReader(body[len(checkpointMagic):])
+	var n uint64
+	var bit int64
+	if binary.Read(r, binary.BigEndian, &n) != nil || binary.Read(r, binary.BigEndian, &bit) != nil {
+		return invalid("is truncated")
+	}
+	if bit < -1 || bit >= int64(bits.Len64(n)) {
+		return invalid("has an invalid bit position")
+	}
+	var pair [2]*big.Int
+	for i := range pair {
+		var length uint64
+		if binary.Read(r, binary.BigEndian, &length) != nil || length > uint64(r.Len()) {
+			return invalid("is truncated")
+		}
+		magnitude := make([]byte, length)
+		r.Read(magnitude)
+		pair[i] = new(big.Int).SetBytes(magnitude)
+	}
+	if r.Len() != 0 {
+		return invalid("has trailing data")
+	}
+	c.n, c.bit, c.fk, c.fk1 = n, int(bit), pair[0], pair[1]
+	return nil
+}
+
+// SaveFile writes a checkpoint of the computation to path
+// The data goes to a temporary file renamed over path, so a crash never leaves a torn checkpoint.
+func (c *BigComputation) SaveFile(path string) error {
+	data, err := c.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadBigComputation resumes a computation from a checkpoint written by SaveFile
+func LoadBigComputation(path string) (*BigComputation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &BigComputation{}
+	if err := c.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,40 @@
+package fib
+
+import "math/big"
+
+// Convergent is the rational P/Q of a continued fraction truncated after some term
+type Convergent struct {
+	P, Q *big.Int
+}
+
+// GoldenConvergents returns the depth+1 convergents of phi = [1; 1, 1, ...]
+// The k-th one follows p(k) = a(k) p(k-1) + p(k-2), with every a(k) = 1, and
+// equals F(k+2) / F(k+1).
+func GoldenConvergents(depth uint64) []Convergent {
+	convergents := make([]Convergent, 0, depth+1)
+	// p(-1)/q(-1) = 1/0 and p(-2)/q(-2) = 0/1 seed the recurrence
+	p1, q1 := big.NewInt(1), big.NewInt(0)
+	p2, q2 := big.NewInt(0), big.NewInt(1)
+	for k := uint64(0); k <= depth; k++ {
+		p := new(big.Int).Add(p1, p2)
+		q := new(big.Int).Add(q1, q2)
+		convergents = append(convergents, Convergent{p, q})
+		p1, p2 = p, p1
+		q1, q2 = q, q1
+	}
+	return convergents
+}
+
+// GoldenContinuedFraction evaluates [1; 1, ..., 1] with depth ones after the
+// semicolon in rational arithmetic, from the innermost term outwards: x = 1, then
+// depth times x = 1 + 1/x. Every step reduces the fraction, so it costs a big
+// gcd per term where GoldenConvergents only adds.
+func GoldenContinuedFraction(depth uint64) *big.Rat {
+	one := big.NewRat(1, 1)
+	x := big.NewRat(1, 1)
+	for i := uint64(0); i < depth; i++ {
+		x.Inv(x)
+		x.Add(x, one)
+	}
+	return x
+}
@@ -0,0 +1,97 @@
+package fib
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// exactDigitsMaxN is the largest n for which digit queries materialize F(n) exactly
+const exactDigitsMaxN = 1000
+
+// fibLog10 returns log10(phi^n / sqrt(5)) = n*log10(phi) - log10(5)/2 to prec bits
+// It equals log10 F(n) up to an error below phi^(-2n), negligible above exactDigitsMaxN.
+func fibLog10(n uint64, prec uint) *big.Float {
+	work := prec + guardBits
+	ln10 := bigLn(new(big.Float).SetPrec(work).SetInt64(10), work)
+
+	result := bigLn(bigPhi(work), work)
+	result.Mul(result, new(big.Float).SetPrec(work).SetUint64(n))
+	halfLn5 := bigLn(new(big.Float).SetPrec(work).SetInt64(5), work)
+	halfLn5.Quo(halfLn5, big.NewFloat(2))
+	result.Sub(result, halfLn5)
+	return result.Quo(result, ln10).SetPrec(prec)
+}
+
+// DigitCount returns the number of decimal digits of F(n) without materializing it
+// Uses the Binet logarithm, falling back to the exact value near digit boundaries.
+func DigitCount(n uint64) uint64 {
+	if n <= exactDigitsMaxN {
+		return uint64(len(BigDoubling(n).String()))
+	}
+
+	logValue := fibLog10(n, 192)
+	whole, _ := logValue.Int(nil)
+	frac := new(big.Float).Sub(logValue, new(big.Float).SetInt(whole))
+
+	// A fractional part this close to 0 or 1 is beyond what the precision can settle
+	const boundary = 1e-20
+	if f, _ := frac.Float64(); f < boundary || f > 1-boundary {
+		return uint64(len(BigDoubling(n).String()))
+	}
+	return whole.Uint64() + 1
+}
+
+// LeadingDigits returns the first k decimal digits of F(n)
+// Only O(k + log n) bits of precision are used, so n may be arbitrarily large.
+// Returns all digits if F(n) has fewer than k.
+func LeadingDigits(n, k uint64) string {
+	if k == 0 {
+		return ""
+	}
+	if n <= exactDigitsMaxN {
+		digits := BigDoubling(n).String()
+		return digits[:min(uint64(len(digits)), k)]
+	}
+	if total := DigitCount(n); k >= total {
+		return BigDoubling(n).String()
+	}
+
+	// 10^(frac + k - 1) has exactly k digits before the decimal point
+	prec := uint(64 + 4*k)
+	logValue := fibLog10(n, prec)
+	whole, _ := logValue.Int(nil)
+	exponent := new(big.Float).SetPrec(prec).Sub(logValue, new(big.Float).SetInt(whole))
+	exponent.Add(exponent, new(big.Float).SetUint64(k-1))
+
+	ln10 := bigLn(new(big.Float).SetPrec(prec).SetInt64(10), prec)
+	leading, _ := bigExp(exponent.Mul(exponent, ln10), prec).Int(nil)
+
+	digits := leading.String()
+	if uint64(len(digits)) < k {
+		digits += strings.Repeat("0", int(k)-len(digits))
+	}
+	return digits[:k]
+}
+
+// maxUint64Pow10 is the largest k for which 10^k fits in a uint64
+const maxUint64Pow10 = 19
+
+// LastDigits returns F(n) mod 10^k as a k-digit zero-padded string
+// Uses modular matrix power (uint64 for k <= 19, math/big beyond), so n may be arbitrarily large.
+func LastDigits(n, k uint64) string {
+	if k == 0 {
+		return ""
+	}
+	if k <= maxUint64Pow10 {
+		modulus := uint64(1)
+		for i := uint64(0); i < k; i++ {
+			modulus *= 10
+		}
+		return fmt.Sprintf("%0*d", int(k), Mod(n, modulus))
+	}
+
+	modulus := new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(k), nil)
+	digits := BigMod(n, modulus).String()
+	return strings.Repeat("0", int(k)-len(digits)) + digits
+}
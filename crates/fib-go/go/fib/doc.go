@@ -0,0 +1,7 @@
+// Package fib implements the Fibonacci algorithms of the benchmark in pure Go.
+//
+// It has no cgo dependency and can be imported directly; the parent package
+// only wraps it in the C interface of libfibgo. Functions returning uint64 wrap
+// modulo 2^64 past F(93) (see MaxSafeN) unless they report overflow; the Big*
+// variants are exact.
+package fib
@@ -0,0 +1,11 @@
+package fib
+
+import "errors"
+
+// Errors reported by the functions that validate their input
+var (
+	// ErrOverflow means the result does not fit in the integer type returned
+	ErrOverflow = errors.New("fib: result overflows")
+	// ErrInvalidInput means an argument is outside the domain of the function
+	ErrInvalidInput = errors.New("fib: invalid input")
+)
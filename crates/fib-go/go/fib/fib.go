@@ -0,0 +1,151 @@
+package fib
+
+import "math/bits"
+
+// MaxSafeN is the largest n for which F(n) fits in a uint64
+const MaxSafeN = 93
+
+// Matrix2x2 represents a 2x2 matrix for Fibonacci calculation
+type Matrix2x2 struct {
+	a, b, c, d uint64
+}
+
+// Iterative calculates F(n) using the iterative method - O(n)
+func Iterative(n uint64) uint64 {
+	if n <= 1 {
+		return n
+	}
+
+	var a, b uint64 = 0, 1
+	for i := uint64(2); i <= n; i++ {
+		a, b = b, a+b
+	}
+	return b
+}
+
+// Recursive calculates F(n) using the naive recursive method - O(2^n)
+// WARNING: Very slow for n > 35.
+func Recursive(n uint64) uint64 {
+	if n <= 1 {
+		return n
+	}
+	return Recursive(n-1) + Recursive(n-2)
+}
+
+// memoFill returns F(n), computing the missing entries of memo in ascending order
+// memo always holds a prefix F(2)..F(k), so the fill resumes after its last entry
+// and neither recurses nor revisits an entry.
+func memoFill(n uint64, memo map[uint64]uint64) uint64 {
+	if n <= 1 {
+		return n
+	}
+	if val, ok := memo[n]; ok {
+		return val
+	}
+	k := uint64(len(memo)) + 1   // memo holds F(2)..F(k)
+	a, b := uint64(0), uint64(1) // F(k-1), F(k)
+	if k >= 2 {
+		a, b = 1, memo[k]
+		if k >= 3 {
+			a = memo[k-1]
+		}
+	}
+	for i := k + 1; i <= n; i++ {
+		a, b = b, a+b
+		memo[i] = b
+	}
+	return b
+}
+
+// matrixMultiply multiplies two 2x2 matrices
+func matrixMultiply(m1, m2 Matrix2x2) Matrix2x2 {
+	return Matrix2x2{
+		a: m1.a*m2.a + m1.b*m2.c,
+		b: m1.a*m2.b + m1.b*m2.d,
+		c: m1.c*m2.a + m1.d*m2.c,
+		d: m1.c*m2.b + m1.d*m2.d,
+	}
+}
+
+// matrixPower calculates matrix power using fast exponentiation
+func matrixPower(m Matrix2x2, n uint64) Matrix2x2 {
+	if n == 0 {
+		return Matrix2x2{a: 1, b: 0, c: 0, d: 1} // Identity matrix
+	}
+	if n == 1 {
+		return m
+	}
+
+	result := Matrix2x2{a: 1, b: 0, c: 0, d: 1} // Identity
+	base := m
+
+	for n > 0 {
+		if n%2 == 1 {
+			result = matrixMultiply(result, base)
+		}
+		base = matrixMultiply(base, base)
+		n /= 2
+	}
+
+	return result
+}
+
+// Matrix calculates F(n) using matrix exponentiation - O(log n)
+func Matrix(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+
+	fibMatrix := Matrix2x2{a: 1, b: 1, c: 1, d: 0}
+	return matrixPower(fibMatrix, n).b
+}
+
+// Doubling calculates F(n) using the doubling method - O(log n)
+// F(2k) = F(k) * (2*F(k+1) - F(k))
+// F(2k+1) = F(k)^2 + F(k+1)^2
+func Doubling(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	return Pair(n)[0]
+}
+
+// Pair returns (F(n), F(n+1)) from a single doubling computation - O(log n)
+func Pair(n uint64) [2]uint64 {
+	if n == 0 {
+		return [2]uint64{0, 1}
+	}
+
+	pair := Pair(n / 2)
+	fk := pair[0]
+	fk1 := pair[1]
+
+	// F(2k) = F(k) * (2*F(k+1) - F(k))
+	f2k := fk * (2*fk1 - fk)
+	// F(2k+1) = F(k)^2 + F(k+1)^2
+	f2k1 := fk*fk + fk1*fk1
+
+	if n%2 == 0 {
+		return [2]uint64{f2k, f2k1}
+	}
+	return [2]uint64{f2k1, f2k + f2k1}
+}
+
+// DoublingIter uses the doubling method without recursion - O(log n)
+// Walks the bits of n from the most significant one, keeping (F(k), F(k+1)).
+func DoublingIter(n uint64) uint64 {
+	var fk, fk1 uint64 = 0, 1
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		// F(2k) = F(k) * (2*F(k+1) - F(k))
+		f2k := fk * (2*fk1 - fk)
+		// F(2k+1) = F(k)^2 + F(k+1)^2
+		f2k1 := fk*fk + fk1*fk1
+
+		if (n>>uint(i))&1 == 0 {
+			fk, fk1 = f2k, f2k1
+		} else {
+			fk, fk1 = f2k1, f2k+f2k1
+		}
+	}
+	return fk
+}
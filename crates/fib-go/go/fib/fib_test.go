@@ -0,0 +1,317 @@
+package fib
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+// f1000 is F(1000), 209 decimal digits
+const f1000 = "43466557686937456435688527675040625802564660517371780402481729089536555417949051890403879840079255169295922593080322634775209689623239873322471161642996440906533187938298969649928516003704476137795166849228875"
+
+// reference returns F(0..n) by big-integer addition, independently from the algorithms under test
+func reference(n uint64) []*big.Int {
+	f := make([]*big.Int, n+1)
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := range f {
+		f[i] = new(big.Int).Set(a)
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return f
+}
+
+// uint64Algorithms are the algorithms returning F(n) modulo 2^64
+var uint64Algorithms = []struct {
+	name string
+	fn   func(uint64) uint64
+	maxN uint64 // largest n tested; Recursive is exponential
+}{
+	{"Iterative", Iterative, 200},
+	{"Recursive", Recursive, 25},
+	{"Memo", Memo, 200},
+	{"Matrix", Matrix, 200},
+	{"Doubling", Doubling, 200},
+	{"DoublingIter", DoublingIter, 200},
+	{"MemoFast", MemoFast, 200},
+	{"Lookup", Lookup, 200},
+}
+
+// bigAlgorithms are the exact big-integer algorithms
+var bigAlgorithms = []struct {
+	name string
+	fn   func(uint64) *big.Int
+}{
+	{"BigIterative", BigIterative},
+	{"BigMatrix", BigMatrix},
+	{"BigDoubling", BigDoubling},
+	{"BigDoublingParallel", BigDoublingParallel},
+	{"BigDoublingSquare", BigDoublingSquare},
+	{"LimbDoubling", LimbDoubling},
+	{"Auto", Auto},
+	{"BigBinet", func(n uint64) *big.Int { return BigBinet(n, 0) }},
+}
+
+func TestTable(t *testing.T) {
+	want := reference(MaxSafeN)
+	for n, v := range fibTable {
+		if want[n].Uint64() != v {
+			t.Errorf("fibTable[%d] = %d, want %s", n, v, want[n])
+		}
+	}
+}
+
+func TestUint64Algorithms(t *testing.T) {
+	ref := reference(200)
+	mask := new(big.Int).SetUint64(^uint64(0))
+	for _, algo := range uint64Algorithms {
+		t.Run(algo.name, func(t *testing.T) {
+			for n := uint64(0); n <= algo.maxN; n++ {
+				// Past MaxSafeN the algorithms wrap modulo 2^64
+				want := new(big.Int).And(ref[n], mask).Uint64()
+				if got := algo.fn(n); got != want {
+					t.Errorf("%s(%d) = %d, want %d", algo.name, n, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEdgeCases(t *testing.T) {
+	cases := []struct {
+		n    uint64
+		want uint64
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 1},
+		{92, 7540113804746346429},
+		{93, 12200160415121876738},
+		{94, 1293530146158671551}, // 19740274219868223167 mod 2^64
+	}
+	for _, c := range cases {
+		for _, algo := range uint64Algorithms {
+			if c.n > algo.maxN {
+				continue
+			}
+			if got := algo.fn(c.n); got != c.want {
+				t.Errorf("%s(%d) = %d, want %d", algo.name, c.n, got, c.want)
+			}
+		}
+	}
+
+	if got, ok := CheckedIterative(MaxSafeN); !ok || got != 12200160415121876738 {
+		t.Errorf("CheckedIterative(%d) = %d, %v, want F(93), true", MaxSafeN, got, ok)
+	}
+	if got, ok := CheckedIterative(MaxSafeN + 1); ok || got != 0 {
+		t.Errorf("CheckedIterative(%d) = %d, %v, want 0, false", MaxSafeN+1, got, ok)
+	}
+	if got, ok := Signed(-MaxSafeSignedN); !ok || got != -7540113804746346429 {
+		t.Errorf("Signed(%d) = %d, %v, want -F(92), true", -MaxSafeSignedN, got, ok)
+	}
+	if _, ok := Signed(MaxSafeSignedN + 1); ok {
+		t.Errorf("Signed(%d) fits in an int64", MaxSafeSignedN+1)
+	}
+	if got := BigDoubling(94).String(); got != "19740274219868223167" {
+		t.Errorf("BigDoubling(94) = %s, want 19740274219868223167", got)
+	}
+}
+
+func TestUint128Algorithms(t *testing.T) {
+	ref := reference(MaxSafeN128)
+	algorithms := map[string]func(uint64) Uint128{
+		"Iterative128": Iterative128,
+		"Matrix128":    Matrix128,
+		"Doubling128":  Doubling128,
+	}
+	for name, fn := range algorithms {
+		for n := uint64(0); n <= MaxSafeN128; n++ {
+			x := fn(n)
+			got := new(big.Int).Lsh(new(big.Int).SetUint64(x.Hi), 64)
+			got.Or(got, new(big.Int).SetUint64(x.Lo))
+			if got.Cmp(ref[n]) != 0 {
+				t.Errorf("%s(%d) = %s, want %s", name, n, got, ref[n])
+			}
+		}
+	}
+}
+
+func TestF1000(t *testing.T) {
+	for _, algo := range bigAlgorithms {
+		if got := algo.fn(1000).String(); got != f1000 {
+			t.Errorf("%s(1000) = %s, want %s", algo.name, got, f1000)
+		}
+	}
+	if got := DigitCount(1000); got != uint64(len(f1000)) {
+		t.Errorf("DigitCount(1000) = %d, want %d", got, len(f1000))
+	}
+	if got := LeadingDigits(1000, 10); got != f1000[:10] {
+		t.Errorf("LeadingDigits(1000, 10) = %s, want %s", got, f1000[:10])
+	}
+	if got := LastDigits(1000, 10); got != f1000[len(f1000)-10:] {
+		t.Errorf("LastDigits(1000, 10) = %s, want %s", got, f1000[len(f1000)-10:])
+	}
+}
+
+func TestBigAlgorithms(t *testing.T) {
+	ref := reference(400)
+	for _, algo := range bigAlgorithms {
+		for n := uint64(0); n <= 400; n++ {
+			if got := algo.fn(n); got.Cmp(ref[n]) != 0 {
+				t.Errorf("%s(%d) = %s, want %s", algo.name, n, got, ref[n])
+			}
+		}
+	}
+}
+
+// TestBigCrossCheck compares fast doubling with the matrix and iterative algorithms where no table reaches
+func TestBigCrossCheck(t *testing.T) {
+	defer SetParallelMulThreshold(ParallelMulThreshold())
+	SetParallelMulThreshold(1 << 10) // exercise the parallel products at these sizes
+	for _, n := range []uint64{1000, 1001, 4095, 4096, 4097, 10007, 65536, 100000} {
+		want := BigDoubling(n)
+		if want.BitLen() == 0 {
+			t.Fatalf("BigDoubling(%d) = 0", n)
+		}
+		for _, algo := range bigAlgorithms {
+			if got := algo.fn(n); got.Cmp(want) != 0 {
+				t.Errorf("%s(%d) differs from BigDoubling in %d bits", algo.name, n, new(big.Int).Xor(got, want).BitLen())
+			}
+		}
+		if got, err := BigDoublingContext(context.Background(), n); err != nil || got.Cmp(want) != 0 {
+			t.Errorf("BigDoublingContext(%d) differs from BigDoubling: %v", n, err)
+		}
+	}
+}
+
+func TestBigDoublingContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := BigDoublingContext(ctx, 1_000_000); err != context.Canceled {
+		t.Errorf("BigDoublingContext on a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestAutoThresholds(t *testing.T) {
+	defer SetAutoThresholds(AutoThresholds())
+	ref := reference(600)
+	for _, th := range []Thresholds{
+		{},
+		{Iterative128MaxN: MaxSafeN128, Matrix128MaxN: MaxSafeN128, BigIterativeMaxN: 600, BigMatrixMaxN: 600},
+		{Iterative128MaxN: 120, Matrix128MaxN: 150, BigIterativeMaxN: 300, BigMatrixMaxN: 450},
+	} {
+		SetAutoThresholds(th)
+		for n := uint64(0); n <= 600; n++ {
+			if got := Auto(n); got.Cmp(ref[n]) != 0 {
+				t.Errorf("Auto(%d) with %+v = %s, want %s", n, th, got, ref[n])
+			}
+		}
+	}
+}
+
+func TestLucas(t *testing.T) {
+	ref := reference(91)
+	algorithms := map[string]func(uint64) uint64{
+		"LucasIterative": LucasIterative,
+		"LucasMemo":      LucasMemo,
+		"LucasMatrix":    LucasMatrix,
+		"LucasDoubling":  LucasDoubling,
+	}
+	for name, fn := range algorithms {
+		if got := fn(0); got != 2 {
+			t.Errorf("%s(0) = %d, want 2", name, got)
+		}
+		// L(n) = F(n-1) + F(n+1)
+		for n := uint64(1); n < 90; n++ {
+			if want := new(big.Int).Add(ref[n-1], ref[n+1]).Uint64(); fn(n) != want {
+				t.Errorf("%s(%d) = %d, want %d", name, n, fn(n), want)
+			}
+		}
+	}
+	if got := LucasRecursive(20); got != 15127 {
+		t.Errorf("LucasRecursive(20) = %d, want 15127", got)
+	}
+}
+
+func TestKBonacci(t *testing.T) {
+	ref := reference(MaxSafeN)
+	for n := uint64(0); n <= MaxSafeN; n++ {
+		if got := K(2, n); got != ref[n].Uint64() {
+			t.Errorf("K(2, %d) = %d, want %s", n, got, ref[n])
+		}
+		if got := BigK(2, n); got.Cmp(ref[n]) != 0 {
+			t.Errorf("BigK(2, %d) = %s, want %s", n, got, ref[n])
+		}
+	}
+	// Tribonacci, with the sequence 0, 0, 1, 1, 2, 4, 7, 13, 24, 44
+	for n, want := range []uint64{0, 0, 1, 1, 2, 4, 7, 13, 24, 44} {
+		if got := K(3, uint64(n)); got != want {
+			t.Errorf("K(3, %d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestMemoFastBoundedTable(t *testing.T) {
+	for _, n := range []uint64{MaxSafeN, MaxSafeN + 1, 1000, 1 << 24} {
+		if got, want := MemoFast(n), Iterative(n); got != want {
+			t.Errorf("MemoFast(%d) = %d, want %d", n, got, want)
+		}
+	}
+	table := memoTablePool.Get().(*memoTable)
+	defer memoTablePool.Put(table)
+	if len(table.values) > MaxSafeN+1 {
+		t.Errorf("a pooled memo table holds %d entries, past F(%d)", len(table.values), MaxSafeN)
+	}
+}
+
+func TestMemoBounded(t *testing.T) {
+	defer SetMemoMaxN(MemoMaxN())
+	defer ClearMemo()
+	ClearMemo()
+	SetMemoMaxN(500)
+	PrecomputeMemo(1 << 40)
+	if got := MemoSize(); got != 499 {
+		t.Errorf("PrecomputeMemo past MemoMaxN left %d entries, want F(2)..F(500)", got)
+	}
+	for _, n := range []uint64{0, 1, 2, 93, 499, 500, 501, 1 << 20} {
+		if got, want := Memo(n), Iterative(n); got != want {
+			t.Errorf("Memo(%d) = %d, want %d", n, got, want)
+		}
+	}
+	if got := MemoSize(); got != 499 {
+		t.Errorf("Memo past MemoMaxN grew the memo to %d entries", got)
+	}
+
+	// A fresh memo fills in one pass, however far n is
+	ClearMemo()
+	SetMemoMaxN(DefaultMemoMaxN)
+	if got, want := Memo(DefaultMemoMaxN), Iterative(DefaultMemoMaxN); got != want {
+		t.Errorf("Memo(%d) = %d, want %d", DefaultMemoMaxN, got, want)
+	}
+}
+
+// contextAlgorithms are the big-integer algorithms checking a context, beside BigDoublingContext
+var contextAlgorithms = []struct {
+	name string
+	fn   func(context.Context, uint64) (*big.Int, error)
+}{
+	{"BigIterativeContext", BigIterativeContext},
+	{"BigMatrixContext", BigMatrixContext},
+	{"BigDoublingParallelContext", BigDoublingParallelContext},
+	{"BigDoublingSquareContext", BigDoublingSquareContext},
+	{"LimbDoublingContext", LimbDoublingContext},
+	{"BigKContext(2)", func(ctx context.Context, n uint64) (*big.Int, error) { return BigKContext(ctx, 2, n) }},
+}
+
+func TestContextAlgorithms(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, algo := range contextAlgorithms {
+		if got, err := algo.fn(context.Background(), 1000); err != nil || got.String() != f1000 {
+			t.Errorf("%s(1000) = %v, %v, want F(1000)", algo.name, got, err)
+		}
+		if _, err := algo.fn(ctx, 1_000_000); err != context.Canceled {
+			t.Errorf("%s on a cancelled context = %v, want context.Canceled", algo.name, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package fib
+
+import "math/bits"
+
+// Fibonacci coding is a universal code for positive integers: the Zeckendorf
+// bits of the value from F(2) upwards, terminated by an extra 1 so every
+// codeword ends in "11". Bits are packed most significant first within each byte.
+
+// bitWriter appends bits to a byte slice, most significant bit first
+type bitWriter struct {
+	buf   []byte
+	nbits uint64
+}
+
+// writeBit appends one bit
+func (w *bitWriter) writeBit(bit bool) {
+	if w.nbits%8 == 0 {
+		w.buf = append(w.buf, 0)
+	}
+	if bit {
+		w.buf[w.nbits/8] |= 0x80 >> (w.nbits % 8)
+	}
+	w.nbits++
+}
+
+// Encode returns the Fibonacci codewords of values and the stream length in bits
+// A zero value cannot be encoded and yields ErrInvalidInput.
+func Encode(values []uint64) ([]byte, uint64, error) {
+	w := &bitWriter{}
+	var codeword [len(fibTable)]bool
+	for _, v := range values {
+		if v == 0 {
+			return nil, 0, ErrInvalidInput
+		}
+		highest := 0
+		for _, k := range ZeckendorfEncode(v) {
+			codeword[k] = true
+			highest = max(highest, int(k))
+		}
+		for k := 2; k <= highest; k++ {
+			w.writeBit(codeword[k])
+			codeword[k] = false
+		}
+		w.writeBit(true)
+	}
+	return w.buf, w.nbits, nil
+}
+
+// Decode parses nbits of Fibonacci codewords from data
+// It returns ErrInvalidInput for a truncated stream and ErrOverflow for a value beyond 64 bits.
+func Decode(data []byte, nbits uint64) ([]uint64, error) {
+	var values []uint64
+	var value uint64
+	k := 2
+	previous := false
+	inCodeword := false
+	for i := uint64(0); i < nbits; i++ {
+		bit := data[i/8]&(0x80>>(i%8)) != 0
+		if bit && previous {
+			values = append(values, value)
+			value, k, previous, inCodeword = 0, 2, false, false
+			continue
+		}
+		if bit {
+			if k >= len(fibTable) {
+				return nil, ErrOverflow
+			}
+			var carry uint64
+			value, carry = bits.Add64(value, fibTable[k], 0)
+			if carry != 0 {
+				return nil, ErrOverflow
+			}
+		}
+		previous = bit
+		inCodeword = true
+		k++
+	}
+	if inCodeword {
+		// Truncated codeword
+		return nil, ErrInvalidInput
+	}
+	return values, nil
+}
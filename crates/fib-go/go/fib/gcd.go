@@ -0,0 +1,32 @@
+package fib
+
+import "math/big"
+
+// gcd returns the greatest common divisor of m and n, gcd(0, n) being n
+func gcd(m, n uint64) uint64 {
+	for n != 0 {
+		m, n = n, m%n
+	}
+	return m
+}
+
+// GCD returns gcd(F(m), F(n)) = F(gcd(m, n)) - O(log min(m, n))
+// Returns (0, false) if F(gcd(m, n)) does not fit in a uint64.
+func GCD(m, n uint64) (uint64, bool) {
+	g := gcd(m, n)
+	if g > MaxSafeN {
+		return 0, false
+	}
+	return Lookup(g), true
+}
+
+// BigGCD returns gcd(F(m), F(n)) = F(gcd(m, n)) with math/big, computing a single Fibonacci number
+func BigGCD(m, n uint64) *big.Int {
+	return BigDoubling(gcd(m, n))
+}
+
+// BigGCDBruteForce returns gcd(F(m), F(n)) by computing both numbers and running Euclid on them
+// It does not rely on the index identity, so it cross-checks BigGCD.
+func BigGCDBruteForce(m, n uint64) *big.Int {
+	return new(big.Int).GCD(nil, nil, BigDoubling(m), BigDoubling(n))
+}
@@ -0,0 +1,38 @@
+package fib
+
+import (
+	"math"
+	"math/big"
+)
+
+// GoldenRatioDigits returns phi = (1 + sqrt(5)) / 2 truncated to k decimal places, "1.618..."
+// sqrt(5) is taken with big.Float at k*log2(10) plus guard bits, so the cost grows
+// like one big square root of that many bits.
+func GoldenRatioDigits(k uint64) string {
+	prec := uint(float64(k)*math.Log2(10)) + guardBits
+	phi := bigPhi(prec)
+	// Text rounds the last place, so format guard digits and cut them
+	const guardDigits = 8
+	text := phi.Text('f', int(k)+guardDigits)
+	if k == 0 {
+		return text[:1]
+	}
+	return text[:len(text)-guardDigits]
+}
+
+// Ratio returns F(n+1) / F(n) rounded to float64 and its distance to phi
+// F(n+1) - phi F(n) = psi^n gives the distance |F(n+1)/F(n) - phi| = phi^(-n) / F(n),
+// about sqrt(5) phi^(-2n): the ratio converges to phi by about 0.42 decimal digits per step.
+// The distance underflows to 0 from n = 776. F(0) = 0 leaves the ratio
+// undefined, so n = 0 returns NaN for both.
+func Ratio(n uint64) (ratio, distance float64) {
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+	if n > MaxBinetN {
+		return math.Phi, 0
+	}
+	fn, fn1 := BigPair(n)
+	ratio, _ = new(big.Rat).SetFrac(fn1, fn).Float64()
+	return ratio, math.Pow(math.Phi, -float64(n)) / Binet(n)
+}
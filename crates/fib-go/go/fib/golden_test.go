@@ -0,0 +1,45 @@
+package fib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatio(t *testing.T) {
+	if ratio, distance := Ratio(0); !math.IsNaN(ratio) || !math.IsNaN(distance) {
+		t.Errorf("Ratio(0) = %v, %v, want NaN, NaN", ratio, distance)
+	}
+	if ratio, _ := Ratio(1); ratio != 1 {
+		t.Errorf("Ratio(1) = %v, want 1", ratio)
+	}
+	if ratio, _ := Ratio(10); ratio != 89.0/55.0 {
+		t.Errorf("Ratio(10) = %v, want 89/55", ratio)
+	}
+	prev := math.Inf(1)
+	for n := uint64(1); n < 40; n++ {
+		ratio, distance := Ratio(n)
+		if got := math.Abs(ratio - math.Phi); got > distance*(1+1e-9)+1e-15 {
+			t.Errorf("Ratio(%d) is %g from phi, past its distance %g", n, got, distance)
+		}
+		if distance >= prev {
+			t.Errorf("Ratio(%d) distance %g does not shrink from %g", n, distance, prev)
+		}
+		prev = distance
+	}
+	if ratio, distance := Ratio(MaxBinetN + 1); ratio != math.Phi || distance != 0 {
+		t.Errorf("Ratio(%d) = %v, %v, want phi, 0", MaxBinetN+1, ratio, distance)
+	}
+}
+
+func TestGoldenRatioDigits(t *testing.T) {
+	for k, want := range map[uint64]string{
+		0:  "1",
+		1:  "1.6",
+		10: "1.6180339887",
+		30: "1.618033988749894848204586834365",
+	} {
+		if got := GoldenRatioDigits(k); got != want {
+			t.Errorf("GoldenRatioDigits(%d) = %q, want %q", k, got, want)
+		}
+	}
+}
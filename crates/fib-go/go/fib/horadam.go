@@ -0,0 +1,57 @@
+package fib
+
+// Horadam sequences W(n) = p*W(n-1) - q*W(n-2) with seeds W(0) = a0, W(1) = a1.
+// The Lucas sequences U(p,q) and V(p,q) are the special cases (0, 1) and (2, p):
+//
+//	Fibonacci  U(1, -1)    Lucas       V(1, -1)
+//	Pell       U(2, -1)    Jacobsthal  U(1, -2)
+//
+// Arithmetic wraps modulo 2^64; results are exact whenever they fit in an int64.
+
+// HoradamMatrix calculates W(n) using matrix exponentiation - O(log n)
+// [W(n+1), W(n)] = [[p, -q], [1, 0]]^n [W(1), W(0)]
+func HoradamMatrix(a0, a1, p, q, n uint64) uint64 {
+	step := Matrix2x2{a: p, b: -q, c: 1, d: 0}
+	result := matrixPower(step, n)
+	return result.c*a1 + result.d*a0
+}
+
+// HoradamDoubling calculates W(n) from the doubled pair (U(n), U(n+1)) - O(log n)
+// W(n) = a1*U(n) + a0*(U(n+1) - p*U(n))
+func HoradamDoubling(a0, a1, p, q, n uint64) uint64 {
+	pair := lucasUPair(p, q, n)
+	un, un1 := pair[0], pair[1]
+	return a1*un + a0*(un1-p*un)
+}
+
+// LucasU calculates the Lucas sequence U(n; p, q) using fast doubling - O(log n)
+func LucasU(p, q, n uint64) uint64 {
+	return lucasUPair(p, q, n)[0]
+}
+
+// LucasV calculates the Lucas sequence V(n; p, q) = 2*U(n+1) - p*U(n) using fast doubling - O(log n)
+func LucasV(p, q, n uint64) uint64 {
+	pair := lucasUPair(p, q, n)
+	return 2*pair[1] - p*pair[0]
+}
+
+// lucasUPair returns (U(n), U(n+1)) for the Lucas sequence with parameters (p, q)
+func lucasUPair(p, q, n uint64) [2]uint64 {
+	if n == 0 {
+		return [2]uint64{0, 1}
+	}
+
+	pair := lucasUPair(p, q, n/2)
+	uk := pair[0]
+	uk1 := pair[1]
+
+	// U(2k) = U(k) * (2*U(k+1) - p*U(k))
+	u2k := uk * (2*uk1 - p*uk)
+	// U(2k+1) = U(k+1)^2 - q*U(k)^2
+	u2k1 := uk1*uk1 - q*uk*uk
+
+	if n%2 == 0 {
+		return [2]uint64{u2k, u2k1}
+	}
+	return [2]uint64{u2k1, p*u2k1 - q*u2k}
+}
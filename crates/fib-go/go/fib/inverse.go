@@ -0,0 +1,35 @@
+package fib
+
+import (
+	"math/big"
+	"sort"
+)
+
+// IndexOf returns the index n such that F(n) == value, or false if value is not a Fibonacci number
+// For value 1 the smallest index (1) is reported.
+func IndexOf(value uint64) (uint64, bool) {
+	i := sort.Search(len(fibTable), func(i int) bool { return fibTable[i] >= value })
+	if i == len(fibTable) || fibTable[i] != value {
+		return 0, false
+	}
+	return uint64(i), true
+}
+
+// IsFibonacci reports whether value is a Fibonacci number
+// x is a Fibonacci number iff 5x^2 + 4 or 5x^2 - 4 is a perfect square.
+func IsFibonacci(value uint64) bool {
+	x := new(big.Int).SetUint64(value)
+	fiveX2 := new(big.Int).Mul(x, x)
+	fiveX2.Mul(fiveX2, big.NewInt(5))
+	return isPerfectSquare(new(big.Int).Add(fiveX2, big.NewInt(4))) ||
+		isPerfectSquare(new(big.Int).Sub(fiveX2, big.NewInt(4)))
+}
+
+// isPerfectSquare reports whether x >= 0 is the square of an integer
+func isPerfectSquare(x *big.Int) bool {
+	if x.Sign() < 0 {
+		return false
+	}
+	root := new(big.Int).Sqrt(x)
+	return root.Mul(root, root).Cmp(x) == 0
+}
@@ -0,0 +1,79 @@
+package fib
+
+// Iterator walks the Fibonacci sequence from an arbitrary index, in both directions
+// It is a cursor on index n keeping the pair (F(n), F(n+1)), so each step is a
+// single addition or, backwards, the subtraction F(n-1) = F(n+1) - F(n). Values
+// wrap modulo 2^64 past F(93), which the subtraction undoes exactly. An Iterator
+// is not safe for concurrent use.
+type Iterator struct {
+	n       uint64
+	fn, fn1 uint64
+}
+
+// NewIterator returns an iterator whose first Next yields F(start) - O(log start)
+func NewIterator(start uint64) *Iterator {
+	it := &Iterator{}
+	it.seek(start)
+	return it
+}
+
+// seek positions the iterator on index n
+func (it *Iterator) seek(n uint64) {
+	pair := Pair(n)
+	it.n, it.fn, it.fn1 = n, pair[0], pair[1]
+}
+
+// Index returns the index of the value the next call to Next yields
+func (it *Iterator) Index() uint64 {
+	return it.n
+}
+
+// Next returns F(n) and advances to n+1 - O(1)
+func (it *Iterator) Next() uint64 {
+	value := it.fn
+	it.n++
+	it.fn, it.fn1 = it.fn1, it.fn+it.fn1
+	return value
+}
+
+// Prev moves back to n-1 and returns F(n-1) - O(1)
+// Next followed by Prev yields the same value twice. At index 0 it returns
+// ErrInvalidInput and leaves the iterator unchanged.
+func (it *Iterator) Prev() (uint64, error) {
+	if it.n == 0 {
+		return 0, ErrInvalidInput
+	}
+	it.n--
+	it.fn, it.fn1 = it.fn1-it.fn, it.fn
+	return it.fn, nil
+}
+
+// seekWalkMax is the largest distance Seek covers by stepping rather than by doubling
+const seekWalkMax = 64
+
+// Seek positions the iterator so that the next call to Next yields F(n)
+// Nearby targets are reached by stepping from the current pair, others by doubling - O(min(d, log n)).
+func (it *Iterator) Seek(n uint64) {
+	switch {
+	case n >= it.n && n-it.n <= seekWalkMax:
+		for it.n < n {
+			it.Next()
+		}
+	case n < it.n && it.n-n <= seekWalkMax:
+		for it.n > n {
+			it.Prev()
+		}
+	default:
+		it.seek(n)
+	}
+}
+
+// Skip advances the iterator by k indices without yielding them - O(log(n+k))
+// It returns ErrOverflow, leaving the iterator unchanged, if n+k exceeds the uint64 range.
+func (it *Iterator) Skip(k uint64) error {
+	if it.n+k < it.n {
+		return ErrOverflow
+	}
+	it.seek(it.n + k)
+	return nil
+}
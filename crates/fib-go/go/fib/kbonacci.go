@@ -0,0 +1,50 @@
+package fib
+
+import (
+	"context"
+	"math/big"
+)
+
+// k-step Fibonacci numbers: F(0) = ... = F(k-2) = 0, F(k-1) = 1, and each
+// following term is the sum of the previous k (k = 2 Fibonacci, 3 tribonacci,
+// 4 tetranacci, ...). F(n) is entry (k-1, 0) of the k x k companion matrix
+// raised to the n-th power.
+
+// MaxK bounds the matrix dimension accepted by the k-bonacci functions
+const MaxK = 256
+
+// K calculates the n-th k-bonacci number using k x k matrix exponentiation - O(k^3 log n)
+// k must be in 1..MaxK; results wrap past 64 bits.
+func K(k int, n uint64) uint64 {
+	companion := newMatrixN(k)
+	for j := 0; j < k; j++ {
+		companion.Set(0, j, 1)
+	}
+	for i := 1; i < k; i++ {
+		companion.Set(i, i-1, 1)
+	}
+	return matrixNPower(companion, n).At(k-1, 0)
+}
+
+// BigK calculates the n-th k-bonacci number with math/big; k must be in 1..MaxK
+func BigK(k int, n uint64) *big.Int {
+	x, _ := BigKContext(context.Background(), k, n)
+	return x
+}
+
+// BigKContext is BigK checking ctx between matrix squarings
+// It returns ctx.Err() once ctx is done; a single product is never interrupted.
+func BigKContext(ctx context.Context, k int, n uint64) (*big.Int, error) {
+	companion := newBigMatrixN(k)
+	for j := 0; j < k; j++ {
+		companion.At(0, j).SetInt64(1)
+	}
+	for i := 1; i < k; i++ {
+		companion.At(i, i-1).SetInt64(1)
+	}
+	m, err := bigMatrixNPower(ctx, companion, n)
+	if err != nil {
+		return nil, err
+	}
+	return m.At(k-1, 0), nil
+}
@@ -0,0 +1,170 @@
+package fib
+
+import (
+	"context"
+	"math/big"
+	"math/bits"
+)
+
+// nat is an unsigned integer held as little-endian 64-bit limbs without high zero limbs
+// It is a deliberately small backend, tuned for the doubling step, to benchmark against math/big.
+type nat []uint64
+
+// karatsubaThreshold is the operand size, in limbs, below which mul uses schoolbook multiplication
+const karatsubaThreshold = 40
+
+// norm drops the high zero limbs of z
+func (z nat) norm() nat {
+	i := len(z)
+	for i > 0 && z[i-1] == 0 {
+		i--
+	}
+	return z[:i]
+}
+
+// add returns x+y
+func (x nat) add(y nat) nat {
+	if len(x) < len(y) {
+		x, y = y, x
+	}
+	z := make(nat, len(x)+1)
+	var carry uint64
+	for i := range x {
+		var yi uint64
+		if i < len(y) {
+			yi = y[i]
+		}
+		z[i], carry = bits.Add64(x[i], yi, carry)
+	}
+	z[len(x)] = carry
+	return z.norm()
+}
+
+// sub returns x-y; x must be >= y
+func (x nat) sub(y nat) nat {
+	z := make(nat, len(x))
+	var borrow uint64
+	for i := range x {
+		var yi uint64
+		if i < len(y) {
+			yi = y[i]
+		}
+		z[i], borrow = bits.Sub64(x[i], yi, borrow)
+	}
+	return z.norm()
+}
+
+// shl1 returns 2x
+func (x nat) shl1() nat {
+	z := make(nat, len(x)+1)
+	var carry uint64
+	for i, xi := range x {
+		z[i] = xi<<1 | carry
+		carry = xi >> 63
+	}
+	z[len(x)] = carry
+	return z.norm()
+}
+
+// addAt adds x into z starting at limb i; z must be long enough to absorb the carry
+func (z nat) addAt(x nat, i int) {
+	var carry uint64
+	for j, xj := range x {
+		z[i+j], carry = bits.Add64(z[i+j], xj, carry)
+	}
+	for k := i + len(x); carry != 0; k++ {
+		z[k], carry = bits.Add64(z[k], 0, carry)
+	}
+}
+
+// mulBasic returns x*y by schoolbook multiplication - O(len(x)*len(y))
+func (x nat) mulBasic(y nat) nat {
+	z := make(nat, len(x)+len(y))
+	for i, xi := range x {
+		var carry uint64
+		for j, yj := range y {
+			hi, lo := bits.Mul64(xi, yj)
+			lo, c := bits.Add64(lo, z[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			z[i+j] = lo
+			carry = hi
+		}
+		z[i+len(y)] = carry
+	}
+	return z.norm()
+}
+
+// mul returns x*y, splitting large operands with Karatsuba - O(n^1.585)
+func (x nat) mul(y nat) nat {
+	if len(x) < len(y) {
+		x, y = y, x
+	}
+	if len(y) == 0 {
+		return nil
+	}
+	if len(y) < karatsubaThreshold {
+		return x.mulBasic(y)
+	}
+
+	m := len(x) / 2
+	x0, x1 := x[:m].norm(), x[m:]
+	z := make(nat, len(x)+len(y)+1)
+	if len(y) <= m {
+		// y has no high half: x*y = x0*y + (x1*y)<<m
+		z.addAt(x0.mul(y), 0)
+		z.addAt(x1.mul(y), m)
+		return z.norm()
+	}
+
+	// x*y = z2*B^2m + (z1-z2-z0)*B^m + z0 with B = 2^64
+	y0, y1 := y[:m].norm(), y[m:]
+	z0 := x0.mul(y0)
+	z2 := x1.mul(y1)
+	z1 := x0.add(x1).mul(y0.add(y1)).sub(z0).sub(z2)
+	z.addAt(z0, 0)
+	z.addAt(z1, m)
+	z.addAt(z2, 2*m)
+	return z.norm()
+}
+
+// bigInt converts x to a *big.Int
+func (x nat) bigInt() *big.Int {
+	buf := make([]byte, 8*len(x))
+	for i, xi := range x {
+		for b := 0; b < 8; b++ {
+			buf[len(buf)-1-8*i-b] = byte(xi >> (8 * b))
+		}
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+// LimbDoubling calculates F(n) by fast doubling on the nat limb backend - O(log n) Karatsuba multiplications
+// It computes the same values as BigDoubling without math/big, which only converts the result.
+func LimbDoubling(n uint64) *big.Int {
+	x, _ := LimbDoublingContext(context.Background(), n)
+	return x
+}
+
+// LimbDoublingContext is LimbDoubling checking ctx between doubling steps
+// It returns ctx.Err() once ctx is done; a single step is never interrupted.
+func LimbDoublingContext(ctx context.Context, n uint64) (*big.Int, error) {
+	fk, fk1 := nat(nil), nat{1}
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// F(2k) = F(k) * (2*F(k+1) - F(k))
+		f2k := fk.mul(fk1.shl1().sub(fk))
+		// F(2k+1) = F(k)^2 + F(k+1)^2
+		f2k1 := fk.mul(fk).add(fk1.mul(fk1))
+
+		if (n>>uint(i))&1 == 0 {
+			fk, fk1 = f2k, f2k1
+		} else {
+			fk, fk1 = f2k1, f2k.add(f2k1)
+		}
+	}
+	return fk.bigInt(), nil
+}
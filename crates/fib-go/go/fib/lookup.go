@@ -0,0 +1,12 @@
+package fib
+
+//go:generate go run ../gentable/main.go -o fib_table.go
+
+// Lookup returns F(n) from the embedded golden table - O(1)
+// Beyond F(93) it falls back to doubling.
+func Lookup(n uint64) uint64 {
+	if n < uint64(len(fibTable)) {
+		return fibTable[n]
+	}
+	return Doubling(n)
+}
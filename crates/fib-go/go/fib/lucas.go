@@ -0,0 +1,89 @@
+package fib
+
+// Lucas numbers: L(0) = 2, L(1) = 1, L(n) = L(n-1) + L(n-2)
+// The uint64 results are exact up to L(92) and wrap beyond, like the Fibonacci functions.
+
+// LucasIterative calculates L(n) using the iterative method - O(n)
+func LucasIterative(n uint64) uint64 {
+	if n == 0 {
+		return 2
+	}
+
+	var a, b uint64 = 2, 1
+	for i := uint64(2); i <= n; i++ {
+		a, b = b, a+b
+	}
+	return b
+}
+
+// LucasRecursive calculates L(n) using the naive recursive method - O(2^n)
+// WARNING: Very slow for n > 35.
+func LucasRecursive(n uint64) uint64 {
+	if n == 0 {
+		return 2
+	}
+	if n == 1 {
+		return 1
+	}
+	return LucasRecursive(n-1) + LucasRecursive(n-2)
+}
+
+// LucasMemo calculates L(n) with a call-local memo - O(n)
+func LucasMemo(n uint64) uint64 {
+	return lucasMemoFill(n, make(map[uint64]uint64))
+}
+
+// lucasMemoFill returns L(n), computing missing entries of memo recursively
+func lucasMemoFill(n uint64, memo map[uint64]uint64) uint64 {
+	if n <= 1 {
+		return LucasRecursive(n)
+	}
+	if val, ok := memo[n]; ok {
+		return val
+	}
+	result := lucasMemoFill(n-1, memo) + lucasMemoFill(n-2, memo)
+	memo[n] = result
+	return result
+}
+
+// LucasMatrix calculates L(n) using matrix exponentiation - O(log n)
+// L(n) is the trace of [[1,1],[1,0]]^n.
+func LucasMatrix(n uint64) uint64 {
+	fibMatrix := Matrix2x2{a: 1, b: 1, c: 1, d: 0}
+	result := matrixPower(fibMatrix, n)
+	return result.a + result.d
+}
+
+// LucasDoubling uses the Lucas doubling identities - O(log n)
+// L(2k) = L(k)^2 - 2(-1)^k
+// L(2k+1) = L(k) * L(k+1) - (-1)^k
+func LucasDoubling(n uint64) uint64 {
+	return lucasPair(n)[0]
+}
+
+// lucasPair returns (L(n), L(n+1))
+func lucasPair(n uint64) [2]uint64 {
+	if n == 0 {
+		return [2]uint64{2, 1}
+	}
+
+	pair := lucasPair(n / 2)
+	lk := pair[0]
+	lk1 := pair[1]
+
+	// sign = (-1)^k, applied with wrapping arithmetic
+	k := n / 2
+	var sign uint64 = 1
+	if k%2 == 1 {
+		sign = ^uint64(0)
+	}
+
+	l2k := lk*lk - 2*sign
+	l2k1 := lk*lk1 - sign
+
+	if n%2 == 0 {
+		return [2]uint64{l2k, l2k1}
+	}
+	// L(2k+2) = L(k+1)^2 + 2(-1)^k
+	return [2]uint64{l2k1, lk1*lk1 + 2*sign}
+}
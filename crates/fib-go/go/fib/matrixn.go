@@ -0,0 +1,139 @@
+package fib
+
+import (
+	"context"
+	"math/big"
+)
+
+// MatrixN is a dense size x size matrix of uint64 (wrapping arithmetic), stored row-major
+type MatrixN struct {
+	size int
+	data []uint64
+}
+
+// newMatrixN returns a zero size x size matrix
+func newMatrixN(size int) MatrixN {
+	return MatrixN{size: size, data: make([]uint64, size*size)}
+}
+
+// identityN returns the size x size identity matrix
+func identityN(size int) MatrixN {
+	m := newMatrixN(size)
+	for i := 0; i < size; i++ {
+		m.Set(i, i, 1)
+	}
+	return m
+}
+
+// At returns the element at row i, column j
+func (m MatrixN) At(i, j int) uint64 {
+	return m.data[i*m.size+j]
+}
+
+// Set stores v at row i, column j
+func (m MatrixN) Set(i, j int, v uint64) {
+	m.data[i*m.size+j] = v
+}
+
+// matrixNMultiply multiplies two matrices of the same dimension
+func matrixNMultiply(m1, m2 MatrixN) MatrixN {
+	size := m1.size
+	result := newMatrixN(size)
+	for i := 0; i < size; i++ {
+		for k := 0; k < size; k++ {
+			aik := m1.At(i, k)
+			if aik == 0 {
+				continue
+			}
+			for j := 0; j < size; j++ {
+				result.data[i*size+j] += aik * m2.At(k, j)
+			}
+		}
+	}
+	return result
+}
+
+// matrixNPower calculates matrix power using fast exponentiation
+func matrixNPower(m MatrixN, n uint64) MatrixN {
+	result := identityN(m.size)
+	base := m
+	for n > 0 {
+		if n%2 == 1 {
+			result = matrixNMultiply(result, base)
+		}
+		n /= 2
+		if n > 0 {
+			base = matrixNMultiply(base, base)
+		}
+	}
+	return result
+}
+
+// BigMatrixN is a dense size x size matrix of arbitrary-precision integers, stored row-major
+type BigMatrixN struct {
+	size int
+	data []*big.Int
+}
+
+// newBigMatrixN returns a zero size x size big-integer matrix
+func newBigMatrixN(size int) BigMatrixN {
+	m := BigMatrixN{size: size, data: make([]*big.Int, size*size)}
+	for i := range m.data {
+		m.data[i] = new(big.Int)
+	}
+	return m
+}
+
+// bigIdentityN returns the size x size big-integer identity matrix
+func bigIdentityN(size int) BigMatrixN {
+	m := newBigMatrixN(size)
+	for i := 0; i < size; i++ {
+		m.At(i, i).SetInt64(1)
+	}
+	return m
+}
+
+// At returns the element at row i, column j; the result aliases the matrix storage
+func (m BigMatrixN) At(i, j int) *big.Int {
+	return m.data[i*m.size+j]
+}
+
+// bigMatrixNMultiply multiplies two big-integer matrices of the same dimension
+func bigMatrixNMultiply(m1, m2 BigMatrixN) BigMatrixN {
+	size := m1.size
+	result := newBigMatrixN(size)
+	var t big.Int
+	for i := 0; i < size; i++ {
+		for k := 0; k < size; k++ {
+			aik := m1.At(i, k)
+			if aik.Sign() == 0 {
+				continue
+			}
+			for j := 0; j < size; j++ {
+				r := result.At(i, j)
+				r.Add(r, t.Mul(aik, m2.At(k, j)))
+			}
+		}
+	}
+	return result
+}
+
+// bigMatrixNPower calculates big-integer matrix power using fast exponentiation
+// ctx is checked before every squaring; it returns ctx.Err() once ctx is done.
+func bigMatrixNPower(ctx context.Context, m BigMatrixN, n uint64) (BigMatrixN, error) {
+	result := bigIdentityN(m.size)
+	base := m
+	for n > 0 {
+		if err := ctx.Err(); err != nil {
+			return BigMatrixN{}, err
+		}
+		if n%2 == 1 {
+			result = bigMatrixNMultiply(result, base)
+		}
+		n /= 2
+		if n > 0 {
+			base = bigMatrixNMultiply(base, base)
+		}
+	}
+	return result, nil
+}
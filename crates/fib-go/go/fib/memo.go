@@ -0,0 +1,149 @@
+package fib
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// memoCache is the process-wide memo shared by Memo calls from any goroutine
+var memoCache = struct {
+	sync.RWMutex
+	values map[uint64]uint64
+}{values: make(map[uint64]uint64)}
+
+// memoHits and memoMisses count the lookups of memoCache by Memo
+var memoHits, memoMisses atomic.Uint64
+
+// DefaultMemoMaxN is the largest n kept in memoCache unless SetMemoMaxN changes it
+const DefaultMemoMaxN = 1 << 20
+
+// memoMaxN is the largest n kept in memoCache; larger requests iterate on from its end
+var memoMaxN atomic.Uint64
+
+func init() {
+	memoMaxN.Store(DefaultMemoMaxN)
+	memoPoolMaxEntries.Store(DefaultMemoPoolMaxEntries)
+}
+
+// Memo returns F(n) from the shared memo, filling it on a miss
+// Past MemoMaxN the memo stops growing: F(n) is iterated on from F(MemoMaxN).
+func Memo(n uint64) uint64 {
+	if n <= 1 {
+		return n
+	}
+	if maxN := memoMaxN.Load(); n > maxN {
+		maxN = max(maxN, 1)
+		a, b := Memo(maxN-1), Memo(maxN)
+		for i := maxN; i < n; i++ {
+			a, b = b, a+b
+		}
+		return b
+	}
+
+	memoCache.RLock()
+	val, ok := memoCache.values[n]
+	memoCache.RUnlock()
+	if ok {
+		memoHits.Add(1)
+		return val
+	}
+
+	memoMisses.Add(1)
+	memoCache.Lock()
+	defer memoCache.Unlock()
+	return memoFill(n, memoCache.values)
+}
+
+// ClearMemo empties the shared memo used by Memo
+func ClearMemo() {
+	memoCache.Lock()
+	memoCache.values = make(map[uint64]uint64)
+	memoCache.Unlock()
+}
+
+// MemoSize returns the number of entries held by the shared memo
+func MemoSize() int {
+	memoCache.RLock()
+	defer memoCache.RUnlock()
+	return len(memoCache.values)
+}
+
+// MemoStats returns the number of Memo calls answered by the shared memo and of those filling it
+// A call above MemoMaxN counts as its lookups of F(MemoMaxN-1) and F(MemoMaxN).
+func MemoStats() (hits, misses uint64) {
+	return memoHits.Load(), memoMisses.Load()
+}
+
+// PrecomputeMemo fills the shared memo with F(2)..F(n) ahead of time
+// n is clamped to MemoMaxN, the largest n the memo keeps.
+func PrecomputeMemo(n uint64) {
+	n = min(n, memoMaxN.Load())
+	memoCache.Lock()
+	defer memoCache.Unlock()
+	memoFill(n, memoCache.values)
+}
+
+// SetMemoMaxN sets the largest n kept in the shared memo (default DefaultMemoMaxN)
+// Lowering it does not shrink the memo; ClearMemo does.
+func SetMemoMaxN(n uint64) {
+	memoMaxN.Store(n)
+}
+
+// MemoMaxN returns the largest n kept in the shared memo
+func MemoMaxN() uint64 {
+	return memoMaxN.Load()
+}
+
+// memoTable is a slice-backed memo: values[i] holds F(i) for every i < len(values)
+type memoTable struct {
+	values []uint64
+}
+
+// DefaultMemoPoolMaxEntries caps the size of tables returned to memoTablePool
+const DefaultMemoPoolMaxEntries = 1 << 20
+
+// memoPoolMaxEntries is the configured cap on pooled memo tables
+var memoPoolMaxEntries atomic.Uint64
+
+// SetMemoPoolMaxEntries sets the largest table MemoFast returns to its pool
+func SetMemoPoolMaxEntries(n uint64) {
+	memoPoolMaxEntries.Store(n)
+}
+
+// MemoPoolMaxEntries returns the largest table MemoFast returns to its pool
+func MemoPoolMaxEntries() uint64 {
+	return memoPoolMaxEntries.Load()
+}
+
+// memoTablePool recycles memo tables between MemoFast calls
+var memoTablePool = sync.Pool{
+	New: func() any { return &memoTable{values: []uint64{0, 1}} },
+}
+
+// MemoFast calculates F(n) with a slice-backed memo recycled via sync.Pool - O(n)
+// Avoids the map hashing of Memo; a recycled table already holds a valid prefix.
+// The table stops at F(MaxSafeN): past it the wrapping values are iterated from
+// its last two entries, so memory stays bounded whatever n.
+func MemoFast(n uint64) uint64 {
+	table := memoTablePool.Get().(*memoTable)
+	for uint64(len(table.values)) <= min(n, MaxSafeN) {
+		k := len(table.values)
+		table.values = append(table.values, table.values[k-1]+table.values[k-2])
+	}
+	var result uint64
+	if n <= MaxSafeN {
+		result = table.values[n]
+	} else {
+		a, b := table.values[MaxSafeN-1], table.values[MaxSafeN]
+		for i := uint64(MaxSafeN); i < n; i++ {
+			a, b = b, a+b
+		}
+		result = b
+	}
+
+	// Oversized tables are left to the GC rather than pinned by the pool
+	if uint64(len(table.values)) <= memoPoolMaxEntries.Load() {
+		memoTablePool.Put(table)
+	}
+	return result
+}
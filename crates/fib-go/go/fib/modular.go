@@ -0,0 +1,129 @@
+package fib
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// mulMod returns a*b mod m using a 128-bit intermediate product
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// addMod returns a+b mod m for a, b < m without overflowing
+func addMod(a, b, m uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 || sum >= m {
+		sum -= m
+	}
+	return sum
+}
+
+// matrixMultiplyMod multiplies two 2x2 matrices modulo m
+func matrixMultiplyMod(m1, m2 Matrix2x2, m uint64) Matrix2x2 {
+	return Matrix2x2{
+		a: addMod(mulMod(m1.a, m2.a, m), mulMod(m1.b, m2.c, m), m),
+		b: addMod(mulMod(m1.a, m2.b, m), mulMod(m1.b, m2.d, m), m),
+		c: addMod(mulMod(m1.c, m2.a, m), mulMod(m1.d, m2.c, m), m),
+		d: addMod(mulMod(m1.c, m2.b, m), mulMod(m1.d, m2.d, m), m),
+	}
+}
+
+// matrixPowerMod calculates matrix power modulo m using fast exponentiation
+func matrixPowerMod(base Matrix2x2, n, m uint64) Matrix2x2 {
+	result := Matrix2x2{a: 1 % m, b: 0, c: 0, d: 1 % m} // Identity
+	for n > 0 {
+		if n%2 == 1 {
+			result = matrixMultiplyMod(result, base, m)
+		}
+		base = matrixMultiplyMod(base, base, m)
+		n /= 2
+	}
+	return result
+}
+
+// Mod calculates F(n) mod m using modular matrix exponentiation - O(log n)
+// Works for any 64-bit modulus; m == 0 is invalid and yields 0.
+func Mod(n, m uint64) uint64 {
+	if m == 0 {
+		return 0
+	}
+	fibMatrix := Matrix2x2{a: 1 % m, b: 1 % m, c: 1 % m, d: 0}
+	return matrixPowerMod(fibMatrix, n, m).b
+}
+
+// bigMatrixMultiplyMod multiplies two 2x2 big-integer matrices modulo m
+func bigMatrixMultiplyMod(m1, m2 BigMatrix2x2, m *big.Int) BigMatrix2x2 {
+	result := bigMatrixMultiply(m1, m2)
+	for _, x := range []*big.Int{result.a, result.b, result.c, result.d} {
+		x.Mod(x, m)
+	}
+	return result
+}
+
+// BigMod calculates F(n) mod m for a big modulus m > 0 using modular matrix exponentiation
+func BigMod(n uint64, m *big.Int) *big.Int {
+	one := new(big.Int).Mod(big.NewInt(1), m)
+	result := BigMatrix2x2{a: one, b: big.NewInt(0), c: big.NewInt(0), d: one} // Identity
+	base := BigMatrix2x2{a: one, b: one, c: one, d: big.NewInt(0)}
+	for n > 0 {
+		if n%2 == 1 {
+			result = bigMatrixMultiplyMod(result, base, m)
+		}
+		n /= 2
+		if n > 0 {
+			base = bigMatrixMultiplyMod(base, base, m)
+		}
+	}
+	return result.b
+}
+
+// MaxPisanoModulus is the largest modulus whose Pisano period is searched, bounding the 6m steps
+const MaxPisanoModulus = 1 << 24
+
+// pisanoCache memoizes periods computed by ModFast, keyed by modulus
+var pisanoCache sync.Map
+
+// PisanoPeriod returns the period of the Fibonacci sequence modulo m - O(m)
+// The period never exceeds 6m; m == 0 is invalid and m > MaxPisanoModulus is
+// refused, both yielding 0.
+func PisanoPeriod(m uint64) uint64 {
+	if m == 0 || m > MaxPisanoModulus {
+		return 0
+	}
+	if m == 1 {
+		return 1
+	}
+
+	var a, b uint64 = 0, 1
+	for period := uint64(1); ; period++ {
+		a, b = b, addMod(a, b, m)
+		if a == 0 && b == 1 {
+			return period
+		}
+	}
+}
+
+// ClearPisanoCache drops the periods cached by ModFast
+func ClearPisanoCache() {
+	pisanoCache.Clear()
+}
+
+// ModFast calculates F(n) mod m after reducing n modulo the Pisano period of m
+// The period is computed once per modulus and cached, so the first call for a given m is O(m).
+// Above MaxPisanoModulus it is Mod, without the period search.
+func ModFast(n, m uint64) uint64 {
+	if m == 0 {
+		return 0
+	}
+	if m > MaxPisanoModulus {
+		return Mod(n, m)
+	}
+	period, ok := pisanoCache.Load(m)
+	if !ok {
+		period, _ = pisanoCache.LoadOrStore(m, PisanoPeriod(m))
+	}
+	return Mod(n%period.(uint64), m)
+}
@@ -0,0 +1,32 @@
+package fib
+
+import "testing"
+
+func TestPisanoPeriod(t *testing.T) {
+	for m, want := range map[uint64]uint64{0: 0, 1: 1, 2: 3, 3: 8, 5: 20, 10: 60, 100: 300, 1000: 1500} {
+		if got := PisanoPeriod(m); got != want {
+			t.Errorf("PisanoPeriod(%d) = %d, want %d", m, got, want)
+		}
+	}
+	if got := PisanoPeriod(MaxPisanoModulus + 1); got != 0 {
+		t.Errorf("PisanoPeriod(%d) = %d, want 0 past MaxPisanoModulus", MaxPisanoModulus+1, got)
+	}
+}
+
+func TestModFast(t *testing.T) {
+	ref := reference(300)
+	for _, m := range []uint64{1, 2, 7, 10, 1000, 1 << 20, MaxPisanoModulus + 1, 1<<63 + 7} {
+		for n := uint64(0); n <= 300; n++ {
+			want := ref[n].Uint64() % m
+			if n > MaxSafeN {
+				want = Mod(n, m)
+			}
+			if got := ModFast(n, m); got != want {
+				t.Errorf("ModFast(%d, %d) = %d, want %d", n, m, got, want)
+			}
+		}
+		if got, want := ModFast(1<<62, m), Mod(1<<62, m); got != want {
+			t.Errorf("ModFast(2^62, %d) = %d, Mod gives %d", m, got, want)
+		}
+	}
+}
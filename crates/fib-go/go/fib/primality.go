@@ -0,0 +1,207 @@
+package fib
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Probable-prime tests built on Lucas sequences modulo n, the Lucas half of Baillie-PSW.
+
+// subMod returns a-b mod m for a, b < m
+func subMod(a, b, m uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return m - (b - a)
+}
+
+// halfMod returns x/2 mod m for an odd m and x < m
+func halfMod(x, m uint64) uint64 {
+	if x%2 == 0 {
+		return x / 2
+	}
+	// (x + m) / 2 without overflowing
+	return x/2 + m/2 + 1
+}
+
+// powMod returns b^e mod m
+func powMod(b, e, m uint64) uint64 {
+	result := 1 % m
+	b %= m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMod(result, b, m)
+		}
+		b = mulMod(b, b, m)
+	}
+	return result
+}
+
+// signedMod returns x mod m in [0, m)
+func signedMod(x int64, m uint64) uint64 {
+	if x >= 0 {
+		return uint64(x) % m
+	}
+	r := (uint64(-(x + 1)) % m) + 1 // |x| mod m, avoiding -MinInt64
+	return (m - r%m) % m
+}
+
+// LucasSequenceMod returns U(k), V(k) and Q^k modulo an odd m for the Lucas sequences with parameters (p, q)
+// It walks the bits of k from the most significant one with
+// U(2j) = U(j)V(j), V(2j) = V(j)² - 2Q^j and
+// U(2j+1) = (p U(2j) + V(2j)) / 2, V(2j+1) = (D U(2j) + p V(2j)) / 2,
+// D = p² - 4q - O(log k) modular multiplications.
+func LucasSequenceMod(p, q int64, k, m uint64) (u, v, qk uint64) {
+	if m == 1 {
+		return 0, 0, 0
+	}
+	pm, qm := signedMod(p, m), signedMod(q, m)
+	d := subMod(mulMod(pm, pm, m), mulMod(4%m, qm, m), m)
+	if k == 0 {
+		return 0, 2 % m, 1 % m
+	}
+	u, v, qk = 1%m, pm, qm
+	for i := bits.Len64(k) - 2; i >= 0; i-- {
+		u = mulMod(u, v, m)
+		v = subMod(mulMod(v, v, m), addMod(qk, qk, m), m)
+		qk = mulMod(qk, qk, m)
+		if k>>uint(i)&1 == 1 {
+			u, v = halfMod(addMod(mulMod(pm, u, m), v, m), m), halfMod(addMod(mulMod(d, u, m), mulMod(pm, v, m), m), m)
+			qk = mulMod(qk, qm, m)
+		}
+	}
+	return u, v, qk
+}
+
+// jacobi returns the Jacobi symbol (a / n) for an odd n
+func jacobi(a int64, n uint64) int {
+	return big.Jacobi(big.NewInt(a), new(big.Int).SetUint64(n))
+}
+
+// isSquare reports whether n is the square of an integer
+func isSquare(n uint64) bool {
+	return isPerfectSquare(new(big.Int).SetUint64(n))
+}
+
+// SelfridgeParameters picks D as the first of 5, -7, 9, -11, ... with (D / n) = -1, and P = 1, Q = (1 - D) / 4
+// ok is false if n is an odd perfect square, for which no such D exists, or if
+// a D sharing a factor with n proves it composite first.
+func SelfridgeParameters(n uint64) (d, p, q int64, ok bool) {
+	if isSquare(n) {
+		return 0, 0, 0, false
+	}
+	for d = 5; ; {
+		switch jacobi(d, n) {
+		case -1:
+			return d, 1, (1 - d) / 4, true
+		case 0:
+			if uint64(max(d, -d)) != n {
+				return d, 0, 0, false
+			}
+		}
+		if d > 0 {
+			d = -(d + 2)
+		} else {
+			d = -d + 2
+		}
+	}
+}
+
+// IsStrongLucasProbablePrime reports whether n passes the strong Lucas probable prime test with Selfridge's parameters
+// With n + 1 = d 2^s, an odd prime n has U(d) = 0 or V(d 2^r) = 0 mod n for some
+// r < s. Combined with a base-2 strong Fermat test it is Baillie-PSW, with no
+// known counterexample.
+func IsStrongLucasProbablePrime(n uint64) bool {
+	switch {
+	case n < 2:
+		return false
+	case n == 2:
+		return true
+	case n%2 == 0:
+		return false
+	}
+	_, p, q, ok := SelfridgeParameters(n)
+	if !ok {
+		// An odd square, or a D sharing a factor with n: never a prime
+		return false
+	}
+	// n + 1 may be 2^64: count s on n+1 = d 2^s without overflowing
+	d, s := n/2+1, 1
+	for d%2 == 0 {
+		d /= 2
+		s++
+	}
+	u, v, qk := LucasSequenceMod(p, q, d, n)
+	if u == 0 || v == 0 {
+		return true
+	}
+	for r := 1; r < s; r++ {
+		v = subMod(mulMod(v, v, n), addMod(qk, qk, n), n)
+		qk = mulMod(qk, qk, n)
+		if v == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStrongFermatProbablePrime2 reports whether n passes the Miller-Rabin test to base 2
+func IsStrongFermatProbablePrime2(n uint64) bool {
+	switch {
+	case n < 2:
+		return false
+	case n == 2:
+		return true
+	case n%2 == 0:
+		return false
+	}
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= uint(s)
+	x := powMod(2, d, n)
+	if x == 1 || x == n-1 {
+		return true
+	}
+	for r := 1; r < s; r++ {
+		x = mulMod(x, x, n)
+		if x == n-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// FibonacciResidues returns e = (n / 5), F(n - e) mod n and F(n) mod n
+// A prime n has F(n - e) = 0 and F(n) = e mod n; a composite passing both is a
+// Fibonacci pseudoprime. n must be odd.
+func FibonacciResidues(n uint64) (e int, fnMinusE, fn uint64) {
+	e = jacobi(5, n)
+	// [[F(n+1), F(n)], [F(n), F(n-1)]] gives every index needed, n+1 included
+	m := matrixPowerMod(Matrix2x2{a: 1 % n, b: 1 % n, c: 1 % n, d: 0}, n, n)
+	switch e {
+	case 1:
+		fnMinusE = m.d
+	case -1:
+		fnMinusE = m.a
+	default:
+		fnMinusE = m.b
+	}
+	return e, fnMinusE, m.b
+}
+
+// smallPrimes screens IsPrime candidates by trial division before the probable-prime tests
+var smallPrimes = [...]uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47}
+
+// IsPrime reports whether n is prime with Baillie-PSW, exact for every 64-bit n
+// Trial division by the primes below 50 settles most composites first.
+func IsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range smallPrimes {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	return IsStrongFermatProbablePrime2(n) && IsStrongLucasProbablePrime(n)
+}
@@ -0,0 +1,70 @@
+package fib
+
+import "math/big"
+
+// Largest n for which each sum fits in a uint64
+const (
+	MaxSafeSumN        = MaxSafeN - 2 // F(n+2) - 1
+	MaxSafeSumEvenN    = MaxSafeN / 2 // F(2n+1) - 1
+	MaxSafeSumOddN     = MaxSafeN / 2 // F(2n)
+	MaxSafeSumSquaresN = 47           // F(n) * F(n+1)
+)
+
+// Sum returns F(0) + F(1) + ... + F(n) = F(n+2) - 1 - O(log n)
+// Returns (sum, false) with a wrapped sum if n > MaxSafeSumN.
+func Sum(n uint64) (uint64, bool) {
+	p := Pair(n)
+	return p[0] + p[1] - 1, n <= MaxSafeSumN
+}
+
+// SumEven returns F(0) + F(2) + ... + F(2n) = F(2n+1) - 1 - O(log n)
+// Returns (sum, false) with a wrapped sum if n > MaxSafeSumEvenN.
+func SumEven(n uint64) (uint64, bool) {
+	p := Pair(n)
+	return p[0]*p[0] + p[1]*p[1] - 1, n <= MaxSafeSumEvenN
+}
+
+// SumOdd returns F(1) + F(3) + ... + F(2n-1) = F(2n) - O(log n)
+// Returns (sum, false) with a wrapped sum if n > MaxSafeSumOddN.
+func SumOdd(n uint64) (uint64, bool) {
+	p := Pair(n)
+	return p[0] * (2*p[1] - p[0]), n <= MaxSafeSumOddN
+}
+
+// SumSquares returns F(0)² + F(1)² + ... + F(n)² = F(n) * F(n+1) - O(log n)
+// Returns (sum, false) with a wrapped sum if n > MaxSafeSumSquaresN.
+func SumSquares(n uint64) (uint64, bool) {
+	p := Pair(n)
+	return p[0] * p[1], n <= MaxSafeSumSquaresN
+}
+
+// BigSum returns F(0) + F(1) + ... + F(n) = F(n+2) - 1 with math/big - O(log n) multiplications
+func BigSum(n uint64) *big.Int {
+	fn, fn1 := BigPair(n)
+	fn.Add(fn, fn1)
+	return fn.Sub(fn, big.NewInt(1))
+}
+
+// BigSumEven returns F(0) + F(2) + ... + F(2n) = F(2n+1) - 1 with math/big
+// F(2n+1) is F(n)² + F(n+1)², so n may be as large as a uint64 allows.
+func BigSumEven(n uint64) *big.Int {
+	fn, fn1 := BigPair(n)
+	fn.Mul(fn, fn)
+	fn.Add(fn, fn1.Mul(fn1, fn1))
+	return fn.Sub(fn, big.NewInt(1))
+}
+
+// BigSumOdd returns F(1) + F(3) + ... + F(2n-1) = F(2n) with math/big
+// F(2n) is F(n) * (2F(n+1) - F(n)), so n may be as large as a uint64 allows.
+func BigSumOdd(n uint64) *big.Int {
+	fn, fn1 := BigPair(n)
+	fn1.Lsh(fn1, 1)
+	fn1.Sub(fn1, fn)
+	return fn.Mul(fn, fn1)
+}
+
+// BigSumSquares returns F(0)² + F(1)² + ... + F(n)² = F(n) * F(n+1) with math/big
+func BigSumSquares(n uint64) *big.Int {
+	fn, fn1 := BigPair(n)
+	return fn.Mul(fn, fn1)
+}
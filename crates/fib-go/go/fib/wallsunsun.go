@@ -0,0 +1,31 @@
+package fib
+
+import (
+	"math"
+	"math/big"
+)
+
+// WallSunSunResidue returns e = (p / 5) and F(p - e) mod p² for a prime p
+// Every prime p divides F(p - e); a Wall-Sun-Sun prime is one for which p² does
+// too, and none is known. Below 2^32 p² fits a uint64 and Mod is used, above it
+// the residue is taken with BigMod. (2 / 5) is -1.
+func WallSunSunResidue(p uint64) (e int, residue *big.Int) {
+	switch p {
+	case 2:
+		e = -1
+	default:
+		e = jacobi(5, p)
+	}
+	index := p
+	switch e {
+	case 1:
+		index--
+	case -1:
+		index++
+	}
+	if p <= math.MaxUint32 {
+		return e, new(big.Int).SetUint64(Mod(index, p*p))
+	}
+	m := new(big.Int).SetUint64(p)
+	return e, BigMod(index, m.Mul(m, m))
+}
@@ -0,0 +1,40 @@
+package fib
+
+import "math/bits"
+
+// Zeckendorf's theorem: every positive integer is uniquely a sum of non-consecutive
+// Fibonacci numbers F(k) with k >= 2. Representations are index lists in decreasing order.
+
+// ZeckendorfEncode returns the Zeckendorf indices of value, largest first; 0 has no terms
+func ZeckendorfEncode(value uint64) []uint64 {
+	var indices []uint64
+	for k := len(fibTable) - 1; value > 0 && k >= 2; k-- {
+		if fibTable[k] <= value {
+			value -= fibTable[k]
+			indices = append(indices, uint64(k))
+			k-- // the next term cannot be consecutive
+		}
+	}
+	return indices
+}
+
+// ZeckendorfDecode sums the Fibonacci numbers named by a Zeckendorf index list
+// It returns ErrInvalidInput unless indices are >= 2, strictly decreasing and
+// non-consecutive, and ErrOverflow if the sum does not fit in a uint64.
+func ZeckendorfDecode(indices []uint64) (uint64, error) {
+	var sum uint64
+	for i, k := range indices {
+		if k < 2 || (i > 0 && k+1 >= indices[i-1]) {
+			return 0, ErrInvalidInput
+		}
+		if k >= uint64(len(fibTable)) {
+			return 0, ErrOverflow
+		}
+		var carry uint64
+		sum, carry = bits.Add64(sum, fibTable[k], 0)
+		if carry != 0 {
+			return 0, ErrOverflow
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,122 @@
+/*
+ * Shared C types of the Go Fibonacci library.
+ *
+ * Included by the cgo preambles and copied verbatim into the generated
+ * include/fib.h, so the Go code and its C callers agree on every constant.
+ */
+#ifndef FIB_TYPES_H
+#define FIB_TYPES_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+/* ABI version: the major number changes on incompatible changes, the minor one when exports are added */
+#define FIB_ABI_VERSION_MAJOR 1
+#define FIB_ABI_VERSION_MINOR 80
+#define FIB_ABI_VERSION ((FIB_ABI_VERSION_MAJOR << 16) | FIB_ABI_VERSION_MINOR)
+
+/* Status codes returned by the exports that can fail */
+typedef int32_t fib_status;
+#define FIB_STATUS_OK 0
+#define FIB_STATUS_OVERFLOW 1
+#define FIB_STATUS_INVALID_ARG 2
+#define FIB_STATUS_LIMIT_EXCEEDED 3
+#define FIB_STATUS_NOT_FOUND 4
+#define FIB_STATUS_BUFFER_TOO_SMALL 5
+#define FIB_STATUS_INTERNAL 6 /* a Go panic was recovered, see GetLastErrorMessage */
+#define FIB_STATUS_INVALID_STATE 7 /* e.g. FibInit while already initialized */
+#define FIB_STATUS_CANCELLED 8 /* the cancel token passed to the call was cancelled */
+#define FIB_STATUS_TIMEOUT 9 /* the deadline of the call (timeout_ms or a timed token) passed */
+#define FIB_STATUS_INEXACT 10 /* an approximation was stored, e.g. FibBinet past its float64 accuracy */
+
+/* Algorithm identifiers accepted by the dispatching exports */
+typedef int32_t fib_algorithm;
+#define FIB_ALGO_ITERATIVE 0
+#define FIB_ALGO_RECURSIVE 1
+#define FIB_ALGO_MEMO 2
+#define FIB_ALGO_MATRIX 3
+#define FIB_ALGO_DOUBLING 4
+#define FIB_ALGO_DOUBLING_ITER 5
+#define FIB_ALGO_MEMO_FAST 6
+#define FIB_ALGO_LOOKUP 7
+
+/* External implementation of F(n) accepted by RegisterAlgorithm */
+typedef uint64_t (*fib_algorithm_fn)(uint64_t n);
+
+/* Progress callback of SetProgressCallback: doubling steps (bits of n) done out of total */
+typedef void (*fib_progress_fn)(uint64_t bits_processed, uint64_t total_bits, void *userdata);
+
+/* Completion callback of FibSubmit, invoked once on a library-owned thread */
+typedef void (*fib_job_callback)(uint64_t job_id, fib_status status, uint64_t result, void *userdata);
+
+/* Levels passed to a fib_log_fn, the log/slog values */
+typedef int32_t fib_log_level;
+#define FIB_LOG_DEBUG (-4)
+#define FIB_LOG_INFO 0
+#define FIB_LOG_WARN 4
+#define FIB_LOG_ERROR 8
+
+/* Log sink of SetLogCallback: one formatted line without the trailing newline, valid during the call */
+typedef void (*fib_log_fn)(fib_log_level level, const char *line, void *userdata);
+
+/* Priorities of FibJobSubmit: higher levels are dequeued first, FIFO within a level */
+typedef int32_t fib_job_priority;
+#define FIB_PRIORITY_LOW 0
+#define FIB_PRIORITY_NORMAL 1
+#define FIB_PRIORITY_HIGH 2
+
+/* States reported by FibJobStatus */
+typedef int32_t fib_job_state;
+#define FIB_JOB_QUEUED 0
+#define FIB_JOB_RUNNING 1
+#define FIB_JOB_DONE 2 /* finished, failed or cancelled: FibJobResult tells which */
+
+/* Crossovers used by FibAuto: the largest n computed by each method, doubling taking the rest */
+typedef struct {
+    uint64_t iterative128_max_n;
+    uint64_t matrix128_max_n;
+    uint64_t big_iterative_max_n;
+    uint64_t big_matrix_max_n;
+} fib_thresholds;
+
+/* Result of FibTimed: elapsed_ns covers the computation only, not FFI marshaling */
+typedef struct {
+    uint64_t value;
+    fib_status status;
+    uint64_t elapsed_ns;
+} fib_timed_result;
+
+/* Result of RunBenchmark: per-call timings in nanoseconds over the measured iterations */
+typedef struct {
+    fib_status status;
+    uint64_t iterations;
+    uint64_t min_ns;
+    uint64_t max_ns;
+    double mean_ns;
+    double stddev_ns; /* sample standard deviation, 0 for a single iteration */
+} fib_bench_result;
+
+/* Result of RunThroughputBenchmark: calls completed by all threads over the measured wall time */
+typedef struct {
+    fib_status status;
+    uint64_t threads;
+    uint64_t operations;
+    uint64_t elapsed_ns;
+    double ops_per_sec;
+} fib_throughput_result;
+
+/* Values of GetRuntimeState */
+typedef int32_t fib_runtime_state;
+#define FIB_RUNTIME_STARTING 0    /* the Go runtime is still bootstrapping; exports block until it is up */
+#define FIB_RUNTIME_IDLE 1        /* runtime up but parked until FibInit (fib_deferred builds) */
+#define FIB_RUNTIME_LIVE 2        /* runtime up and serving calls */
+#define FIB_RUNTIME_INITIALIZED 3 /* FibInit has run and FibShutdown has not */
+
+/* Implemented in C: never enters Go, so it answers (without blocking) while the runtime starts */
+fib_runtime_state GetRuntimeState(void);
+
+/* Behaviors of FibRecursive above the SetRecursiveMaxN cutoff */
+#define FIB_RECURSIVE_FALLBACK 0
+#define FIB_RECURSIVE_ERROR 1
+
+#endif /* FIB_TYPES_H */
@@ -0,0 +1,72 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibEncodeStream Fibonacci-codes count values into out (outCapacity bytes)
+// outBits receives the stream length in bits. Returns StatusBufferTooSmall (with outBits
+// set to the required size) if out is too small, StatusInvalidArg if a value is 0.
+//
+//export FibEncodeStream
+func FibEncodeStream(values *C.uint64_t, count C.size_t, out *C.uint8_t, outCapacity C.size_t, outBits *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if outBits == nil || (values == nil && count > 0) {
+		return StatusInvalidArg
+	}
+	var in []uint64
+	if count > 0 {
+		in = unsafe.Slice((*uint64)(unsafe.Pointer(values)), count)
+	}
+	encoded, nbits, err := fib.Encode(in)
+	if err != nil {
+		return statusOf(err)
+	}
+
+	*outBits = C.uint64_t(nbits)
+	if len(encoded) > int(outCapacity) || (out == nil && len(encoded) > 0) {
+		return StatusBufferTooSmall
+	}
+	if len(encoded) > 0 {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(out)), len(encoded)), encoded)
+	}
+	return StatusOK
+}
+
+// FibDecodeStream decodes nbits of Fibonacci-coded data into out (outCapacity values)
+// outCount receives the number of values. Returns StatusBufferTooSmall (with outCount set to
+// the required size) if out is too small, StatusInvalidArg for a truncated stream.
+//
+//export FibDecodeStream
+func FibDecodeStream(data *C.uint8_t, nbits C.uint64_t, out *C.uint64_t, outCapacity C.size_t, outCount *C.size_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if outCount == nil || (data == nil && nbits > 0) {
+		return StatusInvalidArg
+	}
+	var in []byte
+	if nbits > 0 {
+		in = unsafe.Slice((*byte)(unsafe.Pointer(data)), (uint64(nbits)+7)/8)
+	}
+	values, err := fib.Decode(in, uint64(nbits))
+	if err != nil {
+		return statusOf(err)
+	}
+
+	*outCount = C.size_t(len(values))
+	if len(values) > int(outCapacity) || (out == nil && len(values) > 0) {
+		return StatusBufferTooSmall
+	}
+	if len(values) > 0 {
+		copy(unsafe.Slice((*uint64)(unsafe.Pointer(out)), len(values)), values)
+	}
+	return StatusOK
+}
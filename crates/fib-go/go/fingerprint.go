@@ -0,0 +1,143 @@
+package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// hostFingerprint describes the environment a benchmark ran in
+// Fields that cannot be read on this host are left empty.
+type hostFingerprint struct {
+	Hostname     string     `json:"hostname"`
+	OS           string     `json:"os"`
+	OSVersion    string     `json:"os_version"`
+	Kernel       string     `json:"kernel"`
+	Arch         string     `json:"arch"`
+	CPUModel     string     `json:"cpu_model"`
+	Cores        int        `json:"cores"`
+	CPUMHz       float64    `json:"cpu_mhz,omitempty"`
+	Governor     string     `json:"frequency_governor"`
+	NUMANodes    []numaNode `json:"numa_nodes"`
+	GoVersion    string     `json:"go_version"`
+	GOMAXPROCS   int        `json:"gomaxprocs"`
+	GOGC         int        `json:"gogc"`
+	MemoryLimit  int64      `json:"memory_limit"`
+	CgoEnabled   bool       `json:"cgo_enabled"`
+	DeferredInit bool       `json:"deferred_runtime"`
+}
+
+// numaNode is a NUMA node and the CPUs attached to it, in the kernel's list syntax
+type numaNode struct {
+	Node int    `json:"node"`
+	CPUs string `json:"cpus"`
+}
+
+// GetHostFingerprint returns the environment of this process as a JSON object
+// It holds the CPU model, core count, frequency governor, NUMA layout, OS version,
+// GOMAXPROCS and GOGC among others, to store alongside benchmark results.
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export GetHostFingerprint
+func GetHostFingerprint() *C.char {
+	defer recoverPanic()
+	data, err := json.Marshal(hostFingerprintGo())
+	if err != nil {
+		return nil
+	}
+	return C.CString(string(data))
+}
+
+// hostFingerprintGo collects the fingerprint; the probes read Linux /proc and /sys files
+func hostFingerprintGo() hostFingerprint {
+	fp := hostFingerprint{
+		Hostname:     machineName(),
+		OS:           runtime.GOOS,
+		OSVersion:    osRelease(),
+		Kernel:       readTrimmed("/proc/sys/kernel/osrelease"),
+		Arch:         runtime.GOARCH,
+		Cores:        runtime.NumCPU(),
+		Governor:     readTrimmed("/sys/devices/system/cpu/cpu0/cpufreq/scaling_governor"),
+		NUMANodes:    numaNodes(),
+		GoVersion:    runtime.Version(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		GOGC:         int(gcPercentSetting.Load()),
+		MemoryLimit:  debug.SetMemoryLimit(-1),
+		CgoEnabled:   true,
+		DeferredInit: deferredStartup,
+	}
+	fp.CPUModel, fp.CPUMHz = cpuInfo()
+	return fp
+}
+
+// readTrimmed returns the content of a small text file, or "" if it cannot be read
+func readTrimmed(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// osRelease returns PRETTY_NAME from /etc/os-release
+func osRelease() string {
+	for _, line := range strings.Split(readTrimmed("/etc/os-release"), "\n") {
+		if value, ok := strings.CutPrefix(line, "PRETTY_NAME="); ok {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// cpuInfo returns the model name and current clock of the first CPU in /proc/cpuinfo
+func cpuInfo() (model string, mhz float64) {
+	f, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return "", 0
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		key, value, ok := strings.Cut(sc.Text(), ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case key == "model name" && model == "":
+			model = value
+		case key == "cpu MHz" && mhz == 0:
+			mhz, _ = strconv.ParseFloat(value, 64)
+		}
+		if model != "" && mhz != 0 {
+			break
+		}
+	}
+	return model, mhz
+}
+
+// numaNodes lists the NUMA nodes of /sys/devices/system/node
+func numaNodes() []numaNode {
+	dirs, _ := filepath.Glob("/sys/devices/system/node/node[0-9]*")
+	nodes := []numaNode{}
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+		nodes = append(nodes, numaNode{id, readTrimmed(filepath.Join(dir, "cpulist"))})
+	}
+	slices.SortFunc(nodes, func(a, b numaNode) int { return a.Node - b.Node })
+	return nodes
+}
@@ -0,0 +1,136 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// gcRecentPauses bounds the pauses listed by GetGCStats
+const gcRecentPauses = 16
+
+// gcPercentSetting is the GC percentage last configured by GOGC, SetGCPercent or FibInit
+// It is what the reports show: the runtime only exposes its percentage through
+// debug.SetGCPercent, and setting it just to read it back races with a concurrent setter.
+var gcPercentSetting atomic.Int64
+
+func init() {
+	gcPercentSetting.Store(int64(gogcFromEnv()))
+}
+
+// gogcFromEnv reads GOGC the way the runtime does: "off" is -1, anything unparsable 100
+func gogcFromEnv() int {
+	s := os.Getenv("GOGC")
+	if s == "off" {
+		return -1
+	}
+	if percent, err := strconv.Atoi(s); err == nil {
+		return percent
+	}
+	return 100
+}
+
+// setGCPercent installs the GC percentage and records it for the reports
+func setGCPercent(percent int) int {
+	gcPercentSetting.Store(int64(percent))
+	return debug.SetGCPercent(percent)
+}
+
+// SetGCPercent sets the GOGC target percentage and returns the previous one
+// A negative percent disables the collector, matching the Rust side which has
+// none; memory then only grows until SetMemoryLimit forces a cycle. Before the
+// FibInit of a deferred-startup runtime the value is kept for FibInit to install.
+//
+//export SetGCPercent
+func SetGCPercent(percent C.int32_t) C.int32_t {
+	defer recoverPanic()
+	lifecycle.Lock()
+	defer lifecycle.Unlock()
+	if C.GetRuntimeState() == RuntimeIdle {
+		previous := idleSettings.gcPercent
+		idleSettings.gcPercent = int(percent)
+		gcPercentSetting.Store(int64(percent))
+		return C.int32_t(previous)
+	}
+	return C.int32_t(setGCPercent(int(percent)))
+}
+
+// SetMemoryLimit sets the GOMEMLIMIT soft limit in bytes and returns the previous one
+// A negative limit only reports the current one; math.MaxInt64 removes the limit.
+//
+//export SetMemoryLimit
+func SetMemoryLimit(limit C.int64_t) C.int64_t {
+	defer recoverPanic()
+	return C.int64_t(debug.SetMemoryLimit(int64(limit)))
+}
+
+// gcStats is the JSON object returned by GetGCStats
+type gcStats struct {
+	NumGC        int64   `json:"num_gc"`
+	PauseTotalNS int64   `json:"pause_total_ns"`
+	LastGC       string  `json:"last_gc,omitempty"` // RFC 3339, empty before the first cycle
+	RecentPauses []int64 `json:"recent_pauses_ns"`  // most recent first
+	GCCPUFrac    float64 `json:"gc_cpu_fraction"`
+	HeapAlloc    uint64  `json:"heap_alloc"`
+	HeapSys      uint64  `json:"heap_sys"`
+	NextGC       uint64  `json:"next_gc"`
+	TotalAlloc   uint64  `json:"total_alloc"`
+	Mallocs      uint64  `json:"mallocs"`
+	Frees        uint64  `json:"frees"`
+	GCPercent    int     `json:"gc_percent"`
+	MemoryLimit  int64   `json:"memory_limit"`
+}
+
+// GetGCStats reports the collector activity and heap of the process as a JSON object
+// Meant for inspection after a benchmark run, e.g. to confirm no cycle ran with
+// SetGCPercent(-1). The string must be released with FreeCString.
+//
+//export GetGCStats
+func GetGCStats() *C.char {
+	defer recoverPanic()
+	data, err := json.Marshal(readGCStats())
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return nil
+	}
+	return C.CString(string(data))
+}
+
+// readGCStats collects the fields of GetGCStats
+func readGCStats() gcStats {
+	var gc debug.GCStats
+	debug.ReadGCStats(&gc)
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	s := gcStats{
+		NumGC:        gc.NumGC,
+		PauseTotalNS: gc.PauseTotal.Nanoseconds(),
+		RecentPauses: make([]int64, 0, min(len(gc.Pause), gcRecentPauses)),
+		GCCPUFrac:    mem.GCCPUFraction,
+		HeapAlloc:    mem.HeapAlloc,
+		HeapSys:      mem.HeapSys,
+		NextGC:       mem.NextGC,
+		TotalAlloc:   mem.TotalAlloc,
+		Mallocs:      mem.Mallocs,
+		Frees:        mem.Frees,
+		GCPercent:    int(gcPercentSetting.Load()),
+		MemoryLimit:  debug.SetMemoryLimit(-1),
+	}
+	if gc.NumGC > 0 {
+		s.LastGC = gc.LastGC.Format(time.RFC3339Nano)
+	}
+	for _, p := range gc.Pause[:min(len(gc.Pause), gcRecentPauses)] {
+		s.RecentPauses = append(s.RecentPauses, p.Nanoseconds())
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibGCD calculates gcd(F(m), F(n)) through the identity gcd(F(m), F(n)) = F(gcd(m, n))
+// Stores the result into out and returns StatusOK, StatusOverflow if gcd(m, n) > 93 or StatusInvalidArg.
+//
+//export FibGCD
+func FibGCD(m, n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if out == nil {
+		return StatusInvalidArg
+	}
+	value, ok := fib.GCD(uint64(m), uint64(n))
+	if !ok {
+		return StatusOverflow
+	}
+	*out = C.uint64_t(value)
+	return StatusOK
+}
+
+// FibBigGCD is the big-integer variant of FibGCD, exact for every m and n
+// With verify non-zero it also computes F(m) and F(n) and runs Euclid on them,
+// returning NULL with StatusInternal if the two results differ and
+// StatusLimitExceeded if m or n exceeds max_n. Returns a decimal C string owned
+// by the caller; release it with FreeCString.
+//
+//export FibBigGCD
+func FibBigGCD(m, n C.uint64_t, verify C.int32_t) *C.char {
+	defer recoverPanic()
+	return documentCString(bigGCDDocument("FibBigGCD", m, n, verify))
+}
+
+// bigGCDDocument computes FibBigGCD in decimal for the export called export, verifying it if asked
+func bigGCDDocument(export string, m, n C.uint64_t, verify C.int32_t) (string, C.fib_status, error) {
+	z := tracedBig(export, "big_gcd", uint64(n), func(uint64) *big.Int { return fib.BigGCD(uint64(m), uint64(n)) })
+	if verify != 0 {
+		if maxN := dispatchMaxN.Load(); uint64(m) > maxN || uint64(n) > maxN {
+			return "", StatusLimitExceeded, fmt.Errorf("FibBigGCD: verifying needs F(%d) and F(%d), past max_n", m, n)
+		}
+		if brute := fib.BigGCDBruteForce(uint64(m), uint64(n)); brute.Cmp(z) != 0 {
+			return "", StatusInternal, fmt.Errorf("FibBigGCD: F(gcd(%d, %d)) = %s but gcd(F(%d), F(%d)) = %s", m, n, z, m, n, brute)
+		}
+	}
+	return z.String(), StatusOK, nil
+}
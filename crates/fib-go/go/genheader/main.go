@@ -0,0 +1,293 @@
+// Command genheader generates include/fib.h, the stable C header of the library.
+//
+// It copies fib_types.h verbatim and appends a prototype for every function
+// marked //export in the package sources, preceded by its doc comment, refusing
+// a char* export without its caller-allocated *Buf twin. It also
+// emits the fib_vtable struct of function pointers, whose member order comes
+// from the append-only vtable.txt manifest, and the internal initializer of the
+// table returned by GetFibVTable.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// vtableExport is the export returning the table; it is not a member of it
+const vtableExport = "GetFibVTable"
+
+// bufferTwins names the *Buf twins not called after their char* export
+var bufferTwins = map[string]string{
+	"BigToDecimalString":  "BigToDecimalBuf",
+	"GetLastErrorMessage": "GetLastErrorStringBuf", // alias of GetLastErrorString
+}
+
+// export is the C rendering of one //export function
+type export struct {
+	name   string
+	doc    string
+	result string
+	params []string
+}
+
+func main() {
+	dir := flag.String("dir", ".", "directory holding the Go sources and fib_types.h")
+	output := flag.String("o", "../include/fib.h", "output file")
+	vtableOutput := flag.String("vtable", "fib_vtable_init.h", "output file for the vtable initializer")
+	flag.Parse()
+
+	types, err := os.ReadFile(filepath.Join(*dir, "fib_types.h"))
+	if err != nil {
+		log.Fatalf("genheader: %v", err)
+	}
+	exports, err := exportedFunctions(*dir)
+	if err != nil {
+		log.Fatalf("genheader: %v", err)
+	}
+	members, err := vtableMembers(filepath.Join(*dir, "vtable.txt"), exports)
+	if err != nil {
+		log.Fatalf("genheader: %v", err)
+	}
+	if err := checkBufferTwins(exports); err != nil {
+		log.Fatalf("genheader: %v", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "/* Code generated by genheader/main.go; DO NOT EDIT. */")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "/* fib.h - C interface of the Go Fibonacci library (libfibgo) */")
+	fmt.Fprintln(&buf, "#ifndef FIB_H")
+	fmt.Fprintln(&buf, "#define FIB_H")
+	fmt.Fprintln(&buf)
+	buf.Write(types)
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "#ifdef __cplusplus")
+	fmt.Fprintln(&buf, `extern "C" {`)
+	fmt.Fprintln(&buf, "#endif")
+	fmt.Fprintln(&buf)
+	writeVTableStruct(&buf, members)
+	for _, e := range exports {
+		fmt.Fprintln(&buf)
+		buf.WriteString(e.prototype())
+	}
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "#ifdef __cplusplus")
+	fmt.Fprintln(&buf, "}")
+	fmt.Fprintln(&buf, "#endif")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "#endif /* FIB_H */")
+
+	if err := os.WriteFile(*output, buf.Bytes(), 0o644); err != nil {
+		log.Fatalf("genheader: %v", err)
+	}
+	if err := os.WriteFile(*vtableOutput, vtableInitializer(members), 0o644); err != nil {
+		log.Fatalf("genheader: %v", err)
+	}
+}
+
+// exportedFunctions returns every //export function, ordered by file then position
+func exportedFunctions(dir string) ([]export, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var exports []export
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil || !isExported(fn) {
+				continue
+			}
+			e, err := cExport(fn)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", fset.Position(fn.Pos()), err)
+			}
+			exports = append(exports, e)
+		}
+	}
+	return exports, nil
+}
+
+// vtableMembers resolves the manifest against the exports, in manifest order
+// Every export but GetFibVTable must be listed, so new ones have to be appended.
+func vtableMembers(manifest string, exports []export) ([]export, error) {
+	f, err := os.Open(manifest)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	byName := make(map[string]export, len(exports))
+	for _, e := range exports {
+		byName[e.name] = e
+	}
+	var members []export
+	listed := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		e, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: %s is not an export; members can only be appended", manifest, name)
+		}
+		if listed[name] {
+			return nil, fmt.Errorf("%s: %s is listed twice", manifest, name)
+		}
+		listed[name] = true
+		members = append(members, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, e := range exports {
+		if !listed[e.name] && e.name != vtableExport {
+			return nil, fmt.Errorf("%s: append the new export %s", manifest, e.name)
+		}
+	}
+	return members, nil
+}
+
+// checkBufferTwins requires a caller-allocated *Buf twin for every export returning char*
+func checkBufferTwins(exports []export) error {
+	names := make(map[string]bool, len(exports))
+	for _, e := range exports {
+		names[e.name] = true
+	}
+	for _, e := range exports {
+		if e.result != "char*" {
+			continue
+		}
+		twin, ok := bufferTwins[e.name]
+		if !ok {
+			twin = e.name + "Buf"
+		}
+		if !names[twin] {
+			return fmt.Errorf("%s returns char*: add its caller-allocated twin %s to buffer.go", e.name, twin)
+		}
+	}
+	return nil
+}
+
+// writeVTableStruct emits the fib_vtable typedef
+func writeVTableStruct(buf *bytes.Buffer, members []export) {
+	fmt.Fprintln(buf, "/* Every export as a function pointer, resolved with a single symbol lookup of GetFibVTable.")
+	fmt.Fprintln(buf, " * Members are only ever appended: a host compiled against an older fib.h may use the")
+	fmt.Fprintln(buf, " * members it knows once abi_version has the same major and size covers them. */")
+	fmt.Fprintln(buf, "typedef struct fib_vtable {")
+	fmt.Fprintln(buf, "    uint32_t abi_version;")
+	fmt.Fprintln(buf, "    uint32_t size;")
+	for _, e := range members {
+		fmt.Fprintf(buf, "    %s (*%s)(%s);\n", e.result, e.name, strings.Join(e.params, ", "))
+	}
+	fmt.Fprintln(buf, "} fib_vtable;")
+}
+
+// vtableInitializer renders fib_vtable_init.h, included only by vtable.go
+func vtableInitializer(members []export) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "/* Code generated by genheader/main.go; DO NOT EDIT. */")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "/* Initializer of the table returned by GetFibVTable; requires fib.h */")
+	fmt.Fprintln(&buf, "static const fib_vtable fib_vtable_instance = {")
+	fmt.Fprintln(&buf, "    FIB_ABI_VERSION,")
+	fmt.Fprintln(&buf, "    sizeof(fib_vtable),")
+	for _, e := range members {
+		fmt.Fprintf(&buf, "    %s,\n", e.name)
+	}
+	fmt.Fprintln(&buf, "};")
+	return buf.Bytes()
+}
+
+// isExported reports whether the doc comment of fn carries an //export directive for it
+func isExported(fn *ast.FuncDecl) bool {
+	for _, c := range fn.Doc.List {
+		if c.Text == "//export "+fn.Name.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// cExport renders the doc comment and C signature of an exported function
+func cExport(fn *ast.FuncDecl) (export, error) {
+	e := export{name: fn.Name.Name, result: "void"}
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(fn.Doc.Text()), "\n") {
+		b.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+	}
+	e.doc = b.String()
+
+	if fn.Type.Results != nil {
+		if fn.Type.Results.NumFields() != 1 {
+			return e, fmt.Errorf("%s: exports must return at most one value", fn.Name.Name)
+		}
+		var err error
+		if e.result, err = cType(fn.Type.Results.List[0].Type); err != nil {
+			return e, err
+		}
+	}
+
+	for _, field := range fn.Type.Params.List {
+		t, err := cType(field.Type)
+		if err != nil {
+			return e, err
+		}
+		for _, name := range field.Names {
+			e.params = append(e.params, t+" "+name.Name)
+		}
+	}
+	if len(e.params) == 0 {
+		e.params = []string{"void"}
+	}
+	return e, nil
+}
+
+// prototype renders the doc comment and C declaration of e
+func (e export) prototype() string {
+	return fmt.Sprintf("%s%s %s(%s);\n", e.doc, e.result, e.name, strings.Join(e.params, ", "))
+}
+
+// cType maps a cgo type expression (C.name, *C.name, unsafe.Pointer) to its C spelling
+func cType(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		inner, err := cType(t.X)
+		if err != nil {
+			return "", err
+		}
+		return inner + "*", nil
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			switch {
+			case pkg.Name == "C":
+				return t.Sel.Name, nil
+			case pkg.Name == "unsafe" && t.Sel.Name == "Pointer":
+				return "void*", nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unsupported parameter type %T in export", expr)
+}
@@ -0,0 +1,3 @@
+module github.com/agbru/FibBenchmark/crates/fib-go/go
+
+go 1.23
@@ -0,0 +1,82 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// maxGoldenRatioDigits bounds GoldenRatioDigits and GET /golden-ratio
+const maxGoldenRatioDigits = 1_000_000
+
+// GoldenRatioDigits returns phi = (1 + sqrt(5)) / 2 truncated to k decimal places, "1.618..."
+// It is computed with a k*log2(10)-bit big.Float square root, making a heavier
+// big-float workload than the integer algorithms. Returns a C string owned by the
+// caller; release it with FreeCString. Returns NULL with StatusLimitExceeded if k
+// exceeds 1000000.
+//
+//export GoldenRatioDigits
+func GoldenRatioDigits(k C.uint64_t) *C.char {
+	defer recoverPanic()
+	if k > maxGoldenRatioDigits {
+		setLastError(C.int32_t(StatusLimitExceeded), fmt.Sprintf("GoldenRatioDigits: k must be <= %d", maxGoldenRatioDigits))
+		return nil
+	}
+	return C.CString(fib.GoldenRatioDigits(uint64(k)))
+}
+
+// FibRatio calculates F(n+1) / F(n), the approximation of phi by consecutive Fibonacci numbers
+// Stores the correctly rounded ratio into ratio and, if distance is not NULL,
+// the error bound |F(n+1)/F(n) - phi| = phi^(-n) / F(n) into *distance; it shrinks
+// about 2.6 times per step and underflows to 0 from n = 776. Returns StatusOK, or
+// StatusInvalidArg for n == 0 (F(0) = 0) or a NULL ratio.
+//
+//export FibRatio
+func FibRatio(n C.uint64_t, ratio, distance *C.double) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if n == 0 || ratio == nil {
+		return StatusInvalidArg
+	}
+	r, d := fib.Ratio(uint64(n))
+	*ratio = C.double(r)
+	if distance != nil {
+		*distance = C.double(d)
+	}
+	return StatusOK
+}
+
+// addGoldenRoutes registers the golden-ratio endpoints of StartHTTPServer
+func addGoldenRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /golden-ratio", func(w http.ResponseWriter, r *http.Request) {
+		k, err := queryUint(r, "digits", 50)
+		if err != nil || k > maxGoldenRatioDigits {
+			writeJSON(w, http.StatusBadRequest, apiError{StatusInvalidArg, fmt.Sprintf("digits must be an integer in [0, %d]", maxGoldenRatioDigits)})
+			return
+		}
+		writeJSON(w, http.StatusOK, struct {
+			Digits uint64 `json:"digits"`
+			Value  string `json:"value"`
+		}{k, fib.GoldenRatioDigits(k)})
+	})
+	mux.HandleFunc("GET /ratio/{n}", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.ParseUint(r.PathValue("n"), 10, 64)
+		if err != nil || n == 0 {
+			writeJSON(w, http.StatusBadRequest, apiError{StatusInvalidArg, "n must be a positive 64-bit integer"})
+			return
+		}
+		ratio, distance := fib.Ratio(n)
+		writeJSON(w, http.StatusOK, struct {
+			N        uint64  `json:"n"`
+			Ratio    float64 `json:"ratio"`
+			Distance float64 `json:"distance"`
+		}{n, ratio, distance})
+	})
+}
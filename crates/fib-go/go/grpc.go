@@ -0,0 +1,432 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gRPC over HTTP/2 without a gRPC runtime: the service of proto/fib/v1/fib.proto is
+// served from net/http with the length-prefixed framing and grpc-status trailers
+// of the gRPC wire protocol, so generated clients in any language can call it.
+
+const (
+	// grpcServicePath prefixes the method paths of FibService
+	grpcServicePath = "/fib.v1.FibService/"
+	// maxGRPCMessage bounds a request message, the gRPC default
+	maxGRPCMessage = 4 << 20
+	// maxRPCBenchIters bounds measure_iters and warmup_iters of Benchmark
+	maxRPCBenchIters = 1 << 20
+	// maxStreamItems bounds the items of one StreamSequence call
+	maxStreamItems = 1 << 20
+)
+
+// gRPC status codes used by the service
+const (
+	grpcOK                = 0
+	grpcCancelled         = 1
+	grpcInvalidArgument   = 3
+	grpcDeadlineExceeded  = 4
+	grpcNotFound          = 5
+	grpcResourceExhausted = 8
+	grpcOutOfRange        = 11
+	grpcUnimplemented     = 12
+	grpcInternal          = 13
+)
+
+// grpcError is a failed call: a gRPC status code and message
+type grpcError struct {
+	code    int
+	message string
+}
+
+func (e *grpcError) Error() string { return e.message }
+
+func grpcErrorf(code int, format string, args ...any) error {
+	return &grpcError{code, fmt.Sprintf(format, args...)}
+}
+
+// grpcCodeOf maps a failing library status to a gRPC status code
+func grpcCodeOf(status C.fib_status) int {
+	switch status {
+	case StatusInvalidArg:
+		return grpcInvalidArgument
+	case StatusOverflow:
+		return grpcOutOfRange
+	case StatusLimitExceeded:
+		return grpcResourceExhausted
+	case StatusNotFound:
+		return grpcNotFound
+	case StatusTimeout:
+		return grpcDeadlineExceeded
+	case StatusCancelled:
+		return grpcCancelled
+	}
+	return grpcInternal
+}
+
+// grpcContextError converts a done context into the matching gRPC error
+func grpcContextError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return grpcErrorf(grpcDeadlineExceeded, "deadline exceeded")
+	}
+	return grpcErrorf(grpcCancelled, "cancelled")
+}
+
+// grpcMethods are the FibService handlers; send writes one response message
+var grpcMethods = map[string]func(ctx context.Context, req []byte, send func([]byte) error) error{
+	"Compute":         grpcCompute,
+	"ComputeBatch":    grpcComputeBatch,
+	"Benchmark":       grpcBenchmark,
+	"StreamSequence":  grpcStreamSequence,
+	"StreamBigResult": grpcStreamBigResult,
+}
+
+func grpcCompute(ctx context.Context, req []byte, send func([]byte) error) error {
+	var algorithm string
+	var n uint64
+	err := parseProto(req, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			algorithm, err = f.str()
+		case 2:
+			n, err = f.uint()
+		}
+		return err
+	})
+	if err != nil {
+		return grpcErrorf(grpcInvalidArgument, "%v", err)
+	}
+	r, status, err := computeNamed(ctx, algorithm, n)
+	if status != StatusOK {
+		return grpcErrorf(grpcCodeOf(status), "%v", err)
+	}
+	return send(marshalComputeResponse(nil, r, StatusOK, ""))
+}
+
+func grpcComputeBatch(ctx context.Context, req []byte, send func([]byte) error) error {
+	var algorithm string
+	var ns []uint64
+	err := parseProto(req, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			algorithm, err = f.str()
+		case 2:
+			ns, err = f.uints(ns)
+		}
+		return err
+	})
+	if err != nil {
+		return grpcErrorf(grpcInvalidArgument, "%v", err)
+	}
+	var out []byte
+	for _, n := range ns {
+		if ctx.Err() != nil {
+			return grpcContextError(ctx)
+		}
+		r, status, err := computeNamed(ctx, algorithm, n)
+		message := ""
+		if err != nil {
+			message = err.Error()
+		}
+		out = appendRawBytes(out, 1, marshalComputeResponse(nil, r, status, message))
+	}
+	return send(out)
+}
+
+// marshalComputeResponse encodes a ComputeResponse
+func marshalComputeResponse(b []byte, r apiResult, status C.fib_status, message string) []byte {
+	b = appendUint(b, 1, r.N)
+	b = appendString(b, 2, r.Algorithm)
+	b = appendString(b, 3, r.Value)
+	b = appendInt(b, 4, r.ElapsedNS)
+	b = appendInt(b, 5, int64(status))
+	return appendString(b, 6, message)
+}
+
+func grpcBenchmark(ctx context.Context, req []byte, send func([]byte) error) error {
+	var algorithm string
+	var n uint64
+	opts := benchOptions{WarmupIters: defaultBenchOptions.WarmupIters, MeasureIters: defaultBenchOptions.MeasureIters}
+	err := parseProto(req, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			algorithm, err = f.str()
+		case 2:
+			n, err = f.uint()
+		case 3:
+			opts.WarmupIters, err = f.uint()
+		case 4:
+			opts.MeasureIters, err = f.uint()
+		}
+		return err
+	})
+	if err != nil {
+		return grpcErrorf(grpcInvalidArgument, "%v", err)
+	}
+	algo, ok := algorithmByName(algorithm)
+	switch {
+	case !ok:
+		return grpcErrorf(grpcInvalidArgument, "unknown uint64 algorithm %q", algorithm)
+	case opts.MeasureIters == 0 || opts.MeasureIters > maxRPCBenchIters || opts.WarmupIters > maxRPCBenchIters:
+		return grpcErrorf(grpcInvalidArgument, "measure_iters must be in [1, %d] and warmup_iters at most %[1]d", maxRPCBenchIters)
+	}
+	stats, status := runBenchmarkGo(algo, n, opts)
+	if status != StatusOK {
+		return grpcErrorf(grpcCodeOf(status), "%s", statusText(status))
+	}
+	var b []byte
+	b = appendUint(b, 1, uint64(len(stats.samples)))
+	b = appendUint(b, 2, uint64(stats.min))
+	b = appendUint(b, 3, uint64(stats.max))
+	b = appendDouble(b, 4, stats.mean)
+	b = appendDouble(b, 5, stats.stddev)
+	b = appendUint(b, 6, uint64(stats.p50))
+	b = appendUint(b, 7, uint64(stats.p90))
+	b = appendUint(b, 8, uint64(stats.p99))
+	return send(b)
+}
+
+func grpcStreamSequence(ctx context.Context, req []byte, send func([]byte) error) error {
+	var from, to uint64
+	err := parseProto(req, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			from, err = f.uint()
+		case 2:
+			to, err = f.uint()
+		}
+		return err
+	})
+	if err != nil {
+		return grpcErrorf(grpcInvalidArgument, "%v", err)
+	}
+	var sendErr error
+	status, err := streamSequence(ctx, from, to, func(n uint64, value string) error {
+		sendErr = send(appendString(appendUint(nil, 1, n), 2, value))
+		return sendErr
+	})
+	return grpcStreamError(ctx, status, err, sendErr)
+}
+
+func grpcStreamBigResult(ctx context.Context, req []byte, send func([]byte) error) error {
+	var algorithm, format string
+	var n, chunkSize uint64
+	err := parseProto(req, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			n, err = f.uint()
+		case 2:
+			algorithm, err = f.str()
+		case 3:
+			format, err = f.str()
+		case 4:
+			chunkSize, err = f.uint()
+		}
+		return err
+	})
+	if err != nil {
+		return grpcErrorf(grpcInvalidArgument, "%v", err)
+	}
+	if chunkSize > maxChunkSize {
+		return grpcErrorf(grpcInvalidArgument, "chunk_size must be in [1, %d]", maxChunkSize)
+	}
+	var sendErr error
+	status, err := streamBigResult(ctx, algorithm, n, format, int(chunkSize), func(offset, total int, chunk []byte) error {
+		var b []byte
+		b = appendBytes(b, 1, chunk)
+		b = appendUint(b, 2, uint64(offset))
+		b = appendUint(b, 3, uint64(total))
+		sendErr = send(b)
+		return sendErr
+	})
+	return grpcStreamError(ctx, status, err, sendErr)
+}
+
+// grpcStreamError converts the outcome of a stream, preferring the error of send
+func grpcStreamError(ctx context.Context, status C.fib_status, err, sendErr error) error {
+	switch {
+	case status == StatusOK:
+		return nil
+	case sendErr != nil:
+		return sendErr
+	case status == StatusCancelled || status == StatusTimeout:
+		return grpcContextError(ctx)
+	}
+	return grpcErrorf(grpcCodeOf(status), "%v", err)
+}
+
+// serveGRPC answers one gRPC call on an HTTP/2 request
+func serveGRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC requests only", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc")
+	w.WriteHeader(http.StatusOK)
+	err := grpcCall(w, r)
+	code, message := grpcOK, ""
+	if err != nil {
+		code, message = grpcInternal, err.Error()
+		var e *grpcError
+		if errors.As(err, &e) {
+			code = e.code
+		}
+	}
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", grpcPercentEncode(message))
+	}
+}
+
+// grpcCall reads the request message and runs the method named by the path
+func grpcCall(w http.ResponseWriter, r *http.Request) error {
+	method, ok := strings.CutPrefix(r.URL.Path, grpcServicePath)
+	handler := grpcMethods[method]
+	if !ok || handler == nil {
+		return grpcErrorf(grpcUnimplemented, "unknown method %s", r.URL.Path)
+	}
+	ctx := r.Context()
+	if timeout := r.Header.Get("Grpc-Timeout"); timeout != "" {
+		d, err := parseGRPCTimeout(timeout)
+		if err != nil {
+			return grpcErrorf(grpcInvalidArgument, "%v", err)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+	req, err := readGRPCMessage(r.Body)
+	if err != nil {
+		return err
+	}
+	flusher, _ := w.(http.Flusher)
+	return handler(ctx, req, func(msg []byte) error {
+		frame := make([]byte, 5, 5+len(msg))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+		if _, err := w.Write(append(frame, msg...)); err != nil {
+			return grpcErrorf(grpcCancelled, "client gone: %v", err)
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+}
+
+// readGRPCMessage reads the single length-prefixed message of a unary or server-streaming call
+func readGRPCMessage(body io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(body, header[:]); err != nil {
+		return nil, grpcErrorf(grpcInvalidArgument, "reading request: %v", err)
+	}
+	if header[0] != 0 {
+		return nil, grpcErrorf(grpcUnimplemented, "compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxGRPCMessage {
+		return nil, grpcErrorf(grpcResourceExhausted, "request of %d bytes exceeds %d", size, maxGRPCMessage)
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(body, msg); err != nil {
+		return nil, grpcErrorf(grpcInvalidArgument, "reading request: %v", err)
+	}
+	return msg, nil
+}
+
+// grpcTimeoutUnits are the units of the grpc-timeout header
+var grpcTimeoutUnits = map[byte]time.Duration{
+	'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+	'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+}
+
+// parseGRPCTimeout decodes a grpc-timeout header such as "250m"
+func parseGRPCTimeout(s string) (time.Duration, error) {
+	if len(s) < 2 || len(s) > 9 {
+		return 0, fmt.Errorf("malformed grpc-timeout %q", s)
+	}
+	unit, ok := grpcTimeoutUnits[s[len(s)-1]]
+	value, err := strconv.ParseUint(s[:len(s)-1], 10, 64)
+	if !ok || err != nil {
+		return 0, fmt.Errorf("malformed grpc-timeout %q", s)
+	}
+	return time.Duration(value) * unit, nil
+}
+
+// grpcPercentEncode escapes a grpc-message value
+func grpcPercentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 0x20 && c <= 0x7e && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// grpcServer is the server started by StartGRPCServer
+var grpcServer struct {
+	sync.Mutex
+	srv *http.Server
+}
+
+// StartGRPCServer serves FibService (proto/fib/v1/fib.proto) over HTTP/2 without TLS on addr until StopGRPCServer
+// Compute, ComputeBatch, Benchmark, StreamSequence and StreamBigResult take the
+// algorithm names of StartHTTPServer and honor grpc-timeout; compressed messages
+// are refused.
+// Returns StatusInvalidArg for a NULL addr, StatusInvalidState if a server is
+// already running or the library was built with a Go toolchain older than 1.24
+// (no unencrypted HTTP/2), and StatusInternal if addr cannot be listened on.
+//
+//export StartGRPCServer
+func StartGRPCServer(addr *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if addr == nil {
+		return StatusInvalidArg
+	}
+	if !h2cSupported {
+		return failWith(StatusInvalidState, "StartGRPCServer: needs a library built with Go 1.24 or later")
+	}
+	grpcServer.Lock()
+	defer grpcServer.Unlock()
+	if grpcServer.srv != nil {
+		return failWith(StatusInvalidState, "StartGRPCServer: already serving on %s", grpcServer.srv.Addr)
+	}
+	srv, err := startHTTPServer("grpc", C.GoString(addr), http.HandlerFunc(serveGRPC))
+	if err != nil {
+		return failWith(StatusInternal, "grpc server: %v", err)
+	}
+	grpcServer.srv = srv
+	return StatusOK
+}
+
+// StopGRPCServer stops the server started by StartGRPCServer
+// Running calls get a few seconds to finish. Returns StatusInvalidState if no server is running.
+//
+//export StopGRPCServer
+func StopGRPCServer() (status C.fib_status) {
+	defer recoverStatus(&status)
+	grpcServer.Lock()
+	defer grpcServer.Unlock()
+	if grpcServer.srv == nil {
+		return failWith(StatusInvalidState, "StopGRPCServer: no server is running")
+	}
+	stopHTTPServer(grpcServer.srv)
+	grpcServer.srv = nil
+	return StatusOK
+}
@@ -0,0 +1,15 @@
+//go:build go1.24
+
+package main
+
+import "net/http"
+
+// h2cSupported reports whether the servers speak HTTP/2 without TLS, which gRPC requires
+const h2cSupported = true
+
+// enableH2C lets srv accept HTTP/2 with prior knowledge beside HTTP/1.1
+func enableH2C(srv *http.Server) {
+	srv.Protocols = new(http.Protocols)
+	srv.Protocols.SetHTTP1(true)
+	srv.Protocols.SetUnencryptedHTTP2(true)
+}
@@ -0,0 +1,11 @@
+//go:build !go1.24
+
+package main
+
+import "net/http"
+
+// h2cSupported is false before Go 1.24, whose net/http has no unencrypted HTTP/2 (see h2c.go)
+const h2cSupported = false
+
+// enableH2C leaves srv on HTTP/1.1
+func enableH2C(srv *http.Server) {}
@@ -0,0 +1,152 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"math/big"
+	"runtime/cgo"
+	"slices"
+	"unsafe"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibBigCompute calculates F(n) with big-integer doubling and returns an opaque handle
+// The handle owns the result until it is released with BigFree.
+//
+//export FibBigCompute
+func FibBigCompute(n C.uint64_t) C.uintptr_t {
+	defer recoverPanic()
+	return C.uintptr_t(cgo.NewHandle(tracedBig("FibBigCompute", "big_doubling", uint64(n), bigDoublingReported)))
+}
+
+// FibAuto calculates F(n) with the fastest safe algorithm for n and returns an opaque handle
+// Lookup table, 128-bit or big-integer arithmetic by n; iteration or doubling by the
+// auto_* crossovers of the config. The handle owns the result until BigFree.
+//
+//export FibAuto
+func FibAuto(n C.uint64_t) C.uintptr_t {
+	defer recoverPanic()
+	return C.uintptr_t(cgo.NewHandle(tracedBig("FibAuto", "auto", uint64(n), fib.Auto)))
+}
+
+// FibBigDoublingParallel is FibBigCompute multiplying concurrently once operands reach parallel_mul_threshold bits
+// The handle owns the result until it is released with BigFree.
+//
+//export FibBigDoublingParallel
+func FibBigDoublingParallel(n C.uint64_t) C.uintptr_t {
+	defer recoverPanic()
+	return C.uintptr_t(cgo.NewHandle(tracedBig("FibBigDoublingParallel", "big_doubling_parallel", uint64(n), fib.BigDoublingParallel)))
+}
+
+// FibBigDoublingSquare is FibBigCompute using three squarings per doubling step instead of a general product
+// The handle owns the result until it is released with BigFree.
+//
+//export FibBigDoublingSquare
+func FibBigDoublingSquare(n C.uint64_t) C.uintptr_t {
+	defer recoverPanic()
+	return C.uintptr_t(cgo.NewHandle(tracedBig("FibBigDoublingSquare", "big_doubling_square", uint64(n), fib.BigDoublingSquare)))
+}
+
+// FibLimbDoubling is FibBigCompute on the library's own []uint64 limb arithmetic instead of math/big
+// Karatsuba multiplication, no assembly; the handle owns the result until BigFree.
+//
+//export FibLimbDoubling
+func FibLimbDoubling(n C.uint64_t) C.uintptr_t {
+	defer recoverPanic()
+	return C.uintptr_t(cgo.NewHandle(tracedBig("FibLimbDoubling", "limb_doubling", uint64(n), fib.LimbDoubling)))
+}
+
+// bigFromHandle resolves a handle returned by FibBigCompute, or nil for the zero handle
+func bigFromHandle(h C.uintptr_t) *big.Int {
+	if h == 0 {
+		return nil
+	}
+	x, _ := cgo.Handle(h).Value().(*big.Int)
+	return x
+}
+
+// BigToDecimalString returns the decimal representation of a big result
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export BigToDecimalString
+func BigToDecimalString(h C.uintptr_t) *C.char {
+	defer recoverPanic()
+	x := bigFromHandle(h)
+	if x == nil {
+		return nil
+	}
+	return C.CString(x.String())
+}
+
+// BigByteLen returns the size in bytes of the magnitude of a big result
+//
+//export BigByteLen
+func BigByteLen(h C.uintptr_t) C.size_t {
+	defer recoverPanic()
+	x := bigFromHandle(h)
+	if x == nil {
+		return 0
+	}
+	return C.size_t((x.BitLen() + 7) / 8)
+}
+
+// BigExportBytes writes the big-endian magnitude of a big result into buf
+// Returns the number of bytes required; nothing is written if length is smaller.
+//
+//export BigExportBytes
+func BigExportBytes(h C.uintptr_t, buf *C.uint8_t, length C.size_t) C.size_t {
+	defer recoverPanic()
+	x := bigFromHandle(h)
+	if x == nil {
+		return 0
+	}
+	needed := (x.BitLen() + 7) / 8
+	if buf != nil && int(length) >= needed {
+		x.FillBytes(unsafe.Slice((*byte)(unsafe.Pointer(buf)), needed))
+	}
+	return C.size_t(needed)
+}
+
+// FibBigExport writes the magnitude of a big result into buf, least significant byte first if littleEndian != 0
+// Matches mpz_import (order -1 or 1, size 1) and num-bigint's from_bytes_le/from_bytes_be.
+// Returns the number of bytes required; nothing is written if length is smaller.
+//
+//export FibBigExport
+func FibBigExport(h C.uintptr_t, buf *C.uint8_t, length C.size_t, littleEndian C.int32_t) C.size_t {
+	defer recoverPanic()
+	x := bigFromHandle(h)
+	if x == nil {
+		return 0
+	}
+	needed := (x.BitLen() + 7) / 8
+	if buf != nil && int(length) >= needed {
+		out := unsafe.Slice((*byte)(unsafe.Pointer(buf)), needed)
+		x.FillBytes(out)
+		if littleEndian != 0 {
+			slices.Reverse(out)
+		}
+	}
+	return C.size_t(needed)
+}
+
+// BigFree releases a handle returned by FibBigCompute
+// A handle holding anything but a big result, e.g. an iterator, is left alone
+// and StatusInvalidArg becomes the last error. 0 is ignored.
+//
+//export BigFree
+func BigFree(h C.uintptr_t) {
+	defer recoverPanic()
+	if h == 0 {
+		return
+	}
+	if bigFromHandle(h) == nil {
+		setLastError(C.int32_t(StatusInvalidArg), "BigFree: the handle does not hold a big result")
+		return
+	}
+	cgo.Handle(h).Delete()
+}
@@ -0,0 +1,168 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"runtime"
+	"time"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// goldenValues are known Fibonacci numbers checked by HealthCheck
+var goldenValues = []struct{ n, value uint64 }{
+	{0, 0}, {1, 1}, {2, 1}, {20, 6765}, {50, 12586269025},
+	{92, 7540113804746346429}, {93, 12200160415121876738},
+}
+
+// goldenRecursiveMaxN keeps naive recursion cheap in HealthCheck
+const goldenRecursiveMaxN = 20
+
+// goldenBig is F(300), checked against the big-integer doubling
+const goldenBig = "222232244629420445529739893461909967206666939096499764990979600"
+
+// checkGolden computes the golden values with every built-in algorithm and the big-integer doubling
+// Returns a description of the first mismatch, or "" if all match.
+func checkGolden() string {
+	for _, algo := range registeredAlgorithms()[:AlgoLookup+1] {
+		for _, g := range goldenValues {
+			var got uint64
+			switch {
+			case algo.ID != AlgoRecursive:
+				got = algo.fn(g.n)
+			case g.n <= goldenRecursiveMaxN:
+				got = fib.Recursive(g.n)
+			default:
+				continue
+			}
+			if got != g.value {
+				return fmt.Sprintf("%s: F(%d) = %d, want %d", algo.Name, g.n, got, g.value)
+			}
+		}
+	}
+	if got := fib.BigDoubling(300); got.Cmp(mustBig(goldenBig)) != 0 {
+		return fmt.Sprintf("big doubling: F(300) = %s, want %s", got, goldenBig)
+	}
+	return ""
+}
+
+// mustBig parses a decimal constant
+func mustBig(s string) *big.Int {
+	z, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big constant " + s)
+	}
+	return z
+}
+
+// HealthCheck is a liveness probe: it computes known Fibonacci numbers with every built-in algorithm
+// It takes well under a millisecond on the calling thread and shows the Go runtime
+// accepts calls. Returns StatusOK, or StatusInternal with the mismatch recorded
+// as the last error. GetHealthJSON adds readiness and queue details.
+//
+//export HealthCheck
+func HealthCheck() (status C.fib_status) {
+	defer recoverStatus(&status)
+	if mismatch := checkGolden(); mismatch != "" {
+		return failWith(StatusInternal, "golden value mismatch: %s", mismatch)
+	}
+	return StatusOK
+}
+
+// queueHealth describes the FibJobSubmit queue and its worker pool
+type queueHealth struct {
+	Queued      int `json:"queued"`
+	Running     int `json:"running"`
+	Done        int `json:"done"` // finished but not yet collected by FibJobResult
+	Capacity    int `json:"capacity"`
+	Workers     int `json:"workers"`
+	WorkerLimit int `json:"worker_limit"`
+}
+
+// healthReport is the JSON object returned by GetHealthJSON
+type healthReport struct {
+	Healthy     bool        `json:"healthy"`
+	Ready       bool        `json:"ready"`
+	Error       string      `json:"error,omitempty"`
+	CheckNS     int64       `json:"check_ns"`
+	Runtime     string      `json:"runtime_state"`
+	Initialized bool        `json:"initialized"`
+	Jobs        queueHealth `json:"jobs"`
+	Goroutines  int         `json:"goroutines"`
+	UptimeNS    int64       `json:"uptime_ns"`
+}
+
+// runtimeStateNames names the GetRuntimeState values
+var runtimeStateNames = map[C.fib_runtime_state]string{
+	RuntimeStarting:    "starting",
+	RuntimeIdle:        "idle",
+	RuntimeLive:        "live",
+	RuntimeInitialized: "initialized",
+}
+
+// GetHealthJSON runs HealthCheck and reports readiness and the job queue as a JSON object
+// healthy is the HealthCheck outcome; ready also requires a live runtime, so a
+// deferred-startup library is not ready before FibInit. jobs counts the
+// FibJobSubmit jobs by state with the busy workers and the pool limit. The string
+// must be released with FreeCString.
+//
+//export GetHealthJSON
+func GetHealthJSON() *C.char {
+	defer recoverPanic()
+	data, err := json.Marshal(health())
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return nil
+	}
+	return C.CString(string(data))
+}
+
+// health collects the report of GetHealthJSON
+func health() healthReport {
+	start := time.Now()
+	mismatch := checkGolden()
+	state := C.GetRuntimeState()
+	lifecycle.Lock()
+	initialized := lifecycle.initialized
+	lifecycle.Unlock()
+	r := healthReport{
+		Healthy:     mismatch == "",
+		Error:       mismatch,
+		CheckNS:     time.Since(start).Nanoseconds(),
+		Runtime:     runtimeStateNames[state],
+		Initialized: initialized,
+		Jobs:        jobQueueHealth(),
+		Goroutines:  runtime.NumGoroutine(),
+		UptimeNS:    time.Since(libraryLoaded).Nanoseconds(),
+	}
+	r.Ready = r.Healthy && (state == RuntimeLive || state == RuntimeInitialized)
+	return r
+}
+
+// jobQueueHealth counts the uncollected jobs by state
+func jobQueueHealth() queueHealth {
+	jobQueue.Lock()
+	defer jobQueue.Unlock()
+	q := queueHealth{
+		Queued:      jobQueue.pending.Len(),
+		Capacity:    maxJobs,
+		Workers:     jobQueue.workers,
+		WorkerLimit: int(workerCount.Load()),
+	}
+	for _, j := range jobQueue.jobs {
+		switch j.state {
+		case JobRunning:
+			q.Running++
+		case JobDone:
+			q.Done++
+		}
+	}
+	return q
+}
@@ -0,0 +1,42 @@
+package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// HoradamMatrix calculates W(n) using matrix exponentiation - O(log n)
+// [W(n+1), W(n)] = [[p, -q], [1, 0]]^n [W(1), W(0)]
+//
+//export HoradamMatrix
+func HoradamMatrix(a0, a1, p, q C.int64_t, n C.uint64_t) C.int64_t {
+	defer recoverPanic()
+	return C.int64_t(fib.HoradamMatrix(uint64(a0), uint64(a1), uint64(p), uint64(q), uint64(n)))
+}
+
+// HoradamDoubling calculates W(n) from the doubled pair (U(n), U(n+1)) - O(log n)
+// W(n) = a1*U(n) + a0*(U(n+1) - p*U(n))
+//
+//export HoradamDoubling
+func HoradamDoubling(a0, a1, p, q C.int64_t, n C.uint64_t) C.int64_t {
+	defer recoverPanic()
+	return C.int64_t(fib.HoradamDoubling(uint64(a0), uint64(a1), uint64(p), uint64(q), uint64(n)))
+}
+
+// LucasU calculates the Lucas sequence U(n; p, q) using fast doubling - O(log n)
+//
+//export LucasU
+func LucasU(p, q C.int64_t, n C.uint64_t) C.int64_t {
+	defer recoverPanic()
+	return C.int64_t(fib.LucasU(uint64(p), uint64(q), uint64(n)))
+}
+
+// LucasV calculates the Lucas sequence V(n; p, q) = 2*U(n+1) - p*U(n) using fast doubling - O(log n)
+//
+//export LucasV
+func LucasV(p, q C.int64_t, n C.uint64_t) C.int64_t {
+	defer recoverPanic()
+	return C.int64_t(fib.LucasV(uint64(p), uint64(q), uint64(n)))
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// httpShutdownTimeout bounds how long stopHTTPServer waits for running requests
+const httpShutdownTimeout = 5 * time.Second
+
+// startHTTPServer listens on address and serves handler on a new goroutine
+// The listener is opened before returning, so address errors are reported to
+// the caller and srv.Addr holds the bound address, even for port 0. HTTP/2
+// without TLS is accepted too where the toolchain allows it (see h2c.go).
+func startHTTPServer(name, address string, handler http.Handler) (*http.Server, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{Addr: ln.Addr().String(), Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	enableH2C(srv)
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(name+" server stopped", "addr", srv.Addr, "error", err)
+		}
+	}()
+	logger.Info(name+" server listening", "addr", srv.Addr)
+	return srv, nil
+}
+
+// stopHTTPServer shuts srv down, closing it if running requests outlast httpShutdownTimeout
+func stopHTTPServer(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		srv.Close()
+	}
+}
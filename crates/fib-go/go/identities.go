@@ -0,0 +1,182 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// maxIdentitiesN bounds VerifyIdentities, whose pair identities are O(maxN²) per implementation
+const maxIdentitiesN = 1000
+
+// maxIdentityFailures bounds the failures listed in the report; failure_count has the total
+const maxIdentityFailures = 100
+
+// identityNames are the identities checked by VerifyIdentities
+var identityNames = []string{"cassini", "docagne", "addition"}
+
+// identityFailure is one instance of an identity an implementation broke
+type identityFailure struct {
+	Identity       string `json:"identity"`
+	Implementation string `json:"implementation"`
+	M              uint64 `json:"m"`
+	N              uint64 `json:"n"`
+	Detail         string `json:"detail"`
+}
+
+// identityCoverage is how far one implementation was checked
+type identityCoverage struct {
+	Name     string `json:"name"`
+	MaxN     uint64 `json:"max_n"`
+	Checks   uint64 `json:"checks"`
+	Failures uint64 `json:"failures"`
+}
+
+// identityReport is the VerifyIdentities document
+type identityReport struct {
+	MaxN            uint64             `json:"max_n"`
+	Identities      []string           `json:"identities"`
+	Implementations []identityCoverage `json:"implementations"`
+	Passed          bool               `json:"passed"`
+	FailureCount    uint64             `json:"failure_count"`
+	Failures        []identityFailure  `json:"failures"`
+}
+
+// signedUnit returns (-1)^n
+func signedUnit(n uint64) *big.Int {
+	if n%2 == 1 {
+		return big.NewInt(-1)
+	}
+	return big.NewInt(1)
+}
+
+// checkIdentities checks the identities on f, the values F(0..len(f)-1) of implementation name
+// Products are taken in big integers, so uint64 values are checked exactly.
+func (r *identityReport) checkIdentities(name string, f []*big.Int) {
+	cov := identityCoverage{Name: name, MaxN: uint64(len(f) - 1)}
+	var lhs, rhs, t big.Int
+	fail := func(identity string, m, n uint64) {
+		cov.Failures++
+		r.FailureCount++
+		if len(r.Failures) < maxIdentityFailures {
+			r.Failures = append(r.Failures, identityFailure{identity, name, m, n, fmt.Sprintf("%s != %s", &lhs, &rhs)})
+		}
+	}
+	last := uint64(len(f) - 1)
+
+	// Cassini: F(n-1)F(n+1) - F(n)² = (-1)^n
+	for n := uint64(1); n < last; n++ {
+		lhs.Mul(f[n-1], f[n+1])
+		lhs.Sub(&lhs, t.Mul(f[n], f[n]))
+		rhs.Set(signedUnit(n))
+		cov.Checks++
+		if lhs.Cmp(&rhs) != 0 {
+			fail("cassini", 0, n)
+		}
+	}
+	// d'Ocagne: F(m)F(n+1) - F(m+1)F(n) = (-1)^n F(m-n) for m >= n
+	for m := uint64(0); m < last; m++ {
+		for n := uint64(0); n <= m; n++ {
+			lhs.Mul(f[m], f[n+1])
+			lhs.Sub(&lhs, t.Mul(f[m+1], f[n]))
+			rhs.Mul(signedUnit(n), f[m-n])
+			cov.Checks++
+			if lhs.Cmp(&rhs) != 0 {
+				fail("docagne", m, n)
+			}
+		}
+	}
+	// Addition: F(m+n) = F(m)F(n+1) + F(m-1)F(n) for m >= 1
+	for m := uint64(1); m <= last; m++ {
+		for n := uint64(0); m+n <= last; n++ {
+			lhs.Set(f[m+n])
+			rhs.Mul(f[m], f[n+1])
+			rhs.Add(&rhs, t.Mul(f[m-1], f[n]))
+			cov.Checks++
+			if lhs.Cmp(&rhs) != 0 {
+				fail("addition", m, n)
+			}
+		}
+	}
+	r.Implementations = append(r.Implementations, cov)
+}
+
+// verifyIdentitiesGo checks the identities on F(0..maxN) of every implementation
+// uint64 algorithms stop at their max_safe_n and naive recursion at the
+// VerifyAlgorithms cutoff; the big-integer algorithms cover the whole range.
+func verifyIdentitiesGo(maxN uint64) (identityReport, error) {
+	report := identityReport{MaxN: maxN, Identities: identityNames, Failures: []identityFailure{}}
+	recursiveLimit := min(recursiveMaxN.Load(), verifyRecursiveMaxN)
+	for _, algo := range registeredAlgorithms() {
+		last := min(maxN, algo.MaxSafeN)
+		if algo.ID == AlgoRecursive {
+			last = min(last, recursiveLimit)
+		}
+		f := make([]*big.Int, last+1)
+		for n := range f {
+			f[n] = new(big.Int).SetUint64(algo.fn(uint64(n)))
+		}
+		report.checkIdentities(algo.Name, f)
+	}
+
+	names := make([]string, 0, len(bigAlgorithms))
+	for name := range bigAlgorithms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		f := make([]*big.Int, maxN+1)
+		for n := range f {
+			z, err := bigAlgorithms[name](context.Background(), uint64(n))
+			if err != nil {
+				return report, fmt.Errorf("%s: F(%d): %w", name, n, err)
+			}
+			f[n] = z
+		}
+		report.checkIdentities(name, f)
+	}
+	report.Passed = report.FailureCount == 0
+	return report, nil
+}
+
+// VerifyIdentities checks Fibonacci identities on F(0..maxN) of every implementation
+// Cassini's F(n-1)F(n+1) - F(n)² = (-1)^n, d'Ocagne's
+// F(m)F(n+1) - F(m+1)F(n) = (-1)^n F(m-n) and the addition formula
+// F(m+n) = F(m)F(n+1) + F(m-1)F(n) are evaluated exactly in big integers on the
+// values each implementation returns, catching errors a single comparison can
+// miss. uint64 algorithms are checked up to their max_safe_n and naive
+// recursion up to n = 25. Returns {"max_n", "identities", "implementations":
+// [{"name", "max_n", "checks", "failures"}], "passed", "failure_count",
+// "failures": [{"identity", "implementation", "m", "n", "detail"}]} listing the
+// first 100 failures; the string is owned by the caller and must be released
+// with FreeCString. Returns NULL with StatusLimitExceeded if maxN exceeds 1000.
+//
+//export VerifyIdentities
+func VerifyIdentities(maxN C.uint64_t) *C.char {
+	defer recoverPanic()
+	return documentCString(identitiesDocument(maxN))
+}
+
+// identitiesDocument checks the bound of VerifyIdentities and encodes its report
+func identitiesDocument(maxN C.uint64_t) (string, C.fib_status, error) {
+	if maxN > maxIdentitiesN {
+		return "", StatusLimitExceeded, fmt.Errorf("VerifyIdentities: maxN must be <= %d", maxIdentitiesN)
+	}
+	report, err := verifyIdentitiesGo(uint64(maxN))
+	if err != nil {
+		return "", statusOf(err), fmt.Errorf("VerifyIdentities: %v", err)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(data), StatusOK, nil
+}
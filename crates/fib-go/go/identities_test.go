@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// identityTestMaxN bounds the identity tests, which are quadratic in it
+const identityTestMaxN = 150
+
+func TestVerifyIdentitiesPasses(t *testing.T) {
+	report, err := verifyIdentitiesGo(identityTestMaxN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Passed || report.FailureCount != 0 {
+		t.Fatalf("%d failures: %+v", report.FailureCount, report.Failures)
+	}
+	if want := len(registeredAlgorithms()) + len(bigAlgorithms); len(report.Implementations) != want {
+		t.Errorf("%d implementations checked, want %d", len(report.Implementations), want)
+	}
+	for _, cov := range report.Implementations {
+		if cov.Checks == 0 {
+			t.Errorf("%s: no identity checked", cov.Name)
+		}
+	}
+	if _, status, _ := identitiesDocument(maxIdentitiesN + 1); status != StatusLimitExceeded {
+		t.Errorf("maxN %d: status %d, want StatusLimitExceeded", maxIdentitiesN+1, status)
+	}
+}
+
+func TestCheckIdentitiesReportsFailures(t *testing.T) {
+	f := make([]*big.Int, 21)
+	for n := range f {
+		f[n] = fib.BigIterative(uint64(n))
+	}
+	f[10] = big.NewInt(56)
+	var report identityReport
+	report.checkIdentities("corrupt", f)
+	failed := map[string]bool{}
+	for _, failure := range report.Failures {
+		failed[failure.Identity] = true
+	}
+	for _, identity := range identityNames {
+		if !failed[identity] {
+			t.Errorf("%s holds with F(10) = 56", identity)
+		}
+	}
+	if cov := report.Implementations[0]; cov.Failures != report.FailureCount || cov.Failures == 0 {
+		t.Errorf("coverage %+v, want the %d failures", cov, report.FailureCount)
+	}
+}
+
+// TestFibIdentities checks the identities directly on the big-integer algorithms of package fib
+func TestFibIdentities(t *testing.T) {
+	f := make([]*big.Int, identityTestMaxN+2)
+	for n := range f {
+		f[n] = fib.BigDoubling(uint64(n))
+	}
+	var lhs, rhs, tmp big.Int
+
+	for n := uint64(1); n <= identityTestMaxN; n++ {
+		// Cassini: F(n-1)F(n+1) - F(n)² = (-1)^n
+		lhs.Sub(lhs.Mul(f[n-1], f[n+1]), tmp.Mul(f[n], f[n]))
+		if lhs.Cmp(signedUnit(n)) != 0 {
+			t.Errorf("Cassini at n = %d: %s", n, &lhs)
+		}
+	}
+	for m := uint64(0); m <= identityTestMaxN; m++ {
+		for n := uint64(0); n <= m; n++ {
+			// d'Ocagne: F(m)F(n+1) - F(m+1)F(n) = (-1)^n F(m-n)
+			lhs.Sub(lhs.Mul(f[m], f[n+1]), tmp.Mul(f[m+1], f[n]))
+			rhs.Mul(signedUnit(n), f[m-n])
+			if lhs.Cmp(&rhs) != 0 {
+				t.Errorf("d'Ocagne at m = %d, n = %d: %s != %s", m, n, &lhs, &rhs)
+			}
+
+			// gcd(F(m), F(n)) = F(gcd(m, n))
+			want := new(big.Int).GCD(nil, nil, f[m], f[n])
+			if got := fib.BigGCD(m, n); got.Cmp(want) != 0 {
+				t.Errorf("BigGCD(%d, %d) = %s, want %s", m, n, got, want)
+			}
+			if got, ok := fib.GCD(m, n); ok && (!want.IsUint64() || got != want.Uint64()) {
+				t.Errorf("GCD(%d, %d) = %d, want %s", m, n, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// FibIndexOf stores into n the index such that F(n) == value
+// For value 1 the smallest index (1) is reported. Returns StatusNotFound if value is not a Fibonacci number.
+//
+//export FibIndexOf
+func FibIndexOf(value C.uint64_t, n *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if n == nil {
+		return StatusInvalidArg
+	}
+	index, ok := fib.IndexOf(uint64(value))
+	if !ok {
+		return StatusNotFound
+	}
+	*n = C.uint64_t(index)
+	return StatusOK
+}
+
+// IsFibonacci returns 1 if value is a Fibonacci number, 0 otherwise
+// x is a Fibonacci number iff 5x^2 + 4 or 5x^2 - 4 is a perfect square.
+//
+//export IsFibonacci
+func IsFibonacci(value C.uint64_t) C.int32_t {
+	defer recoverPanic()
+	if fib.IsFibonacci(uint64(value)) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"sync"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// iterHandle is the value behind a handle returned by FibIterNew
+// The mutex lets a host share a handle between threads.
+type iterHandle struct {
+	sync.Mutex
+	it *fib.Iterator
+}
+
+// iterFromHandle resolves a handle returned by FibIterNew, or nil for an invalid one
+func iterFromHandle(h C.uintptr_t) *iterHandle {
+	if h == 0 {
+		return nil
+	}
+	it, _ := cgo.Handle(h).Value().(*iterHandle)
+	return it
+}
+
+// FibIterNew returns an iterator handle whose first FibIterNext yields F(startN) - O(log startN)
+// Streaming consumers then pay one addition per value. Release it with FibIterFree.
+//
+//export FibIterNew
+func FibIterNew(startN C.uint64_t) C.uintptr_t {
+	defer recoverPanic()
+	return C.uintptr_t(cgo.NewHandle(&iterHandle{it: fib.NewIterator(uint64(startN))}))
+}
+
+// FibIterNext returns the current Fibonacci number of an iterator and advances it - O(1)
+// Values wrap past F(93) like the single-value exports. Returns 0 and records
+// StatusInvalidArg as the last error for an invalid handle.
+//
+//export FibIterNext
+func FibIterNext(h C.uintptr_t) C.uint64_t {
+	defer recoverPanic()
+	ih := iterFromHandle(h)
+	if ih == nil {
+		setLastError(C.int32_t(StatusInvalidArg), "invalid iterator handle")
+		return 0
+	}
+	ih.Lock()
+	defer ih.Unlock()
+	return C.uint64_t(ih.it.Next())
+}
+
+// FibIterPrev steps an iterator back one index and returns that Fibonacci number - O(1)
+// Uses F(n-1) = F(n+1) - F(n), so FibIterNext then FibIterPrev yields the same value twice.
+// Returns 0 and records StatusInvalidArg as the last error for an invalid handle or at index 0.
+//
+//export FibIterPrev
+func FibIterPrev(h C.uintptr_t) C.uint64_t {
+	defer recoverPanic()
+	ih := iterFromHandle(h)
+	if ih == nil {
+		setLastError(C.int32_t(StatusInvalidArg), "invalid iterator handle")
+		return 0
+	}
+	ih.Lock()
+	defer ih.Unlock()
+	value, err := ih.it.Prev()
+	if err != nil {
+		setLastError(C.int32_t(statusOf(err)), "iterator is at F(0)")
+		return 0
+	}
+	return C.uint64_t(value)
+}
+
+// FibIterSeek positions an iterator so that the next FibIterNext yields F(n)
+// Targets within 64 indices are reached by stepping from the current pair, others by doubling.
+// Returns StatusInvalidArg for an invalid handle.
+//
+//export FibIterSeek
+func FibIterSeek(h C.uintptr_t, n C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	ih := iterFromHandle(h)
+	if ih == nil {
+		return StatusInvalidArg
+	}
+	ih.Lock()
+	defer ih.Unlock()
+	ih.it.Seek(uint64(n))
+	return StatusOK
+}
+
+// FibIterSkip advances an iterator by k indices without yielding them - O(log(n+k))
+// Returns StatusInvalidArg for an invalid handle and StatusOverflow if the index would pass UINT64_MAX.
+//
+//export FibIterSkip
+func FibIterSkip(h C.uintptr_t, k C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	ih := iterFromHandle(h)
+	if ih == nil {
+		return StatusInvalidArg
+	}
+	ih.Lock()
+	defer ih.Unlock()
+	return statusOf(ih.it.Skip(uint64(k)))
+}
+
+// FibIterFree releases a handle returned by FibIterNew
+//
+//export FibIterFree
+func FibIterFree(h C.uintptr_t) {
+	defer recoverPanic()
+	if iterFromHandle(h) == nil {
+		return
+	}
+	cgo.Handle(h).Delete()
+}
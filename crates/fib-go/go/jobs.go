@@ -0,0 +1,215 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// Job priorities and states (see fib_types.h)
+const (
+	PriorityLow    C.fib_job_priority = C.FIB_PRIORITY_LOW
+	PriorityNormal C.fib_job_priority = C.FIB_PRIORITY_NORMAL
+	PriorityHigh   C.fib_job_priority = C.FIB_PRIORITY_HIGH
+
+	JobQueued  C.fib_job_state = C.FIB_JOB_QUEUED
+	JobRunning C.fib_job_state = C.FIB_JOB_RUNNING
+	JobDone    C.fib_job_state = C.FIB_JOB_DONE
+)
+
+// maxJobs bounds the jobs submitted with FibJobSubmit and not yet collected by FibJobResult
+const maxJobs = 4096
+
+// queuedJob is a computation owned by the job queue
+type queuedJob struct {
+	id        uint64
+	priority  C.fib_job_priority
+	algorithm C.fib_algorithm
+	fn        func(uint64) uint64
+	n         uint64
+	state     C.fib_job_state
+	cancelled bool
+	value     uint64
+	status    C.fib_status
+	index     int // position in jobQueue.pending while queued
+}
+
+// jobHeap orders queued jobs by priority, then by submission
+type jobHeap []*queuedJob
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].id < h[j].id
+}
+
+func (h jobHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *jobHeap) Push(x any) {
+	j := x.(*queuedJob)
+	j.index = len(*h)
+	*h = append(*h, j)
+}
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	j := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	j.index = -1
+	return j
+}
+
+// jobQueue holds every uncollected job; workers are started on demand up to workerCount
+var jobQueue = struct {
+	sync.Mutex
+	pending jobHeap
+	jobs    map[uint64]*queuedJob
+	workers int
+}{jobs: make(map[uint64]*queuedJob)}
+
+// FibJobSubmit queues F(n) with algorithmID at priority and stores its job id in outID
+// Jobs run on a pool of at most `workers` goroutines (see FibInit), higher
+// priorities first. Returns StatusInvalidArg for an unknown algorithm or priority
+// and StatusLimitExceeded while 4096 jobs are waiting to be collected.
+//
+//export FibJobSubmit
+func FibJobSubmit(algorithmID C.fib_algorithm, n C.uint64_t, priority C.fib_job_priority, outID *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	fn := algorithmFunc(algorithmID)
+	if fn == nil {
+		return failWith(StatusInvalidArg, "unknown algorithm %d", algorithmID)
+	}
+	if priority < PriorityLow || priority > PriorityHigh {
+		return failWith(StatusInvalidArg, "unknown priority %d", priority)
+	}
+	if outID == nil {
+		return StatusInvalidArg
+	}
+
+	jobQueue.Lock()
+	defer jobQueue.Unlock()
+	if len(jobQueue.jobs) >= maxJobs {
+		return failWith(StatusLimitExceeded, "FibJobSubmit: %d jobs await FibJobResult", maxJobs)
+	}
+	j := &queuedJob{
+		id:        lastJobID.Add(1),
+		priority:  priority,
+		algorithm: algorithmID,
+		fn:        fn,
+		n:         uint64(n),
+		state:     JobQueued,
+	}
+	jobQueue.jobs[j.id] = j
+	heap.Push(&jobQueue.pending, j)
+	if jobQueue.workers < int(workerCount.Load()) {
+		jobQueue.workers++
+		go runJobs()
+	}
+	*outID = C.uint64_t(j.id)
+	return StatusOK
+}
+
+// runJobs is a queue worker: it runs jobs until the queue is empty, then exits
+func runJobs() {
+	for {
+		jobQueue.Lock()
+		if jobQueue.pending.Len() == 0 {
+			jobQueue.workers--
+			jobQueue.Unlock()
+			return
+		}
+		j := heap.Pop(&jobQueue.pending).(*queuedJob)
+		j.state = JobRunning
+		jobQueue.Unlock()
+
+		value, status := computeJob(j.algorithm, j.fn, j.n)
+
+		jobQueue.Lock()
+		if j.cancelled {
+			value, status = 0, StatusCancelled
+		}
+		j.value, j.status, j.state = value, status, JobDone
+		jobQueue.Unlock()
+	}
+}
+
+// FibJobStatus stores the state of job id in state
+// Returns StatusNotFound for an unknown or already collected job.
+//
+//export FibJobStatus
+func FibJobStatus(id C.uint64_t, state *C.fib_job_state) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if state == nil {
+		return StatusInvalidArg
+	}
+	jobQueue.Lock()
+	defer jobQueue.Unlock()
+	j, ok := jobQueue.jobs[uint64(id)]
+	if !ok {
+		return failWith(StatusNotFound, "unknown job %d", id)
+	}
+	*state = j.state
+	return StatusOK
+}
+
+// FibJobResult collects a finished job: it stores F(n) in result and returns the job's status
+// The job is forgotten afterwards. Returns StatusNotFound for an unknown or already
+// collected job and StatusInvalidState while it is queued or running.
+//
+//export FibJobResult
+func FibJobResult(id C.uint64_t, result *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if result == nil {
+		return StatusInvalidArg
+	}
+	jobQueue.Lock()
+	defer jobQueue.Unlock()
+	j, ok := jobQueue.jobs[uint64(id)]
+	if !ok {
+		return failWith(StatusNotFound, "unknown job %d", id)
+	}
+	if j.state != JobDone {
+		return failWith(StatusInvalidState, "job %d has not finished", id)
+	}
+	delete(jobQueue.jobs, j.id)
+	*result = C.uint64_t(j.value)
+	return j.status
+}
+
+// FibJobCancel cancels job id, which then finishes with StatusCancelled
+// A queued job never runs; a running one completes but its result is discarded.
+// Returns StatusNotFound for an unknown job and StatusInvalidState once it is done.
+//
+//export FibJobCancel
+func FibJobCancel(id C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	jobQueue.Lock()
+	defer jobQueue.Unlock()
+	j, ok := jobQueue.jobs[uint64(id)]
+	if !ok {
+		return failWith(StatusNotFound, "unknown job %d", id)
+	}
+	switch j.state {
+	case JobQueued:
+		heap.Remove(&jobQueue.pending, j.index)
+		j.status, j.state = StatusCancelled, JobDone
+	case JobRunning:
+		j.cancelled = true
+	default:
+		return failWith(StatusInvalidState, "job %d is already done", id)
+	}
+	return StatusOK
+}
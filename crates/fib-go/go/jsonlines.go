@@ -0,0 +1,276 @@
+package main
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// maxLineBytes bounds one request line of the JSON-lines protocol
+const maxLineBytes = 1 << 20
+
+// lineRequest is one request line, e.g. {"id": 7, "algo": "doubling", "n": 90}
+// id is any JSON value and is echoed back unchanged; algo defaults to big_doubling.
+type lineRequest struct {
+	ID   json.RawMessage `json:"id"`
+	Algo string          `json:"algo"`
+	N    *uint64         `json:"n"`
+}
+
+// lineResponse is one response line: the apiResult fields on success, error otherwise
+type lineResponse struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Status C.fib_status    `json:"status"`
+	Error  string          `json:"error,omitempty"`
+	*apiResult
+}
+
+// serveLines answers the JSON-lines requests read from r on w until r ends
+// Up to workers requests run at once, so responses come back in completion
+// order and are matched to requests by id. Every response is flushed when
+// written. Requests still running when r ends are answered before returning.
+func serveLines(ctx context.Context, r io.Reader, w io.Writer) error {
+	out := bufio.NewWriter(w)
+	var (
+		mu       sync.Mutex
+		writeErr error
+	)
+	respond := func(resp lineResponse) {
+		data, err := json.Marshal(resp)
+		if err != nil {
+			data, _ = json.Marshal(lineResponse{ID: resp.ID, Status: StatusInternal, Error: err.Error()})
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if writeErr == nil {
+			out.Write(append(data, '\n'))
+			writeErr = out.Flush()
+		}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(workerCount.Load(), 1))
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineBytes)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var req lineRequest
+		if err := decodeStrict(string(line), &req); err != nil {
+			// Echo the id of a request that is valid JSON but not a valid request
+			var partial struct {
+				ID json.RawMessage `json:"id"`
+			}
+			json.Unmarshal(line, &partial)
+			respond(lineResponse{ID: partial.ID, Status: StatusInvalidArg, Error: fmt.Sprintf("request: %v", err)})
+			continue
+		}
+		if req.N == nil {
+			respond(lineResponse{ID: req.ID, Status: StatusInvalidArg, Error: "request: n is required"})
+			continue
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			defer func() {
+				if r := recover(); r != nil {
+					respond(lineResponse{ID: req.ID, Status: StatusInternal, Error: fmt.Sprint(r)})
+				}
+			}()
+			result, status, err := computeNamed(ctx, req.Algo, *req.N)
+			if status != StatusOK {
+				respond(lineResponse{ID: req.ID, Status: status, Error: err.Error()})
+				return
+			}
+			respond(lineResponse{ID: req.ID, apiResult: &result})
+		}()
+	}
+	err := scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		respond(lineResponse{Status: StatusLimitExceeded, Error: fmt.Sprintf("request line longer than %d bytes", maxLineBytes)})
+	}
+	wg.Wait()
+	if err == nil {
+		err = writeErr
+	}
+	return err
+}
+
+// linesServer is the Unix domain socket server started by StartLinesServer
+var linesServer struct {
+	sync.Mutex
+	ln     net.Listener
+	cancel context.CancelFunc
+	conns  map[net.Conn]struct{}
+	done   sync.WaitGroup
+}
+
+// startLinesServer listens on the Unix domain socket path and serves every connection with serveLines
+func startLinesServer(path string) error {
+	linesServer.Lock()
+	defer linesServer.Unlock()
+	if linesServer.ln != nil {
+		return fmt.Errorf("already serving on %s", linesServer.ln.Addr())
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	linesServer.ln, linesServer.cancel = ln, cancel
+	linesServer.conns = make(map[net.Conn]struct{})
+	linesServer.done.Add(1)
+	go func() {
+		defer linesServer.done.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					logger.Error("json-lines server stopped", "path", path, "error", err)
+				}
+				return
+			}
+			linesServer.Lock()
+			linesServer.conns[conn] = struct{}{}
+			linesServer.done.Add(1)
+			linesServer.Unlock()
+			go func() {
+				defer linesServer.done.Done()
+				if err := serveLines(ctx, conn, conn); err != nil && !errors.Is(err, net.ErrClosed) {
+					logger.Debug("json-lines connection ended", "error", err)
+				}
+				conn.Close()
+				linesServer.Lock()
+				delete(linesServer.conns, conn)
+				linesServer.Unlock()
+			}()
+		}
+	}()
+	logger.Info("json-lines server listening", "path", path)
+	return nil
+}
+
+// stopLinesServer closes the listener and every connection, cancelling the requests they run
+func stopLinesServer() bool {
+	linesServer.Lock()
+	if linesServer.ln == nil {
+		linesServer.Unlock()
+		return false
+	}
+	linesServer.ln.Close()
+	linesServer.cancel()
+	for conn := range linesServer.conns {
+		conn.Close()
+	}
+	linesServer.ln = nil
+	linesServer.Unlock()
+	linesServer.done.Wait()
+	return true
+}
+
+// StartLinesServer serves the JSON-lines protocol on the Unix domain socket path until StopLinesServer
+// Each line sent by a client is a request {"id", "algo", "n"} answered by one
+// line {"id", "status", "n", "algorithm", "value", "elapsed_ns"}, or
+// {"id", "status", "error"} on failure; id is any JSON value, echoed so
+// pipelined requests, answered as they complete, can be matched. algo takes the
+// names of StartHTTPServer, big_doubling by default. The socket file is removed on stop.
+// Returns StatusInvalidArg for a NULL path, StatusInvalidState if a server is
+// already running and StatusInternal if path cannot be listened on.
+//
+//export StartLinesServer
+func StartLinesServer(path *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if path == nil {
+		return StatusInvalidArg
+	}
+	if err := startLinesServer(C.GoString(path)); err != nil {
+		if linesServerRunning() {
+			return failWith(StatusInvalidState, "StartLinesServer: %v", err)
+		}
+		return failWith(StatusInternal, "json-lines server: %v", err)
+	}
+	return StatusOK
+}
+
+// linesServerRunning reports whether StartLinesServer is serving
+func linesServerRunning() bool {
+	linesServer.Lock()
+	defer linesServer.Unlock()
+	return linesServer.ln != nil
+}
+
+// StopLinesServer stops the server started by StartLinesServer
+// Open connections are closed and their running requests cancelled.
+// Returns StatusInvalidState if no server is running.
+//
+//export StopLinesServer
+func StopLinesServer() (status C.fib_status) {
+	defer recoverStatus(&status)
+	if !stopLinesServer() {
+		return failWith(StatusInvalidState, "StopLinesServer: no server is running")
+	}
+	return StatusOK
+}
+
+// runProgram is the entry point of the package built as a program (go build -o fib-go .)
+// It speaks the JSON-lines protocol on stdin and stdout, or with -socket on a
+// Unix domain socket until SIGINT or SIGTERM, after FibInit with -config.
+// Logs go to stderr. Returns the exit code.
+func runProgram(args []string) int {
+	flags := flag.NewFlagSet("fib-go", flag.ContinueOnError)
+	socket := flags.String("socket", "", "serve on this Unix domain socket instead of stdin and stdout")
+	config := flags.String("config", "", "FibInit configuration JSON; FIB_* environment variables apply either way")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	cfg := C.CString(*config)
+	defer C.free(unsafe.Pointer(cfg))
+	// The last error is per OS thread
+	runtime.LockOSThread()
+	if FibInit(cfg) != StatusOK {
+		msg := lastErrorMessage()
+		fmt.Fprintf(os.Stderr, "fib-go: %s\n", C.GoString(msg))
+		C.free(unsafe.Pointer(msg))
+		return 2
+	}
+	runtime.UnlockOSThread()
+
+	if *socket == "" {
+		if err := serveLines(context.Background(), os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "fib-go: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if err := startLinesServer(*socket); err != nil {
+		fmt.Fprintf(os.Stderr, "fib-go: %v\n", err)
+		return 1
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	stopLinesServer()
+	return 0
+}
@@ -0,0 +1,36 @@
+package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"math/big"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibK calculates the n-th k-bonacci number using k x k matrix exponentiation - O(k^3 log n)
+// Returns 0 for k == 0 or k > 256; results wrap past 64 bits.
+//
+//export FibK
+func FibK(k, n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	if k == 0 || k > fib.MaxK {
+		return 0
+	}
+	return C.uint64_t(tracedUint64("FibK", "k", uint64(n), func(n uint64) uint64 { return fib.K(int(k), n) }))
+}
+
+// FibBigK calculates the n-th k-bonacci number with math/big
+// Returns a decimal C string owned by the caller, or NULL for k == 0 or k > 256.
+//
+//export FibBigK
+func FibBigK(k, n C.uint64_t) *C.char {
+	defer recoverPanic()
+	if k == 0 || k > fib.MaxK {
+		return nil
+	}
+	return C.CString(tracedBig("FibBigK", "big_k", uint64(n), func(n uint64) *big.Int { return fib.BigK(int(k), n) }).String())
+}
@@ -0,0 +1,84 @@
+package main
+
+/*
+#include <stdlib.h>
+#include <string.h>
+#include <stdint.h>
+
+// Last-error slots are C thread-locals: an export runs on the foreign thread that
+// called it, so each host thread sees only the errors of its own calls.
+static _Thread_local int32_t fib_tls_error_code;
+static _Thread_local char *fib_tls_error_message;
+static _Thread_local int fib_tls_error_detailed;
+
+// fib_set_last_error takes ownership of message (malloc'd, may be NULL)
+static void fib_set_last_error(int32_t code, char *message) {
+	free(fib_tls_error_message);
+	fib_tls_error_code = code;
+	fib_tls_error_message = message;
+}
+
+// fib_mark_error_detailed flags the last error as describing the current call
+static void fib_mark_error_detailed(void) {
+	fib_tls_error_detailed = 1;
+}
+
+// fib_take_error_detailed reports and clears the flag set by fib_mark_error_detailed
+static int fib_take_error_detailed(void) {
+	int detailed = fib_tls_error_detailed;
+	fib_tls_error_detailed = 0;
+	return detailed;
+}
+
+static int32_t fib_last_error_code(void) {
+	return fib_tls_error_code;
+}
+
+// fib_last_error_message returns a malloc'd copy of the message, or NULL
+static char *fib_last_error_message(void) {
+	if (fib_tls_error_message == NULL) {
+		return NULL;
+	}
+	size_t len = strlen(fib_tls_error_message);
+	char *copy = malloc(len + 1);
+	if (copy != NULL) {
+		memcpy(copy, fib_tls_error_message, len + 1);
+	}
+	return copy;
+}
+*/
+import "C"
+
+// setLastError records code and message as the calling thread's last error
+func setLastError(code C.int32_t, message string) {
+	if debugEnabled() {
+		logger.Debug("call failed", "status", int(code), "error", message)
+	}
+	C.fib_set_last_error(code, C.CString(message))
+}
+
+// setLastErrorDetail records a call-specific message that recoverStatus keeps
+func setLastErrorDetail(code C.int32_t, message string) {
+	setLastError(code, message)
+	C.fib_mark_error_detailed()
+}
+
+// takeLastErrorDetail reports whether setLastErrorDetail ran since the previous call
+func takeLastErrorDetail() bool {
+	return C.fib_take_error_detailed() != 0
+}
+
+// clearLastError resets the calling thread's last error to StatusOK
+func clearLastError() {
+	C.fib_set_last_error(0, nil)
+}
+
+// lastErrorCode returns the calling thread's last error code
+func lastErrorCode() C.int32_t {
+	return C.fib_last_error_code()
+}
+
+// lastErrorMessage returns a malloc'd copy of the calling thread's last error message, or nil
+func lastErrorMessage() *C.char {
+	return C.fib_last_error_message()
+}
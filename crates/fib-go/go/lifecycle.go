@@ -0,0 +1,104 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"runtime/debug"
+	"sync"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// lifecycle tracks FibInit/FibShutdown and the settings to restore on shutdown
+var lifecycle struct {
+	sync.Mutex
+	initialized    bool
+	previous       libraryConfig
+	memoPrecompute uint64
+	warmUp         bool
+	calibrate      bool
+}
+
+// FibInit configures the library and pays its warm-up cost up front
+// configJSON may be NULL or empty for defaults; FIB_* environment variables
+// override it (see the README for the keys).
+// The library works without FibInit; calling it separates startup cost from
+// first-call cost. Returns StatusInvalidArg for a malformed or out-of-range
+// config and StatusInvalidState if the library is already initialized.
+//
+//export FibInit
+func FibInit(configJSON *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	var data []byte
+	if configJSON != nil {
+		data = []byte(C.GoString(configJSON))
+	}
+	cfg, err := resolveConfig(data)
+	if err != nil {
+		return failWith(StatusInvalidArg, "config: %v", err)
+	}
+
+	lifecycle.Lock()
+	defer lifecycle.Unlock()
+	if lifecycle.initialized {
+		return failWith(StatusInvalidState, "FibInit: already initialized, call FibShutdown first")
+	}
+	if C.GetRuntimeState() == RuntimeIdle {
+		wakeRuntime()
+		// The config was resolved against the parked settings
+		if cfg, err = resolveConfig(data); err != nil {
+			return failWith(StatusInvalidArg, "config: %v", err)
+		}
+	}
+	lifecycle.previous = currentConfig()
+	applyConfig(cfg)
+
+	fib.PrecomputeMemo(cfg.MemoPrecompute)
+	if cfg.WarmUp {
+		// Touch every algorithm once so lazily paged code and pools are ready
+		verifyAlgorithmsGo(fib.MaxSafeN)
+	}
+	if cfg.Calibrate {
+		fib.SetAutoThresholds(fib.Calibrate())
+	}
+	lifecycle.memoPrecompute = cfg.MemoPrecompute
+	lifecycle.warmUp = cfg.WarmUp
+	lifecycle.calibrate = cfg.Calibrate
+	lifecycle.initialized = true
+	setRuntimeState(RuntimeInitialized)
+	logger.Info("fib-go initialized", "config", cfg)
+	return StatusOK
+}
+
+// FibShutdown drops the library caches and restores the settings changed by FibInit
+// Returns StatusInvalidState if the library is not initialized.
+//
+//export FibShutdown
+func FibShutdown() (status C.fib_status) {
+	defer recoverStatus(&status)
+	lifecycle.Lock()
+	defer lifecycle.Unlock()
+	if !lifecycle.initialized {
+		return failWith(StatusInvalidState, "FibShutdown: not initialized")
+	}
+
+	fib.ClearMemo()
+	fib.ClearPisanoCache()
+	applyConfig(lifecycle.previous)
+	debug.FreeOSMemory()
+	lifecycle.memoPrecompute = 0
+	lifecycle.warmUp = false
+	lifecycle.calibrate = false
+	lifecycle.initialized = false
+	if deferredStartup {
+		parkRuntime()
+	} else {
+		setRuntimeState(RuntimeLive)
+	}
+	logger.Info("fib-go shut down")
+	return StatusOK
+}
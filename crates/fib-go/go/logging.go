@@ -0,0 +1,175 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// levelOff silences the library logger
+const levelOff = slog.Level(math.MaxInt32)
+
+// logLevel is the threshold of logger, set by the log_level config key and SetLogLevel
+var logLevel slog.LevelVar
+
+// logSink receives the formatted lines of logger: stderr by default, see SetLogFile and SetLogCallback
+var logSink = &logWriter{out: os.Stderr}
+
+// logger writes the library's diagnostics to logSink
+var logger = slog.New(&sinkHandler{slog.NewTextHandler(logSink, &slog.HandlerOptions{Level: &logLevel})})
+
+func init() {
+	logLevel.Set(slog.LevelWarn)
+}
+
+// logWriter forwards each line to a stream and to the C callback, if any
+type logWriter struct {
+	mu       sync.Mutex
+	out      io.Writer // nil discards
+	file     *os.File  // opened by SetLogFile, closed when replaced
+	fn       C.fib_log_fn
+	userdata unsafe.Pointer
+	level    slog.Level // of the record being written
+}
+
+// Write is called by the text handler with one complete line, under mu
+func (w *logWriter) Write(p []byte) (int, error) {
+	if w.out != nil {
+		w.out.Write(p)
+	}
+	if w.fn != nil {
+		callLogFn(w.fn, int(w.level), string(bytes.TrimSuffix(p, []byte("\n"))), w.userdata)
+	}
+	return len(p), nil
+}
+
+// sinkHandler passes the level of each record to logSink, which a plain io.Writer cannot see
+type sinkHandler struct {
+	slog.Handler
+}
+
+func (h *sinkHandler) Handle(ctx context.Context, r slog.Record) error {
+	logSink.mu.Lock()
+	defer logSink.mu.Unlock()
+	logSink.level = r.Level
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *sinkHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sinkHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *sinkHandler) WithGroup(name string) slog.Handler {
+	return &sinkHandler{h.Handler.WithGroup(name)}
+}
+
+// debugEnabled tells hot paths whether building a debug record is worth it
+func debugEnabled() bool {
+	return logLevel.Level() <= slog.LevelDebug
+}
+
+// parseLogLevel accepts debug, info, warn, error or off
+func parseLogLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	case "off":
+		return levelOff, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", name)
+}
+
+// logLevelName is the inverse of parseLogLevel
+func logLevelName(level slog.Level) string {
+	if level >= levelOff {
+		return "off"
+	}
+	return strings.ToLower(level.String())
+}
+
+// SetLogLevel sets the threshold of the library logger, like the log_level config key
+// level is "debug", "info", "warn", "error" or "off", case-insensitive. At debug
+// level the silent outcomes are logged too: recursion cutoff fallbacks, results
+// wrapping past 64 bits and every failing call. Returns StatusInvalidArg for a
+// NULL or unknown level.
+//
+//export SetLogLevel
+func SetLogLevel(level *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if level == nil {
+		return StatusInvalidArg
+	}
+	parsed, err := parseLogLevel(C.GoString(level))
+	if err != nil {
+		return failWith(StatusInvalidArg, "SetLogLevel: %v", err)
+	}
+	logLevel.Set(parsed)
+	return StatusOK
+}
+
+// SetLogFile appends the library's log lines to the file at path instead of stderr
+// A NULL path restores stderr and an empty one drops the lines, e.g. when a
+// SetLogCallback sink is enough. Returns StatusInternal if the file cannot be
+// opened, the previous output being kept.
+//
+//export SetLogFile
+func SetLogFile(path *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	var (
+		out  io.Writer
+		file *os.File
+	)
+	switch {
+	case path == nil:
+		out = os.Stderr
+	case C.GoString(path) != "":
+		var err error
+		if file, err = os.OpenFile(C.GoString(path), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644); err != nil {
+			return failWith(StatusInternal, "log file: %v", err)
+		}
+		out = file
+	}
+	logSink.mu.Lock()
+	previous := logSink.file
+	logSink.out, logSink.file = out, file
+	logSink.mu.Unlock()
+	if previous != nil {
+		previous.Close()
+	}
+	return StatusOK
+}
+
+// SetLogCallback also passes every log line to fn with its level, or stops doing so when fn is NULL
+// fn runs on whichever thread logged, Go worker threads included, one line at a
+// time; it must not call back into the library. userdata is passed through untouched.
+//
+//export SetLogCallback
+func SetLogCallback(fn C.fib_log_fn, userdata unsafe.Pointer) (status C.fib_status) {
+	defer recoverStatus(&status)
+	logSink.mu.Lock()
+	logSink.fn, logSink.userdata = fn, userdata
+	if fn == nil {
+		logSink.userdata = nil
+	}
+	logSink.mu.Unlock()
+	return StatusOK
+}
@@ -0,0 +1,63 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibLookup returns F(n) from the embedded golden table - O(1)
+// Baseline for measuring pure FFI overhead; beyond F(93) it falls back to doubling.
+//
+//export FibLookup
+func FibLookup(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(tracedUint64("FibLookup", "lookup", uint64(n), fib.Lookup))
+}
+
+// VerifyAgainstTable checks an algorithm against the golden table for F(0..93)
+// Returns the first mismatching index, -1 if every value matches, or -2 for an unknown algorithm.
+//
+//export VerifyAgainstTable
+func VerifyAgainstTable(algorithmID C.fib_algorithm) C.int64_t {
+	defer recoverPanic()
+	fn := algorithmFunc(algorithmID)
+	if fn == nil {
+		return -2
+	}
+	for n := uint64(0); n <= fib.MaxSafeN; n++ {
+		if fn(n) != fib.Lookup(n) {
+			return C.int64_t(n)
+		}
+	}
+	return -1
+}
+
+// debugMode enables golden-table checks on every uint64 export
+var debugMode atomic.Bool
+
+// SetDebugMode enables (non-zero) or disables (zero) golden-table checks
+// In debug mode a uint64 result that contradicts the table for n <= 93 panics.
+//
+//export SetDebugMode
+func SetDebugMode(enabled C.int32_t) {
+	defer recoverPanic()
+	debugMode.Store(enabled != 0)
+}
+
+// debugVerify passes value through, checking it against the table in debug mode
+func debugVerify(n, value uint64) uint64 {
+	if debugMode.Load() && n <= fib.MaxSafeN {
+		if want := fib.Lookup(n); want != value {
+			panic(fmt.Sprintf("fib: F(%d) computed as %d, golden table says %d", n, value, want))
+		}
+	}
+	return value
+}
@@ -0,0 +1,52 @@
+package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// LucasIterative calculates Lucas numbers using iterative method - O(n)
+//
+//export LucasIterative
+func LucasIterative(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(fib.LucasIterative(uint64(n)))
+}
+
+// LucasRecursive calculates Lucas numbers using naive recursive method - O(2^n)
+// WARNING: Very slow for n > 35
+//
+//export LucasRecursive
+func LucasRecursive(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(fib.LucasRecursive(uint64(n)))
+}
+
+// LucasMemo calculates Lucas numbers with memoization - O(n)
+//
+//export LucasMemo
+func LucasMemo(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(fib.LucasMemo(uint64(n)))
+}
+
+// LucasMatrix calculates Lucas numbers using matrix exponentiation - O(log n)
+// L(n) is the trace of [[1,1],[1,0]]^n.
+//
+//export LucasMatrix
+func LucasMatrix(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(fib.LucasMatrix(uint64(n)))
+}
+
+// LucasDoubling uses the Lucas doubling identities - O(log n)
+// L(2k) = L(k)^2 - 2(-1)^k
+// L(2k+1) = L(k) * L(k+1) - (-1)^k
+//
+//export LucasDoubling
+func LucasDoubling(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(fib.LucasDoubling(uint64(n)))
+}
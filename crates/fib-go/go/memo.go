@@ -0,0 +1,44 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// MemoCacheClear empties the shared memo used by FibMemo
+//
+//export MemoCacheClear
+func MemoCacheClear() {
+	defer recoverPanic()
+	fib.ClearMemo()
+}
+
+// MemoCacheSize returns the number of entries held by the shared memo
+//
+//export MemoCacheSize
+func MemoCacheSize() C.size_t {
+	defer recoverPanic()
+	return C.size_t(fib.MemoSize())
+}
+
+// MemoPrecompute fills the shared memo with F(2)..F(n) ahead of time
+// n is clamped to memo_max_n (2^20 unless FibInit sets it), the largest n the memo keeps.
+//
+//export MemoPrecompute
+func MemoPrecompute(n C.uint64_t) {
+	defer recoverPanic()
+	fib.PrecomputeMemo(uint64(n))
+}
+
+// FibMemoFast calculates Fibonacci with a slice-backed memo recycled via sync.Pool - O(n)
+// Avoids the map hashing of FibMemo; a recycled table already holds a valid prefix.
+// The table stops at F(93), so n past it costs time but no memory.
+//
+//export FibMemoFast
+func FibMemoFast(n C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(debugVerify(uint64(n), tracedUint64("FibMemoFast", "memo_fast", uint64(n), fib.MemoFast)))
+}
@@ -0,0 +1,202 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// metricsEnabled makes computeAlgorithm time its calls, set while the metrics
+// server runs or the telemetry config key is on
+var metricsEnabled atomic.Bool
+
+// refreshMetricsEnabled recomputes metricsEnabled; metricsServer must be locked
+func refreshMetricsEnabled() {
+	metricsEnabled.Store(metricsServer.srv != nil || telemetryEnabled.Load())
+}
+
+// metricBounds are the upper bounds of the call latency histogram buckets
+var metricBounds = [...]time.Duration{
+	25 * time.Nanosecond, 50 * time.Nanosecond, 100 * time.Nanosecond, 250 * time.Nanosecond, 500 * time.Nanosecond,
+	time.Microsecond, 2500 * time.Nanosecond, 5 * time.Microsecond, 10 * time.Microsecond, 25 * time.Microsecond,
+	50 * time.Microsecond, 100 * time.Microsecond, 250 * time.Microsecond, 500 * time.Microsecond,
+	time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond, time.Second,
+}
+
+// algoMetrics are the counters of one algorithm
+type algoMetrics struct {
+	calls    atomic.Uint64
+	failures atomic.Uint64
+	sumNS    atomic.Uint64
+	// buckets[i] counts the calls in (metricBounds[i-1], metricBounds[i]], the last one the slower calls
+	buckets [len(metricBounds) + 1]atomic.Uint64
+}
+
+// callMetrics is indexed by algorithm identifier, registered algorithms included
+var callMetrics [int(AlgoLookup) + 1 + maxRegisteredAlgorithms]algoMetrics
+
+// statusCounts counts the failing dispatched calls by status code
+var statusCounts [StatusInexact + 1]atomic.Uint64
+
+// bigResults and bigResultBytes count the results of the big-integer exports and their magnitude bytes
+var bigResults, bigResultBytes atomic.Uint64
+
+// observeCall records one dispatched call of algorithm id
+func observeCall(id C.fib_algorithm, d time.Duration, status C.fib_status) {
+	if id < 0 || int(id) >= len(callMetrics) {
+		return
+	}
+	m := &callMetrics[id]
+	m.calls.Add(1)
+	if status != StatusOK {
+		m.failures.Add(1)
+		if status > 0 && int(status) < len(statusCounts) {
+			statusCounts[status].Add(1)
+		}
+	}
+	m.sumNS.Add(uint64(max(d, 0)))
+	i := 0
+	for i < len(metricBounds) && d > metricBounds[i] {
+		i++
+	}
+	m.buckets[i].Add(1)
+}
+
+// metricsServer is the /metrics endpoint started by StartMetricsServer
+var metricsServer struct {
+	sync.Mutex
+	srv *http.Server
+}
+
+// StartMetricsServer serves Prometheus metrics at /metrics on addr ("host:port") until StopMetricsServer
+// While it runs every dispatched call is timed, which adds two clock reads per
+// call: per-algorithm call and failure counts, a latency histogram, the shared
+// memo hits and misses, and GC and heap statistics are exposed in the text
+// format. Returns StatusInvalidArg for a NULL addr, StatusInvalidState if a
+// server is already running and StatusInternal if addr cannot be listened on.
+//
+//export StartMetricsServer
+func StartMetricsServer(addr *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if addr == nil {
+		return StatusInvalidArg
+	}
+	metricsServer.Lock()
+	defer metricsServer.Unlock()
+	if metricsServer.srv != nil {
+		return failWith(StatusInvalidState, "StartMetricsServer: already serving on %s", metricsServer.srv.Addr)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w)
+	})
+	srv, err := startHTTPServer("metrics", C.GoString(addr), mux)
+	if err != nil {
+		return failWith(StatusInternal, "metrics server: %v", err)
+	}
+	metricsServer.srv = srv
+	refreshMetricsEnabled()
+	return StatusOK
+}
+
+// StopMetricsServer stops the server started by StartMetricsServer and, unless telemetry is on, the call timing
+// The counters are kept and resume with the next StartMetricsServer.
+// Returns StatusInvalidState if no server is running.
+//
+//export StopMetricsServer
+func StopMetricsServer() (status C.fib_status) {
+	defer recoverStatus(&status)
+	metricsServer.Lock()
+	defer metricsServer.Unlock()
+	if metricsServer.srv == nil {
+		return failWith(StatusInvalidState, "StopMetricsServer: no server is running")
+	}
+	stopHTTPServer(metricsServer.srv)
+	metricsServer.srv = nil
+	refreshMetricsEnabled()
+	return StatusOK
+}
+
+// labelEscaper escapes Prometheus label values
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeMetrics writes the metrics in the Prometheus text exposition format
+func writeMetrics(w io.Writer) {
+	algos := registeredAlgorithms()
+	header := func(name, kind, help string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+	// each calls fn for the algorithms called at least once
+	each := func(fn func(label string, m *algoMetrics)) {
+		for _, a := range algos {
+			if m := &callMetrics[a.ID]; m.calls.Load() > 0 {
+				fn(`algorithm="`+labelEscaper.Replace(a.Name)+`"`, m)
+			}
+		}
+	}
+
+	header("fib_calls_total", "counter", "Dispatched F(n) calls.")
+	each(func(label string, m *algoMetrics) {
+		fmt.Fprintf(w, "fib_calls_total{%s} %d\n", label, m.calls.Load())
+	})
+	header("fib_call_failures_total", "counter", "Dispatched F(n) calls that returned a failing status.")
+	each(func(label string, m *algoMetrics) {
+		fmt.Fprintf(w, "fib_call_failures_total{%s} %d\n", label, m.failures.Load())
+	})
+	header("fib_call_duration_seconds", "histogram", "Latency of dispatched F(n) calls.")
+	each(func(label string, m *algoMetrics) {
+		var cumulative uint64
+		for i, bound := range metricBounds {
+			cumulative += m.buckets[i].Load()
+			le := strconv.FormatFloat(bound.Seconds(), 'g', -1, 64)
+			fmt.Fprintf(w, "fib_call_duration_seconds_bucket{%s,le=\"%s\"} %d\n", label, le, cumulative)
+		}
+		cumulative += m.buckets[len(metricBounds)].Load()
+		fmt.Fprintf(w, "fib_call_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", label, cumulative)
+		fmt.Fprintf(w, "fib_call_duration_seconds_sum{%s} %g\n", label, float64(m.sumNS.Load())/1e9)
+		fmt.Fprintf(w, "fib_call_duration_seconds_count{%s} %d\n", label, cumulative)
+	})
+
+	hits, misses := fib.MemoStats()
+	header("fib_memo_hits_total", "counter", "Memo calls answered by the shared memo.")
+	fmt.Fprintf(w, "fib_memo_hits_total %d\n", hits)
+	header("fib_memo_misses_total", "counter", "Memo calls that filled the shared memo.")
+	fmt.Fprintf(w, "fib_memo_misses_total %d\n", misses)
+	header("fib_memo_entries", "gauge", "Entries held by the shared memo.")
+	fmt.Fprintf(w, "fib_memo_entries %d\n", fib.MemoSize())
+	header("fib_big_results_total", "counter", "Results returned by the big-integer exports.")
+	fmt.Fprintf(w, "fib_big_results_total %d\n", bigResults.Load())
+	header("fib_big_result_bytes_total", "counter", "Magnitude bytes of the results returned by the big-integer exports.")
+	fmt.Fprintf(w, "fib_big_result_bytes_total %d\n", bigResultBytes.Load())
+
+	gc := readGCStats()
+	header("go_gc_cycles_total", "counter", "Completed GC cycles.")
+	fmt.Fprintf(w, "go_gc_cycles_total %d\n", gc.NumGC)
+	header("go_gc_pause_seconds_total", "counter", "Total stop-the-world GC pause time.")
+	fmt.Fprintf(w, "go_gc_pause_seconds_total %g\n", float64(gc.PauseTotalNS)/1e9)
+	header("go_gc_cpu_fraction", "gauge", "Fraction of the available CPU time used by the GC since the process started.")
+	fmt.Fprintf(w, "go_gc_cpu_fraction %g\n", gc.GCCPUFrac)
+	header("go_memstats_heap_alloc_bytes", "gauge", "Bytes of allocated heap objects.")
+	fmt.Fprintf(w, "go_memstats_heap_alloc_bytes %d\n", gc.HeapAlloc)
+	header("go_memstats_heap_sys_bytes", "gauge", "Bytes of heap memory obtained from the OS.")
+	fmt.Fprintf(w, "go_memstats_heap_sys_bytes %d\n", gc.HeapSys)
+	header("go_memstats_alloc_bytes_total", "counter", "Bytes allocated for heap objects.")
+	fmt.Fprintf(w, "go_memstats_alloc_bytes_total %d\n", gc.TotalAlloc)
+	header("go_goroutines", "gauge", "Goroutines that currently exist.")
+	fmt.Fprintf(w, "go_goroutines %d\n", runtime.NumGoroutine())
+}
@@ -0,0 +1,166 @@
+// Package fibmobile is the gomobile binding of the Fibonacci algorithms.
+//
+// gomobile only maps signed integers, strings and errors, so every function
+// takes an int64 index and returns the exact value or an error: results that
+// do not fit in an int64 (n > 92) are refused rather than wrapped, and the Big*
+// functions return decimal strings instead. Generate the bindings from the go/
+// directory with
+//
+//	gomobile bind -target=android -o fib.aar ./mobile
+//	gomobile bind -target=ios -o Fib.xcframework ./mobile
+package fibmobile
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// RecursiveMaxN is the largest n accepted by Recursive, keeping it under about a second
+const RecursiveMaxN = 40
+
+// algorithms maps the names accepted by Compute and Benchmark to their implementation
+var algorithms = map[string]func(uint64) uint64{
+	"iterative":     fib.Iterative,
+	"recursive":     fib.Recursive,
+	"memo":          fib.Memo,
+	"matrix":        fib.Matrix,
+	"doubling":      fib.Doubling,
+	"doubling_iter": fib.DoublingIter,
+	"memo_fast":     fib.MemoFast,
+	"lookup":        fib.Lookup,
+}
+
+// index validates n for an algorithm whose result must fit in an int64
+func index(n, maxN int64) (uint64, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("%w: negative index %d", fib.ErrInvalidInput, n)
+	}
+	if n > maxN {
+		return 0, fmt.Errorf("%w: F(%d) does not fit in an int64", fib.ErrOverflow, n)
+	}
+	return uint64(n), nil
+}
+
+// compute validates n, then returns fn(n) as an int64
+func compute(n int64, fn func(uint64) uint64) (int64, error) {
+	i, err := index(n, fib.MaxSafeSignedN)
+	if err != nil {
+		return 0, err
+	}
+	return int64(fn(i)), nil
+}
+
+// Iterative calculates F(n) using the iterative method - O(n)
+func Iterative(n int64) (int64, error) {
+	return compute(n, fib.Iterative)
+}
+
+// Recursive calculates F(n) using naive recursion - O(2^n)
+// Returns an error above RecursiveMaxN.
+func Recursive(n int64) (int64, error) {
+	if n > RecursiveMaxN {
+		return 0, fmt.Errorf("%w: recursion limited to n <= %d", fib.ErrInvalidInput, RecursiveMaxN)
+	}
+	return compute(n, fib.Recursive)
+}
+
+// Memo calculates F(n) with the shared memo - O(n), O(1) once cached
+func Memo(n int64) (int64, error) {
+	return compute(n, fib.Memo)
+}
+
+// Matrix calculates F(n) using matrix exponentiation - O(log n)
+func Matrix(n int64) (int64, error) {
+	return compute(n, fib.Matrix)
+}
+
+// Doubling calculates F(n) using the doubling method - O(log n)
+func Doubling(n int64) (int64, error) {
+	return compute(n, fib.Doubling)
+}
+
+// DoublingIter calculates F(n) using the doubling method without recursion - O(log n)
+func DoublingIter(n int64) (int64, error) {
+	return compute(n, fib.DoublingIter)
+}
+
+// MemoFast calculates F(n) with a slice-backed memo recycled via sync.Pool - O(n)
+func MemoFast(n int64) (int64, error) {
+	return compute(n, fib.MemoFast)
+}
+
+// Lookup returns F(n) from the embedded golden table - O(1)
+func Lookup(n int64) (int64, error) {
+	return compute(n, fib.Lookup)
+}
+
+// BigIterative calculates F(n) with math/big using the iterative method, as a decimal string
+func BigIterative(n int64) (string, error) {
+	i, err := index(n, math.MaxInt64)
+	if err != nil {
+		return "", err
+	}
+	return fib.BigIterative(i).String(), nil
+}
+
+// BigMatrix calculates F(n) with math/big using matrix exponentiation, as a decimal string
+func BigMatrix(n int64) (string, error) {
+	i, err := index(n, math.MaxInt64)
+	if err != nil {
+		return "", err
+	}
+	return fib.BigMatrix(i).String(), nil
+}
+
+// BigDoubling calculates F(n) with math/big using the doubling method, as a decimal string
+func BigDoubling(n int64) (string, error) {
+	i, err := index(n, math.MaxInt64)
+	if err != nil {
+		return "", err
+	}
+	return fib.BigDoubling(i).String(), nil
+}
+
+// Algorithms returns the names accepted by Compute and Benchmark, comma-separated
+func Algorithms() string {
+	return strings.Join([]string{"iterative", "recursive", "memo", "matrix", "doubling", "doubling_iter", "memo_fast", "lookup"}, ",")
+}
+
+// Compute calculates F(n) with the algorithm called name (see Algorithms)
+func Compute(name string, n int64) (int64, error) {
+	fn, ok := algorithms[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown algorithm %q", fib.ErrInvalidInput, name)
+	}
+	if name == "recursive" {
+		return Recursive(n)
+	}
+	return compute(n, fn)
+}
+
+// Benchmark runs the algorithm called name iterations times on n and returns the mean time in nanoseconds
+// Timing happens inside Go, so the result excludes the cost of crossing the binding.
+func Benchmark(name string, n, iterations int64) (int64, error) {
+	if _, err := Compute(name, n); err != nil {
+		return 0, err
+	}
+	if iterations <= 0 {
+		return 0, fmt.Errorf("%w: iterations must be positive", fib.ErrInvalidInput)
+	}
+	fn := algorithms[name]
+	var sink uint64
+	start := time.Now()
+	for i := int64(0); i < iterations; i++ {
+		sink += fn(uint64(n))
+	}
+	elapsed := time.Since(start)
+	benchmarkSink = sink
+	return elapsed.Nanoseconds() / iterations, nil
+}
+
+// benchmarkSink keeps the benchmark loop from being optimized away
+var benchmarkSink uint64
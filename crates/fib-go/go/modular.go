@@ -0,0 +1,45 @@
+package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"fmt"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibMod calculates F(n) mod m using modular matrix exponentiation - O(log n)
+// Works for any 64-bit modulus; m == 0 is invalid and yields 0.
+//
+//export FibMod
+func FibMod(n, m C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(tracedUint64("FibMod", "mod", uint64(n), func(n uint64) uint64 { return fib.Mod(n, uint64(m)) }))
+}
+
+// PisanoPeriod returns the period of the Fibonacci sequence modulo m - O(m)
+// The period never exceeds 6m; m == 0 is invalid and yields 0. Moduli above
+// 2^24 are refused: 0 is returned with StatusLimitExceeded.
+//
+//export PisanoPeriod
+func PisanoPeriod(m C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	if m > fib.MaxPisanoModulus {
+		setLastError(C.int32_t(StatusLimitExceeded), fmt.Sprintf("PisanoPeriod: m must be <= %d", fib.MaxPisanoModulus))
+		return 0
+	}
+	return C.uint64_t(fib.PisanoPeriod(uint64(m)))
+}
+
+// FibModFast calculates F(n) mod m after reducing n modulo the Pisano period of m
+// The period is computed once per modulus and cached, so the first call for a given m is O(m).
+// Above m = 2^24 the period search is skipped and F(n) mod m computed as by FibMod.
+//
+//export FibModFast
+func FibModFast(n, m C.uint64_t) C.uint64_t {
+	defer recoverPanic()
+	return C.uint64_t(tracedUint64("FibModFast", "mod_fast", uint64(n), func(n uint64) uint64 { return fib.ModFast(n, uint64(m)) }))
+}
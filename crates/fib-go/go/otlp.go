@@ -0,0 +1,334 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// otlpQueueSize bounds the spans waiting for export; more are dropped
+	otlpQueueSize = 4096
+	// otlpBatchSize is the largest number of spans sent in one request
+	otlpBatchSize = 512
+	// otlpFlushInterval is how long a span at most waits for its batch
+	otlpFlushInterval = time.Second
+	// otlpTimeout bounds one export request
+	otlpTimeout = 5 * time.Second
+	// defaultOTLPService is the service.name of the spans unless otlp_service_name is set
+	defaultOTLPService = "fib-go"
+)
+
+// otlpSpan is one finished compute call
+type otlpSpan struct {
+	name           string
+	algorithm      string
+	n              uint64
+	resultBits     int
+	status         C.fib_status
+	started, ended time.Time
+}
+
+// otlpExporter sends spans to an OTLP/HTTP collector in the JSON encoding
+type otlpExporter struct {
+	endpoint string // as configured, e.g. http://localhost:4318
+	service  string
+	url      string // endpoint + /v1/traces
+	spans    chan otlpSpan
+	quit     chan struct{}
+	done     chan struct{}
+	dropped  atomic.Uint64
+	client   http.Client
+}
+
+// tracer is the exporter installed by the otlp_endpoint config key, nil when tracing is off
+var tracer atomic.Pointer[otlpExporter]
+
+// tracerMu serializes the replacement of tracer
+var tracerMu sync.Mutex
+
+// configureTracing installs an exporter for endpoint, stopping the previous one
+// An empty endpoint turns tracing off. Unchanged settings keep the running exporter.
+func configureTracing(endpoint, service string) {
+	tracerMu.Lock()
+	defer tracerMu.Unlock()
+	old := tracer.Load()
+	if old != nil && old.endpoint == endpoint && old.service == service {
+		return
+	}
+	var e *otlpExporter
+	if endpoint != "" {
+		e = &otlpExporter{
+			endpoint: endpoint,
+			service:  service,
+			url:      strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+			spans:    make(chan otlpSpan, otlpQueueSize),
+			quit:     make(chan struct{}),
+			done:     make(chan struct{}),
+			client:   http.Client{Timeout: otlpTimeout},
+		}
+		go e.run()
+	}
+	tracer.Store(e)
+	if old != nil {
+		old.stop()
+	}
+}
+
+// tracingSettings returns the endpoint and service name in force
+func tracingSettings() (endpoint, service string) {
+	if e := tracer.Load(); e != nil {
+		return e.endpoint, e.service
+	}
+	return "", defaultOTLPService
+}
+
+// validateOTLPEndpoint accepts an empty string or an http(s) URL without query
+func validateOTLPEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" {
+		return fmt.Errorf("want an http(s)://host[:port][/path] URL, got %q", endpoint)
+	}
+	return nil
+}
+
+// computeSpan times one compute call while tracing is on; the nil span records nothing
+type computeSpan struct {
+	e *otlpExporter
+	otlpSpan
+}
+
+// startSpan begins the span of an export computing F(n) with algorithm
+func startSpan(export, algorithm string, n uint64) *computeSpan {
+	e := tracer.Load()
+	if e == nil {
+		return nil
+	}
+	return &computeSpan{e, otlpSpan{name: export, algorithm: algorithm, n: n, started: time.Now()}}
+}
+
+// end queues the span with the bit length of the result and the status of the call
+// The span is dropped if the export queue is full, so tracing never blocks a call.
+func (s *computeSpan) end(resultBits int, status C.fib_status) {
+	if s == nil {
+		return
+	}
+	s.ended, s.resultBits, s.status = time.Now(), resultBits, status
+	select {
+	case s.e.spans <- s.otlpSpan:
+	default:
+		s.e.dropped.Add(1)
+	}
+}
+
+// tracedBig runs fn(n) inside the span of a big-integer export, counting the result for the metrics
+func tracedBig(export, algorithm string, n uint64, fn func(uint64) *big.Int) *big.Int {
+	span := startSpan(export, algorithm, n)
+	z := fn(n)
+	span.end(z.BitLen(), StatusOK)
+	if metricsEnabled.Load() {
+		bigResults.Add(1)
+		bigResultBytes.Add(uint64(z.BitLen()+7) / 8)
+	}
+	return z
+}
+
+// tracedUint64 runs fn(n) inside the span of a uint64 export
+func tracedUint64(export, algorithm string, n uint64, fn func(uint64) uint64) uint64 {
+	span := startSpan(export, algorithm, n)
+	value := fn(n)
+	span.end(bits.Len64(value), StatusOK)
+	return value
+}
+
+// run batches the queued spans until stop
+func (e *otlpExporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+	var batch []otlpSpan
+	for {
+		select {
+		case s := <-e.spans:
+			if batch = append(batch, s); len(batch) >= otlpBatchSize {
+				e.export(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			e.export(batch)
+			batch = batch[:0]
+		case <-e.quit:
+			for {
+				select {
+				case s := <-e.spans:
+					batch = append(batch, s)
+				default:
+					e.export(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// stop flushes the queued spans and ends run
+func (e *otlpExporter) stop() {
+	close(e.quit)
+	<-e.done
+}
+
+// export posts one batch; failures are logged and the batch is dropped
+func (e *otlpExporter) export(batch []otlpSpan) {
+	if dropped := e.dropped.Swap(0); dropped > 0 {
+		logger.Warn("otlp: dropped spans, export queue full", "count", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+	data, err := json.Marshal(e.request(batch))
+	if err == nil {
+		err = e.post(data)
+	}
+	if err != nil {
+		logger.Warn("otlp: export failed", "url", e.url, "spans", len(batch), "error", err)
+	}
+}
+
+// post sends an encoded ExportTraceServiceRequest
+func (e *otlpExporter) post(data []byte) error {
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
+// The types below are the subset of the OTLP JSON encoding the exporter emits.
+// Trace and span ids are hex strings and 64-bit integers decimal strings.
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"` // 1 OK, 2 ERROR
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpanJSON struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"` // 1 INTERNAL
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope      `json:"scope"`
+	Spans []otlpSpanJSON `json:"spans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func stringAttr(key, value string) otlpAttribute {
+	return otlpAttribute{key, otlpValue{StringValue: &value}}
+}
+
+func intAttr(key string, value int64) otlpAttribute {
+	s := strconv.FormatInt(value, 10)
+	return otlpAttribute{key, otlpValue{IntValue: &s}}
+}
+
+// randomID returns size random bytes in hex
+func randomID(size int) string {
+	var b [16]byte
+	for i := 0; i < size; i += 8 {
+		v := rand.Uint64()
+		for j := 0; j < 8 && i+j < size; j++ {
+			b[i+j] = byte(v >> (8 * j))
+		}
+	}
+	return hex.EncodeToString(b[:size])
+}
+
+// request encodes a batch; every span is the root of its own trace
+func (e *otlpExporter) request(batch []otlpSpan) otlpRequest {
+	spans := make([]otlpSpanJSON, len(batch))
+	for i, s := range batch {
+		status := otlpStatus{Code: 1}
+		if s.status != StatusOK {
+			status = otlpStatus{Code: 2, Message: statusText(s.status)}
+		}
+		spans[i] = otlpSpanJSON{
+			TraceID:           randomID(16),
+			SpanID:            randomID(8),
+			Name:              s.name,
+			Kind:              1,
+			StartTimeUnixNano: strconv.FormatInt(s.started.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.ended.UnixNano(), 10),
+			Attributes: []otlpAttribute{
+				stringAttr("fib.algorithm", s.algorithm),
+				intAttr("fib.n", int64(s.n)),
+				intAttr("fib.result_bits", int64(s.resultBits)),
+				intAttr("fib.status", int64(s.status)),
+			},
+			Status: status,
+		}
+	}
+	return otlpRequest{[]otlpResourceSpans{{
+		Resource:   otlpResource{[]otlpAttribute{stringAttr("service.name", e.service)}},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "fib-go"}, Spans: spans}},
+	}}}
+}
@@ -0,0 +1,32 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+*/
+import "C"
+
+import "unsafe"
+
+// These exports only cross the FFI boundary, so the harness can measure (and
+// subtract) the bare cgo call cost from algorithm timings. They cannot panic and
+// deliberately skip the recover defer the other exports install.
+
+// Noop does nothing - O(1)
+//
+//export Noop
+func Noop() {}
+
+// EchoU64 returns x unchanged - O(1)
+//
+//export EchoU64
+func EchoU64(x C.uint64_t) C.uint64_t {
+	return x
+}
+
+// EchoBuffer receives a buffer without reading it and returns its length - O(1)
+//
+//export EchoBuffer
+func EchoBuffer(ptr unsafe.Pointer, length C.size_t) C.size_t {
+	return length
+}
@@ -0,0 +1,44 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// FibPair stores F(n) and F(n+1) from a single doubling computation - O(log n)
+// Returns StatusOverflow when F(n+1) does not fit in a uint64 (n > 92).
+//
+//export FibPair
+func FibPair(n C.uint64_t, fN, fN1 *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if fN == nil || fN1 == nil {
+		return StatusInvalidArg
+	}
+	if uint64(n) >= fib.MaxSafeN {
+		return StatusOverflow
+	}
+	pair := fib.Pair(uint64(n))
+	*fN, *fN1 = C.uint64_t(pair[0]), C.uint64_t(pair[1])
+	return StatusOK
+}
+
+// FibBigPair stores handles to F(n) and F(n+1) from a single big-integer doubling computation
+// Both handles must be released with BigFree.
+//
+//export FibBigPair
+func FibBigPair(n C.uint64_t, fN, fN1 *C.uintptr_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if fN == nil || fN1 == nil {
+		return StatusInvalidArg
+	}
+	fk, fk1 := fib.BigPair(uint64(n))
+	*fN, *fN1 = C.uintptr_t(cgo.NewHandle(fk)), C.uintptr_t(cgo.NewHandle(fk1))
+	return StatusOK
+}
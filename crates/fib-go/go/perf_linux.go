@@ -0,0 +1,127 @@
+//go:build linux
+
+package main
+
+/*
+#include <errno.h>
+#include <stdint.h>
+#include <string.h>
+#include <linux/perf_event.h>
+#include <sys/ioctl.h>
+#include <sys/syscall.h>
+#include <unistd.h>
+
+// fib_perf_open opens a user-space counter of the calling thread; group is -1 for a leader.
+// Returns the descriptor or -errno.
+static int fib_perf_open(uint32_t type, uint64_t config, int group) {
+	struct perf_event_attr attr;
+	memset(&attr, 0, sizeof attr);
+	attr.size = sizeof attr;
+	attr.type = type;
+	attr.config = config;
+	attr.disabled = group < 0;
+	attr.exclude_kernel = 1;
+	attr.exclude_hv = 1;
+	int fd = (int)syscall(__NR_perf_event_open, &attr, 0, -1, group, 0);
+	return fd < 0 ? -errno : fd;
+}
+
+// fib_perf_ctl resets and enables (enable != 0) or disables a counter group
+static int fib_perf_ctl(int leader, int enable) {
+	if (enable) {
+		if (ioctl(leader, PERF_EVENT_IOC_RESET, PERF_IOC_FLAG_GROUP) != 0) {
+			return -errno;
+		}
+		return ioctl(leader, PERF_EVENT_IOC_ENABLE, PERF_IOC_FLAG_GROUP) != 0 ? -errno : 0;
+	}
+	return ioctl(leader, PERF_EVENT_IOC_DISABLE, PERF_IOC_FLAG_GROUP) != 0 ? -errno : 0;
+}
+
+// fib_perf_read returns the value of a counter, or -errno
+static int64_t fib_perf_read(int fd) {
+	uint64_t value;
+	if (read(fd, &value, sizeof value) != sizeof value) {
+		return -errno;
+	}
+	return (int64_t)value;
+}
+
+static void fib_perf_close(int fd) {
+	close(fd);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// perfEvents are the hardware events of a perfSession, in perfCounts order
+var perfEvents = [...]struct {
+	name   string
+	config C.uint64_t
+}{
+	{"instructions", C.PERF_COUNT_HW_INSTRUCTIONS},
+	{"cycles", C.PERF_COUNT_HW_CPU_CYCLES},
+	{"branch-misses", C.PERF_COUNT_HW_BRANCH_MISSES},
+	{"cache-misses", C.PERF_COUNT_HW_CACHE_MISSES},
+}
+
+// perfSession is a group of perf_event_open counters attached to the calling OS thread
+type perfSession struct {
+	fds [len(perfEvents)]C.int
+}
+
+// startPerf opens and starts the counters; the caller must hold runtime.LockOSThread
+// It fails where perf_event_open is refused, e.g. under perf_event_paranoid > 2 or in
+// containers without CAP_PERFMON.
+func startPerf() (*perfSession, error) {
+	p := &perfSession{}
+	for i := range p.fds {
+		p.fds[i] = -1
+	}
+	for i, ev := range perfEvents {
+		group := C.int(-1)
+		if i > 0 {
+			group = p.fds[0]
+		}
+		fd := C.fib_perf_open(C.PERF_TYPE_HARDWARE, ev.config, group)
+		if fd < 0 {
+			p.close()
+			return nil, fmt.Errorf("perf_event_open %s: %w", ev.name, syscall.Errno(-fd))
+		}
+		p.fds[i] = fd
+	}
+	if rc := C.fib_perf_ctl(p.fds[0], 1); rc != 0 {
+		p.close()
+		return nil, fmt.Errorf("perf enable: %w", syscall.Errno(-rc))
+	}
+	return p, nil
+}
+
+// stop disables the counters, reads them and releases the session
+func (p *perfSession) stop() (perfCounts, error) {
+	defer p.close()
+	var counts perfCounts
+	if rc := C.fib_perf_ctl(p.fds[0], 0); rc != 0 {
+		return counts, fmt.Errorf("perf disable: %w", syscall.Errno(-rc))
+	}
+	for i, fd := range p.fds {
+		v := C.fib_perf_read(fd)
+		if v < 0 {
+			return counts, fmt.Errorf("perf read %s: %w", perfEvents[i].name, syscall.Errno(-v))
+		}
+		counts[i] = uint64(v)
+	}
+	return counts, nil
+}
+
+func (p *perfSession) close() {
+	for i, fd := range p.fds {
+		if fd >= 0 {
+			C.fib_perf_close(fd)
+			p.fds[i] = -1
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+// perfSession is unavailable off Linux (see perf_linux.go)
+type perfSession struct{}
+
+func startPerf() (*perfSession, error) {
+	return nil, errors.New("hardware performance counters need Linux perf_event_open")
+}
+
+func (p *perfSession) stop() (perfCounts, error) {
+	return perfCounts{}, errors.New("hardware performance counters need Linux perf_event_open")
+}
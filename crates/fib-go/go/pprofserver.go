@@ -0,0 +1,93 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+)
+
+// pprofServer is the net/http/pprof endpoint started by EnablePprofServer
+var pprofServer struct {
+	sync.Mutex
+	srv *http.Server
+}
+
+// EnablePprofServer serves net/http/pprof on addr, a loopback "host:port", until DisablePprofServer
+// The handlers live under /debug/pprof/ on a private mux, so the host's
+// http.DefaultServeMux is untouched; e.g. `go tool pprof
+// http://127.0.0.1:6060/debug/pprof/profile?seconds=30`. Requires the profiling
+// config key. Returns StatusInvalidArg for a NULL, malformed or non-loopback
+// addr, StatusInvalidState if profiling is disabled or a server is already
+// running and StatusInternal if addr cannot be listened on.
+//
+//export EnablePprofServer
+func EnablePprofServer(addr *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if addr == nil {
+		return StatusInvalidArg
+	}
+	address := C.GoString(addr)
+	if err := checkLoopback(address); err != nil {
+		return failWith(StatusInvalidArg, "pprof server: %v", err)
+	}
+	if status := profilingAllowed("EnablePprofServer"); status != StatusOK {
+		return status
+	}
+	pprofServer.Lock()
+	defer pprofServer.Unlock()
+	if pprofServer.srv != nil {
+		return failWith(StatusInvalidState, "EnablePprofServer: already serving on %s", pprofServer.srv.Addr)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	srv, err := startHTTPServer("pprof", address, mux)
+	if err != nil {
+		return failWith(StatusInternal, "pprof server: %v", err)
+	}
+	pprofServer.srv = srv
+	return StatusOK
+}
+
+// DisablePprofServer stops the server started by EnablePprofServer
+// Running requests, such as a CPU profile being collected, get a few seconds to
+// finish. Returns StatusInvalidState if no server is running.
+//
+//export DisablePprofServer
+func DisablePprofServer() (status C.fib_status) {
+	defer recoverStatus(&status)
+	pprofServer.Lock()
+	defer pprofServer.Unlock()
+	if pprofServer.srv == nil {
+		return failWith(StatusInvalidState, "DisablePprofServer: no server is running")
+	}
+	stopHTTPServer(pprofServer.srv)
+	pprofServer.srv = nil
+	return StatusOK
+}
+
+// checkLoopback rejects addresses reachable from other machines
+func checkLoopback(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return errors.New("address must be localhost or a loopback IP, got " + address)
+	}
+	return nil
+}
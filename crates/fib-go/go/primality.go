@@ -0,0 +1,121 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// selfridgeJSON are the Lucas parameters picked for n
+type selfridgeJSON struct {
+	D int64 `json:"d"`
+	P int64 `json:"p"`
+	Q int64 `json:"q"`
+}
+
+// primalityWitness is the FibonacciPrimalityWitness document
+type primalityWitness struct {
+	N                 uint64         `json:"n"`
+	Jacobi5           int            `json:"jacobi_5"`
+	FibResidue        uint64         `json:"fibonacci_residue"`   // F(n - jacobi_5) mod n
+	FibNResidue       uint64         `json:"fibonacci_n_residue"` // F(n) mod n
+	FibonacciPRP      bool           `json:"fibonacci_probable_prime"`
+	Selfridge         *selfridgeJSON `json:"selfridge"`
+	StrongLucasPRP    bool           `json:"strong_lucas_probable_prime"`
+	StrongFermatBase2 bool           `json:"strong_fermat_base2"`
+	BailliePSW        bool           `json:"baillie_psw"`
+	Witness           string         `json:"witness"` // the first check proving n composite, "" if none
+}
+
+// primalityWitnessGo runs the Fibonacci, strong Lucas and base-2 checks on n
+func primalityWitnessGo(n uint64) primalityWitness {
+	w := primalityWitness{N: n}
+	switch {
+	case n < 2:
+		w.Witness = "n < 2 is not prime"
+		return w
+	case n == 2:
+		w.FibonacciPRP, w.StrongLucasPRP, w.StrongFermatBase2, w.BailliePSW = true, true, true, true
+		return w
+	case n%2 == 0:
+		w.Witness = "n is even"
+		return w
+	}
+
+	var fnMinusE, fn uint64
+	w.Jacobi5, fnMinusE, fn = fib.FibonacciResidues(n)
+	w.FibResidue, w.FibNResidue = fnMinusE, fn
+	// (n/5) mod n
+	var wantFn uint64
+	switch w.Jacobi5 {
+	case 1:
+		wantFn = 1
+	case -1:
+		wantFn = n - 1
+	}
+	w.FibonacciPRP = fnMinusE == 0 && fn == wantFn
+	if d, p, q, ok := fib.SelfridgeParameters(n); ok {
+		w.Selfridge = &selfridgeJSON{d, p, q}
+	}
+	w.StrongLucasPRP = fib.IsStrongLucasProbablePrime(n)
+	w.StrongFermatBase2 = fib.IsStrongFermatProbablePrime2(n)
+	w.BailliePSW = w.StrongLucasPRP && w.StrongFermatBase2
+
+	switch {
+	case w.Jacobi5 == 0 && n != 5:
+		w.Witness = "n is divisible by 5"
+	case fnMinusE != 0:
+		w.Witness = fmt.Sprintf("F(n - (%d)) mod n = %d, a prime gives 0", w.Jacobi5, fnMinusE)
+	case fn != wantFn:
+		w.Witness = fmt.Sprintf("F(n) mod n = %d, a prime gives (n/5) mod n = %d", fn, wantFn)
+	case w.Selfridge == nil:
+		w.Witness = "no Selfridge parameter exists: n is a square or shares a factor with a candidate D"
+	case !w.StrongLucasPRP:
+		w.Witness = fmt.Sprintf("strong Lucas test with D = %d, P = 1, Q = %d fails", w.Selfridge.D, w.Selfridge.Q)
+	case !w.StrongFermatBase2:
+		w.Witness = "strong Fermat test to base 2 fails"
+	}
+	return w
+}
+
+// IsLucasProbablePrime returns 1 if n passes the strong Lucas probable prime test, 0 otherwise
+// The test uses Selfridge's parameters (the first D of 5, -7, 9, ... with
+// (D/n) = -1, P = 1, Q = (1 - D)/4) and Lucas sequences modulo n, the Lucas half of
+// Baillie-PSW. Every prime passes; the rare composites that pass too are strong
+// Lucas pseudoprimes (5459, 5777, ...).
+//
+//export IsLucasProbablePrime
+func IsLucasProbablePrime(n C.uint64_t) C.int32_t {
+	defer recoverPanic()
+	if fib.IsStrongLucasProbablePrime(uint64(n)) {
+		return 1
+	}
+	return 0
+}
+
+// FibonacciPrimalityWitness runs the Fibonacci and Lucas probable-prime checks of Baillie-PSW on n
+// Returns {"n", "jacobi_5", "fibonacci_residue", "fibonacci_n_residue",
+// "fibonacci_probable_prime", "selfridge": {"d", "p", "q"}, "strong_lucas_probable_prime",
+// "strong_fermat_base2", "baillie_psw", "witness"}: a prime n has
+// F(n - (n/5)) = 0 and F(n) = (n/5) mod n, and witness names the first check
+// proving n composite, or is empty. baillie_psw combines the strong Lucas and
+// base-2 tests and is exact for every 64-bit n. The string is owned by the caller
+// and must be released with FreeCString.
+//
+//export FibonacciPrimalityWitness
+func FibonacciPrimalityWitness(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	data, err := json.Marshal(primalityWitnessGo(uint64(n)))
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return nil
+	}
+	return C.CString(string(data))
+}
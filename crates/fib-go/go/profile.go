@@ -0,0 +1,114 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+)
+
+// profilingEnabled allows the pprof exports, set by the profiling config key
+var profilingEnabled atomic.Bool
+
+// cpuProfile is the pprof CPU profile started by StartCPUProfile
+var cpuProfile struct {
+	sync.Mutex
+	file *os.File
+}
+
+// profilingAllowed returns StatusInvalidState unless the profiling config key is set
+func profilingAllowed(name string) C.fib_status {
+	if !profilingEnabled.Load() {
+		return failWith(StatusInvalidState, "%s: profiling is disabled, set the profiling config key", name)
+	}
+	return StatusOK
+}
+
+// StartCPUProfile begins writing a pprof CPU profile to the file at path, replacing it
+// Samples cover the Go code of the library until StopCPUProfile; open the file
+// with `go tool pprof`. Requires the profiling config key. Returns
+// StatusInvalidArg for a NULL path, StatusInvalidState if profiling is disabled
+// or a CPU profile is already running and StatusInternal if the file cannot be created.
+//
+//export StartCPUProfile
+func StartCPUProfile(path *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if path == nil {
+		return StatusInvalidArg
+	}
+	if status := profilingAllowed("StartCPUProfile"); status != StatusOK {
+		return status
+	}
+	cpuProfile.Lock()
+	defer cpuProfile.Unlock()
+	if cpuProfile.file != nil {
+		return failWith(StatusInvalidState, "StartCPUProfile: a CPU profile is already running")
+	}
+	f, err := os.Create(C.GoString(path))
+	if err != nil {
+		return failWith(StatusInternal, "cpu profile: %v", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return failWith(StatusInvalidState, "cpu profile: %v", err)
+	}
+	cpuProfile.file = f
+	return StatusOK
+}
+
+// StopCPUProfile ends the profile started by StartCPUProfile and closes its file
+// Returns StatusInvalidState if no CPU profile is running and StatusInternal if
+// the file could not be written completely.
+//
+//export StopCPUProfile
+func StopCPUProfile() (status C.fib_status) {
+	defer recoverStatus(&status)
+	cpuProfile.Lock()
+	defer cpuProfile.Unlock()
+	if cpuProfile.file == nil {
+		return failWith(StatusInvalidState, "StopCPUProfile: no CPU profile is running")
+	}
+	pprof.StopCPUProfile()
+	err := cpuProfile.file.Close()
+	cpuProfile.file = nil
+	if err != nil {
+		return failWith(StatusInternal, "cpu profile: %v", err)
+	}
+	return StatusOK
+}
+
+// WriteHeapProfile writes a pprof heap profile of the live objects to the file at path
+// A GC cycle runs first so the profile is up to date, e.g. to see what the FibMemo
+// map retains. Requires the profiling config key. Returns StatusInvalidArg for a
+// NULL path, StatusInvalidState if profiling is disabled and StatusInternal for I/O errors.
+//
+//export WriteHeapProfile
+func WriteHeapProfile(path *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if path == nil {
+		return StatusInvalidArg
+	}
+	if status := profilingAllowed("WriteHeapProfile"); status != StatusOK {
+		return status
+	}
+	f, err := os.Create(C.GoString(path))
+	if err != nil {
+		return failWith(StatusInternal, "heap profile: %v", err)
+	}
+	runtime.GC()
+	err = pprof.WriteHeapProfile(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return failWith(StatusInternal, "heap profile: %v", err)
+	}
+	return StatusOK
+}
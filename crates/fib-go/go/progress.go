@@ -0,0 +1,65 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// progressHook is a callback registered with SetProgressCallback
+type progressHook struct {
+	fn       C.fib_progress_fn
+	userdata unsafe.Pointer
+	interval time.Duration
+}
+
+// progressCallback is the registered hook, nil when none
+var progressCallback atomic.Pointer[progressHook]
+
+// SetProgressCallback registers fn to report the progress of big-integer doubling
+// fn receives (bits_processed, total_bits, userdata) on the calling thread of
+// FibBigCompute, FibBigComputeWithCancel, FibBigDoubling, FibDecimalString and
+// their *Buf twins, at most once per intervalMs milliseconds plus once at the end.
+// Pass NULL to unregister. fn must not call back into the library.
+//
+//export SetProgressCallback
+func SetProgressCallback(fn C.fib_progress_fn, userdata unsafe.Pointer, intervalMs C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if fn == nil {
+		progressCallback.Store(nil)
+		return StatusOK
+	}
+	progressCallback.Store(&progressHook{fn, userdata, millis(uint64(intervalMs))})
+	return StatusOK
+}
+
+// bigDoublingGo calculates F(n) by big-integer doubling, reporting to the progress callback if one is registered
+func bigDoublingGo(ctx context.Context, n uint64) (*big.Int, error) {
+	hook := progressCallback.Load()
+	if hook == nil {
+		return fib.BigDoublingContext(ctx, n)
+	}
+	var last time.Time
+	return fib.BigDoublingProgress(ctx, n, func(done, total int) {
+		if now := time.Now(); done == total || now.Sub(last) >= hook.interval {
+			last = now
+			callProgressFn(hook.fn, done, total, hook.userdata)
+		}
+	})
+}
+
+// bigDoublingReported is bigDoublingGo for the exports without a cancel token
+func bigDoublingReported(n uint64) *big.Int {
+	x, _ := bigDoublingGo(context.Background(), n)
+	return x
+}
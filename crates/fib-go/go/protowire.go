@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Minimal Protocol Buffers wire format, enough for the messages of proto/fib/v1/fib.proto
+// without depending on a protobuf runtime. Zero values are omitted, like proto3 does.
+
+// Wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// errTruncated reports a message cut short
+var errTruncated = errors.New("protobuf: truncated message")
+
+func appendTag(b []byte, field, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wireType))
+}
+
+// appendUint appends a varint field (uint64, int64, int32, bool)
+func appendUint(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	return binary.AppendUvarint(appendTag(b, field, wireVarint), v)
+}
+
+// appendInt appends an int32 or int64 field; negative values take ten bytes, as in protobuf
+func appendInt(b []byte, field int, v int64) []byte {
+	return appendUint(b, field, uint64(v))
+}
+
+func appendDouble(b []byte, field int, v float64) []byte {
+	if v == 0 {
+		return b
+	}
+	return binary.LittleEndian.AppendUint64(appendTag(b, field, wireFixed64), math.Float64bits(v))
+}
+
+// appendBytes appends a string, bytes or embedded message field
+func appendBytes(b []byte, field int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	return appendRawBytes(b, field, v)
+}
+
+// appendRawBytes appends a length-delimited field even when empty, as repeated message elements need
+func appendRawBytes(b []byte, field int, v []byte) []byte {
+	b = binary.AppendUvarint(appendTag(b, field, wireBytes), uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendString(b []byte, field int, v string) []byte {
+	return appendBytes(b, field, []byte(v))
+}
+
+// appendPackedUints appends a packed repeated varint field
+func appendPackedUints(b []byte, field int, vs []uint64) []byte {
+	if len(vs) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range vs {
+		packed = binary.AppendUvarint(packed, v)
+	}
+	return appendRawBytes(b, field, packed)
+}
+
+// protoField is one decoded field: v holds varint and fixed values, data length-delimited ones
+type protoField struct {
+	num      int
+	wireType int
+	v        uint64
+	data     []byte
+}
+
+// parseProto calls fn for every field of a message in order
+func parseProto(b []byte, fn func(f protoField) error) error {
+	for len(b) > 0 {
+		tag, k := binary.Uvarint(b)
+		if k <= 0 {
+			return errTruncated
+		}
+		b = b[k:]
+		f := protoField{num: int(tag >> 3), wireType: int(tag & 7)}
+		switch f.wireType {
+		case wireVarint:
+			if f.v, k = binary.Uvarint(b); k <= 0 {
+				return errTruncated
+			}
+			b = b[k:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return errTruncated
+			}
+			f.v, b = binary.LittleEndian.Uint64(b), b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return errTruncated
+			}
+			f.v, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		case wireBytes:
+			size, k := binary.Uvarint(b)
+			if k <= 0 || size > uint64(len(b)-k) {
+				return errTruncated
+			}
+			f.data, b = b[k:k+int(size)], b[k+int(size):]
+		default:
+			return fmt.Errorf("protobuf: unsupported wire type %d", f.wireType)
+		}
+		if f.num == 0 {
+			return errors.New("protobuf: field number 0")
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uints decodes a repeated varint field, packed or not, appending to dst
+func (f protoField) uints(dst []uint64) ([]uint64, error) {
+	switch f.wireType {
+	case wireVarint:
+		return append(dst, f.v), nil
+	case wireBytes:
+		for b := f.data; len(b) > 0; {
+			v, k := binary.Uvarint(b)
+			if k <= 0 {
+				return dst, errTruncated
+			}
+			dst, b = append(dst, v), b[k:]
+		}
+		return dst, nil
+	}
+	return dst, fmt.Errorf("protobuf: field %d: wire type %d is not a varint", f.num, f.wireType)
+}
+
+// uint checks that f is a varint
+func (f protoField) uint() (uint64, error) {
+	if f.wireType != wireVarint {
+		return 0, fmt.Errorf("protobuf: field %d: wire type %d is not a varint", f.num, f.wireType)
+	}
+	return f.v, nil
+}
+
+// str checks that f is length-delimited
+func (f protoField) str() (string, error) {
+	if f.wireType != wireBytes {
+		return "", fmt.Errorf("protobuf: field %d: wire type %d is not length-delimited", f.num, f.wireType)
+	}
+	return string(f.data), nil
+}
@@ -0,0 +1,307 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// publishAttempts is the number of tries of one upload before it is queued
+	publishAttempts = 3
+	// publishBackoff is the wait before the second try, doubled before each later one
+	publishBackoff = 500 * time.Millisecond
+	// publishTimeout bounds one HTTP request to the collector
+	publishTimeout = 10 * time.Second
+	// maxQueuedResults bounds the offline queue; PublishResults refuses to queue past it
+	maxQueuedResults = 1000
+)
+
+// publishEnvelope is the JSON body POSTed to the collector
+type publishEnvelope struct {
+	Schema      string          `json:"schema"`
+	PublishedAt time.Time       `json:"published_at"`
+	Host        hostFingerprint `json:"host"`
+	Build       BuildInfo       `json:"build"`
+	Report      json.RawMessage `json:"report"`
+}
+
+// queuedResult is one line of the offline queue, an upload waiting for its collector
+type queuedResult struct {
+	URL            string          `json:"url"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	QueuedAt       time.Time       `json:"queued_at"`
+	Body           json.RawMessage `json:"body"`
+}
+
+// publisher holds the publish_queue setting and serializes the queue accesses of this process
+// The queue never stores auth tokens: queued uploads are sent with the token of
+// the next PublishResults call for the same URL.
+var publisher struct {
+	sync.Mutex
+	queuePath string
+}
+
+func init() {
+	if dir, err := os.UserCacheDir(); err == nil {
+		publisher.queuePath = filepath.Join(dir, "fib-go", "publish-queue.ndjson")
+	}
+}
+
+// publishQueuePath returns the publish_queue setting
+func publishQueuePath() string {
+	publisher.Lock()
+	defer publisher.Unlock()
+	return publisher.queuePath
+}
+
+// setPublishQueuePath installs the publish_queue setting; "" disables offline queueing
+func setPublishQueuePath(path string) {
+	publisher.Lock()
+	defer publisher.Unlock()
+	publisher.queuePath = path
+}
+
+// errRefused is a collector answer that retrying cannot fix, such as 400 or 401
+var errRefused = errors.New("refused by the collector")
+
+// validatePublishURL accepts an http(s) URL with a host
+func validatePublishURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("want an http(s):// URL, got %q", raw)
+	}
+	return nil
+}
+
+// newIdempotencyKey returns a random key letting the collector drop the duplicates of retried uploads
+func newIdempotencyKey() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// postResult sends one upload, retrying network errors, 429 and 5xx answers with exponential backoff
+func postResult(target, token, key string, body []byte) error {
+	client := &http.Client{Timeout: publishTimeout}
+	backoff := publishBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = postOnce(client, target, token, key, body)
+		if err == nil || errors.Is(err, errRefused) || attempt == publishAttempts {
+			return err
+		}
+		logger.Debug("publish failed, retrying", "url", target, "attempt", attempt, "error", err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func postOnce(client *http.Client, target, token, key string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	abi := GetABIVersion()
+	req.Header.Set("User-Agent", fmt.Sprintf("fib-go/%d.%d", abi>>16, abi&0xffff))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("collector answered %s", resp.Status)
+	}
+	return fmt.Errorf("%w: %s", errRefused, resp.Status)
+}
+
+// readQueue loads the offline queue, a missing file being empty
+func readQueue(path string) ([]queuedResult, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var queue []queuedResult
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<30)
+	for sc.Scan() {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var q queuedResult
+		if err := json.Unmarshal(sc.Bytes(), &q); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		queue = append(queue, q)
+	}
+	return queue, sc.Err()
+}
+
+// writeQueue replaces the offline queue atomically, removing the file when empty
+func writeQueue(path string, queue []queuedResult) error {
+	if len(queue) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, q := range queue {
+		if err := enc.Encode(q); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// publishResultsGo uploads report to target, first flushing the uploads queued for it
+// An upload that still fails is appended to the queue unless the collector refused
+// it or queueing is disabled. pending is the number of uploads left in the queue.
+func publishResultsGo(target, token string, report []byte) (pending int, err error) {
+	envelope := publishEnvelope{
+		Schema:      "fib-go/run/v1",
+		PublishedAt: time.Now().UTC(),
+		Host:        hostFingerprintGo(),
+		Build:       collectBuildInfo(),
+		Report:      report,
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return 0, err
+	}
+
+	publisher.Lock()
+	defer publisher.Unlock()
+	path := publisher.queuePath
+	var queue []queuedResult
+	if path != "" {
+		if queue, err = readQueue(path); err != nil {
+			return 0, err
+		}
+	}
+	// Older uploads go first so the collector receives runs in order
+	kept := queue[:0]
+	for i, q := range queue {
+		if q.URL != target {
+			kept = append(kept, q)
+			continue
+		}
+		if err := postResult(target, token, q.IdempotencyKey, q.Body); err != nil && !errors.Is(err, errRefused) {
+			// The collector is still unreachable: keep this and the remaining uploads
+			kept = append(kept, queue[i:]...)
+			break
+		} else if err != nil {
+			logger.Warn("collector refused a queued result, dropping it", "url", target, "error", err)
+		}
+	}
+	queue = kept
+
+	key := newIdempotencyKey()
+	sendErr := postResult(target, token, key, body)
+	if sendErr != nil && !errors.Is(sendErr, errRefused) && path != "" {
+		if len(queue) >= maxQueuedResults {
+			sendErr = fmt.Errorf("%w; the offline queue is full (%d results)", sendErr, maxQueuedResults)
+		} else {
+			queue = append(queue, queuedResult{target, key, envelope.PublishedAt, body})
+			logger.Info("collector unreachable, result queued", "url", target, "queue", path, "error", sendErr)
+			sendErr = nil
+		}
+	}
+	if path != "" {
+		if err := writeQueue(path, queue); err != nil {
+			return len(queue), err
+		}
+	}
+	return len(queue), sendErr
+}
+
+// PublishResults POSTs a run's JSON report to a benchmark collector at url
+// The body is {"schema", "published_at", "host", "build", "report"} with the
+// GetHostFingerprint and GetBuildInfo documents; authToken, if neither NULL nor
+// empty, is sent as a bearer token and every upload carries an Idempotency-Key.
+// Network errors, 429 and 5xx answers are retried with backoff, and an upload
+// still failing is kept in the offline queue (publish_queue config key), to be
+// sent first by the next call for the same url. *pending, if not NULL,
+// receives the number of uploads left in the queue. Returns StatusOK once the
+// report is delivered or queued, StatusInvalidArg for a bad url or report or an
+// upload the collector refused (4xx), StatusLimitExceeded if the queue is full
+// and StatusInternal if the collector is unreachable with queueing disabled or
+// the queue cannot be written.
+//
+//export PublishResults
+func PublishResults(url, authToken, reportJSON *C.char, pending *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if url == nil || reportJSON == nil {
+		return StatusInvalidArg
+	}
+	target := C.GoString(url)
+	if err := validatePublishURL(target); err != nil {
+		return failWith(StatusInvalidArg, "PublishResults: %v", err)
+	}
+	report := []byte(C.GoString(reportJSON))
+	if !json.Valid(report) {
+		return failWith(StatusInvalidArg, "PublishResults: the report is not valid JSON")
+	}
+	var compact bytes.Buffer
+	json.Compact(&compact, report)
+	var token string
+	if authToken != nil {
+		token = C.GoString(authToken)
+	}
+
+	n, err := publishResultsGo(target, token, compact.Bytes())
+	if pending != nil {
+		*pending = C.uint64_t(n)
+	}
+	switch {
+	case err == nil:
+		return StatusOK
+	case errors.Is(err, errRefused):
+		return failWith(StatusInvalidArg, "PublishResults: %v", err)
+	case n >= maxQueuedResults:
+		return failWith(StatusLimitExceeded, "PublishResults: %v", err)
+	}
+	return failWith(StatusInternal, "PublishResults: %v", err)
+}
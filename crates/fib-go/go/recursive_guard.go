@@ -0,0 +1,67 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"sync/atomic"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// Behaviors of FibRecursive above the recursion cutoff
+const (
+	RecursiveModeFallback C.int32_t = C.FIB_RECURSIVE_FALLBACK // transparently compute with FibMemo
+	RecursiveModeError    C.int32_t = C.FIB_RECURSIVE_ERROR    // refuse with StatusLimitExceeded
+)
+
+// defaultRecursiveMaxN keeps naive recursion under about a second on current CPUs
+const defaultRecursiveMaxN = 40
+
+var (
+	recursiveMaxN atomic.Uint64
+	recursiveMode atomic.Int32
+)
+
+func init() {
+	recursiveMaxN.Store(defaultRecursiveMaxN)
+	recursiveMode.Store(int32(RecursiveModeFallback))
+}
+
+// SetRecursiveMaxN sets the largest n computed by naive recursion and what happens above it
+// mode is RecursiveModeFallback or RecursiveModeError; pass UINT64_MAX to disable the cutoff.
+//
+//export SetRecursiveMaxN
+func SetRecursiveMaxN(maxN C.uint64_t, mode C.int32_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if mode != RecursiveModeFallback && mode != RecursiveModeError {
+		return StatusInvalidArg
+	}
+	recursiveMaxN.Store(uint64(maxN))
+	recursiveMode.Store(int32(mode))
+	return StatusOK
+}
+
+// fibRecursiveSafe applies the recursion cutoff before calling fib.Recursive
+func fibRecursiveSafe(n uint64) (uint64, C.fib_status) {
+	maxN := recursiveMaxN.Load()
+	if n <= maxN {
+		return fib.Recursive(n), StatusOK
+	}
+	if C.int32_t(recursiveMode.Load()) == RecursiveModeError {
+		return 0, StatusLimitExceeded
+	}
+	if debugEnabled() {
+		logger.Debug("recursion cutoff: computing with memo instead", "n", n, "recursive_max_n", maxN)
+	}
+	return fib.Memo(n), StatusOK
+}
+
+// fibRecursiveGuardedGo is fibRecursiveSafe for callers without a status channel; refused calls yield 0
+func fibRecursiveGuardedGo(n uint64) uint64 {
+	value, _ := fibRecursiveSafe(n)
+	return value
+}
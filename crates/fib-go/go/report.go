@@ -0,0 +1,424 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxReportRuns bounds the runs compared side by side; the runs table lists them all
+const maxReportRuns = 8
+
+// reportRun is one stored run of a report, its repetitions pooled per cell
+type reportRun struct {
+	label string
+	run   storedRun
+	cells map[cellKey]cellStats
+}
+
+// reportWriter renders the blocks of a report in one output format
+type reportWriter interface {
+	heading(level int, text string)
+	paragraph(text string)
+	table(header []string, rows [][]string)
+	chart(title, svg string)
+	String() string
+}
+
+// markdownWriter renders GitHub-flavored Markdown; charts are SVG data URIs
+type markdownWriter struct{ strings.Builder }
+
+func (w *markdownWriter) heading(level int, text string) {
+	fmt.Fprintf(w, "%s %s\n\n", strings.Repeat("#", level), text)
+}
+
+func (w *markdownWriter) paragraph(text string) {
+	fmt.Fprintf(w, "%s\n\n", text)
+}
+
+func (w *markdownWriter) table(header []string, rows [][]string) {
+	cell := func(s string) string { return strings.ReplaceAll(s, "|", `\|`) }
+	line := func(cells []string) {
+		w.WriteString("|")
+		for _, c := range cells {
+			fmt.Fprintf(w, " %s |", cell(c))
+		}
+		w.WriteString("\n")
+	}
+	line(header)
+	w.WriteString("|")
+	for i := range header {
+		if numericColumn(rows, i) {
+			w.WriteString(" ---: |")
+		} else {
+			w.WriteString(" --- |")
+		}
+	}
+	w.WriteString("\n")
+	for _, row := range rows {
+		line(row)
+	}
+	w.WriteString("\n")
+}
+
+func (w *markdownWriter) chart(title, svg string) {
+	fmt.Fprintf(w, "![%s](data:image/svg+xml;base64,%s)\n\n", title, base64.StdEncoding.EncodeToString([]byte(svg)))
+}
+
+// htmlWriter renders a self-contained HTML page with inline SVG charts
+type htmlWriter struct{ strings.Builder }
+
+// reportStyle is the stylesheet of the HTML reports
+const reportStyle = `body{font-family:sans-serif;margin:2em auto;max-width:60em;color:#222}
+table{border-collapse:collapse;margin:1em 0}th,td{border:1px solid #ccc;padding:.3em .6em}
+td.num{text-align:right;font-variant-numeric:tabular-nums}th{background:#f4f4f4}`
+
+func newHTMLWriter(title string) *htmlWriter {
+	w := &htmlWriter{}
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n",
+		html.EscapeString(title), reportStyle)
+	return w
+}
+
+func (w *htmlWriter) heading(level int, text string) {
+	fmt.Fprintf(w, "<h%d>%s</h%d>\n", level, html.EscapeString(text), level)
+}
+
+func (w *htmlWriter) paragraph(text string) {
+	fmt.Fprintf(w, "<p>%s</p>\n", html.EscapeString(text))
+}
+
+func (w *htmlWriter) table(header []string, rows [][]string) {
+	w.WriteString("<table>\n<tr>")
+	for _, h := range header {
+		fmt.Fprintf(w, "<th>%s</th>", html.EscapeString(h))
+	}
+	w.WriteString("</tr>\n")
+	for _, row := range rows {
+		w.WriteString("<tr>")
+		for i, c := range row {
+			if numericColumn(rows, i) {
+				fmt.Fprintf(w, "<td class=\"num\">%s</td>", html.EscapeString(c))
+			} else {
+				fmt.Fprintf(w, "<td>%s</td>", html.EscapeString(c))
+			}
+		}
+		w.WriteString("</tr>\n")
+	}
+	w.WriteString("</table>\n")
+}
+
+func (w *htmlWriter) chart(_, svg string) {
+	w.WriteString(svg)
+	w.WriteString("\n")
+}
+
+func (w *htmlWriter) String() string {
+	return w.Builder.String() + "</body>\n</html>\n"
+}
+
+// numericColumn reports whether every cell of column i is a number, a change or a ratio, to right-align it
+func numericColumn(rows [][]string, i int) bool {
+	for _, row := range rows {
+		if i >= len(row) || row[i] == "" || !strings.ContainsRune("0123456789+-×", []rune(row[i])[0]) {
+			return false
+		}
+	}
+	return len(rows) > 0
+}
+
+// formatNS prints a duration in nanoseconds with three significant digits and a unit
+func formatNS(ns float64) string {
+	switch {
+	case ns >= 1e9:
+		return fmt.Sprintf("%.3g s", ns/1e9)
+	case ns >= 1e6:
+		return fmt.Sprintf("%.3g ms", ns/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.3g µs", ns/1e3)
+	}
+	return fmt.Sprintf("%.3g ns", ns)
+}
+
+// chartPalette colors the runs of a chart
+var chartPalette = []string{"#4e79a7", "#f28e2b", "#59a14f", "#e15759", "#76b7b2", "#edc948", "#b07aa1", "#9c755f"}
+
+// barChartSVG draws the mean time of every algorithm at one n, one bar per run, on a log scale
+func barChartSVG(title string, algorithms []string, runs []reportRun, n uint64) string {
+	const (
+		width, labelW, valueW = 760, 170, 70
+		barH, gap, top        = 12, 10, 40
+	)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, r := range runs {
+		for _, a := range algorithms {
+			if c, ok := r.cells[cellKey{a, n}]; ok && c.mean > 0 {
+				lo, hi = min(lo, math.Log10(c.mean)), max(hi, math.Log10(c.mean))
+			}
+		}
+	}
+	if math.IsInf(lo, 1) {
+		lo, hi = 0, 1
+	}
+	lo, hi = math.Floor(lo), math.Ceil(hi)
+	if hi <= lo {
+		hi = lo + 1
+	}
+	plotW := float64(width - labelW - valueW)
+	x := func(ns float64) float64 { return labelW + (math.Log10(ns)-lo)/(hi-lo)*plotW }
+	groupH := len(runs)*barH + gap
+	height := top + len(algorithms)*groupH + 20
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`,
+		width, height, width, height)
+	fmt.Fprintf(&b, `<title>%s</title><rect width="100%%" height="100%%" fill="#fff"/>`, html.EscapeString(title))
+	fmt.Fprintf(&b, `<text x="4" y="14" font-size="13" font-weight="bold">%s</text>`, html.EscapeString(title))
+	for i, r := range runs {
+		lx := labelW + i*70
+		fmt.Fprintf(&b, `<rect x="%d" y="22" width="10" height="10" fill="%s"/><text x="%d" y="31">%s</text>`,
+			lx, chartPalette[i%len(chartPalette)], lx+14, html.EscapeString(r.label))
+	}
+	// One gridline per decade
+	for e := lo; e <= hi; e++ {
+		gx := x(math.Pow(10, e))
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#ddd"/><text x="%.1f" y="%d" text-anchor="middle" fill="#666">%s</text>`,
+			gx, top-4, gx, height-18, gx, height-6, formatNS(math.Pow(10, e)))
+	}
+	for i, a := range algorithms {
+		gy := top + i*groupH
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, labelW-6, gy+len(runs)*barH/2+4, html.EscapeString(a))
+		for j, r := range runs {
+			c, ok := r.cells[cellKey{a, n}]
+			if !ok || c.mean <= 0 {
+				continue
+			}
+			y := gy + j*barH
+			w := max(x(c.mean)-labelW, 1)
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="%s"><title>%s: %s</title></rect>`,
+				labelW, y, w, barH-2, chartPalette[j%len(chartPalette)], html.EscapeString(r.label), formatNS(c.mean))
+			fmt.Fprintf(&b, `<text x="%.1f" y="%d" fill="#333">%s</text>`, labelW+w+4, y+barH-3, formatNS(c.mean))
+		}
+	}
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+// renderReportGo renders the runs of the store at path matching revision and host
+// Every run whose report is a RunBenchmarkMatrix document contributes; the others
+// are listed as skipped.
+func renderReportGo(path, revision, host, format string) (string, error) {
+	const title = "Fibonacci benchmark report"
+	var w reportWriter
+	switch format {
+	case "", "markdown":
+		w = &markdownWriter{}
+	case "html":
+		w = newHTMLWriter(title)
+	default:
+		return "", fmt.Errorf("format must be \"markdown\" or \"html\", got %q", format)
+	}
+	stored, err := queryRunsGo(path, revision, host)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		runs    []reportRun
+		skipped []string
+	)
+	for i, run := range stored {
+		label := fmt.Sprintf("#%d", i+1)
+		var rep benchReport
+		if err := json.Unmarshal(run.Report, &rep); err != nil || len(rep.Results) == 0 {
+			skipped = append(skipped, fmt.Sprintf("%s (%s, %s) is not a RunBenchmarkMatrix report", label, run.Revision, run.Host))
+			continue
+		}
+		runs = append(runs, reportRun{label, run, poolCells(rep.Results)})
+	}
+
+	w.heading(1, title)
+	filter := ""
+	if revision != "" || host != "" {
+		filter = fmt.Sprintf(" matching revision %q and host %q", revision, host)
+	}
+	w.paragraph(fmt.Sprintf("%d runs from %s%s, generated %s. Times are the mean per call, repetitions pooled.",
+		len(runs), path, filter, time.Now().UTC().Format(time.RFC3339)))
+	if len(runs) == 0 {
+		w.paragraph("No stored run has benchmark results.")
+		return w.String(), nil
+	}
+
+	w.heading(2, "Runs")
+	rows := make([][]string, len(runs))
+	for i, r := range runs {
+		rows[i] = []string{r.label, r.run.SavedAt.Format(time.RFC3339), r.run.Revision, r.run.Host, fmt.Sprint(len(r.cells))}
+	}
+	w.table([]string{"Run", "Saved at", "Revision", "Host", "Cells"}, rows)
+
+	compared := runs
+	if len(runs) > maxReportRuns {
+		compared = runs[len(runs)-maxReportRuns:]
+		w.paragraph(fmt.Sprintf("The comparisons below show the last %d runs.", maxReportRuns))
+	}
+	var (
+		algorithms []string
+		ns         []uint64
+	)
+	for _, r := range compared {
+		for key := range r.cells {
+			if !slices.Contains(algorithms, key.algorithm) {
+				algorithms = append(algorithms, key.algorithm)
+			}
+			if !slices.Contains(ns, key.n) {
+				ns = append(ns, key.n)
+			}
+		}
+	}
+	slices.Sort(algorithms)
+	slices.Sort(ns)
+
+	first, last := compared[0], compared[len(compared)-1]
+	header := []string{"Algorithm"}
+	for _, r := range compared {
+		header = append(header, r.label)
+	}
+	if len(compared) > 1 {
+		header = append(header, fmt.Sprintf("Change %s → %s", first.label, last.label))
+	}
+	header = append(header, "vs fastest")
+	for _, n := range ns {
+		var present []string
+		fastest := math.Inf(1)
+		for _, a := range algorithms {
+			if c, ok := last.cells[cellKey{a, n}]; ok {
+				fastest = min(fastest, c.mean)
+			}
+			if slices.ContainsFunc(compared, func(r reportRun) bool { _, ok := r.cells[cellKey{a, n}]; return ok }) {
+				present = append(present, a)
+			}
+		}
+		slices.SortStableFunc(present, func(a, b string) int {
+			ca, okA := last.cells[cellKey{a, n}]
+			cb, okB := last.cells[cellKey{b, n}]
+			switch {
+			case okA && !okB:
+				return -1
+			case okB && !okA:
+				return 1
+			}
+			return cmp.Compare(ca.mean, cb.mean)
+		})
+
+		section := fmt.Sprintf("n = %d", n)
+		w.heading(2, section)
+		w.chart(section, barChartSVG(section, present, compared, n))
+		rows := make([][]string, 0, len(present))
+		for _, a := range present {
+			key := cellKey{a, n}
+			row := []string{a}
+			for _, r := range compared {
+				cell := "-"
+				if c, ok := r.cells[key]; ok {
+					cell = formatNS(c.mean)
+				}
+				row = append(row, cell)
+			}
+			if len(compared) > 1 {
+				row = append(row, changeCell(first.cells, last.cells, key))
+			}
+			ratio := "-"
+			if c, ok := last.cells[key]; ok && fastest > 0 {
+				ratio = fmt.Sprintf("×%.2f", c.mean/fastest)
+				if c.mean/fastest >= 100 {
+					ratio = fmt.Sprintf("×%.0f", c.mean/fastest)
+				}
+			}
+			rows = append(rows, append(row, ratio))
+		}
+		w.table(header, rows)
+	}
+	if len(compared) > 1 {
+		w.paragraph("† marks a change Welch's t-test finds significant at 95%.")
+	}
+	if len(skipped) > 0 {
+		w.heading(2, "Skipped runs")
+		for _, s := range skipped {
+			w.paragraph(s)
+		}
+	}
+	return w.String(), nil
+}
+
+// changeCell formats the relative change of a cell between two runs, marked when significant
+func changeCell(before, after map[cellKey]cellStats, key cellKey) string {
+	b, okB := before[key]
+	a, okA := after[key]
+	if !okB || !okA || b.mean == 0 {
+		return "-"
+	}
+	s := fmt.Sprintf("%+.1f%%", (a.mean-b.mean)/b.mean*100)
+	if math.Abs(welchT(b, a)) > significanceZ {
+		s += " †"
+	}
+	return s
+}
+
+// RenderReport turns the runs of the result store at path into a Markdown or HTML report
+// revision and host filter the runs like QueryRuns; format is "markdown" (the
+// default for NULL) or "html", a self-contained page. For every n the report
+// holds a log-scale SVG bar chart of the algorithms, one bar per run, and a table
+// of their mean times, the change from the first to the last run compared and
+// the ratio to the fastest algorithm of the last run. Markdown embeds the charts
+// as data URIs. Only the last maxReportRuns (8) runs are compared.
+// Returns NULL and records StatusNotFound if the store does not exist and
+// StatusInvalidArg for an unknown format.
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export RenderReport
+func RenderReport(path, revision, host, format *C.char) *C.char {
+	defer recoverPanic()
+	return documentCString(reportDocument(path, revision, host, format))
+}
+
+// reportDocument checks the arguments of RenderReport and renders the report
+func reportDocument(path, revision, host, format *C.char) (string, C.fib_status, error) {
+	if path == nil {
+		return "", StatusInvalidArg, errors.New("RenderReport: NULL path")
+	}
+	var rev, hostName, formatName string
+	if revision != nil {
+		rev = C.GoString(revision)
+	}
+	if host != nil {
+		hostName = C.GoString(host)
+	}
+	if format != nil {
+		formatName = C.GoString(format)
+	}
+	if formatName != "" && formatName != "markdown" && formatName != "html" {
+		return "", StatusInvalidArg, fmt.Errorf("RenderReport: format must be \"markdown\" or \"html\", got %q", formatName)
+	}
+	doc, err := renderReportGo(C.GoString(path), rev, hostName, formatName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", StatusNotFound, err
+	}
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return doc, StatusOK, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxStoredSamples bounds the samples kept for export; later samples are dropped until ClearResults
+const maxStoredSamples = 1 << 20
+
+// benchSample is one timed call of a benchmark run, a row of the exported results
+type benchSample struct {
+	Machine   string `json:"machine"`
+	Run       uint64 `json:"run"`
+	Algorithm string `json:"algorithm"`
+	N         uint64 `json:"n"`
+	Iteration int    `json:"iteration"`
+	WallNS    int64  `json:"wall_ns"`
+}
+
+// benchResults holds the samples of the benchmark runs since the last ClearResults
+var benchResults struct {
+	sync.Mutex
+	runs    uint64
+	dropped bool
+	samples []benchSample
+}
+
+// machineName identifies the host in exported results
+var machineName = sync.OnceValue(func() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return name
+})
+
+// recordSamples stores the samples of one benchmark run
+func recordSamples(algorithm string, n uint64, samples []time.Duration) {
+	machine := machineName()
+	benchResults.Lock()
+	defer benchResults.Unlock()
+	benchResults.runs++
+	if len(benchResults.samples)+len(samples) > maxStoredSamples {
+		if !benchResults.dropped {
+			logger.Warn("benchmark result store full, dropping samples until ClearResults", "max_samples", maxStoredSamples)
+			benchResults.dropped = true
+		}
+		return
+	}
+	for i, d := range samples {
+		benchResults.samples = append(benchResults.samples, benchSample{machine, benchResults.runs, algorithm, n, i, d.Nanoseconds()})
+	}
+}
+
+// storedSamples returns a snapshot of the recorded samples
+func storedSamples() []benchSample {
+	benchResults.Lock()
+	defer benchResults.Unlock()
+	return append([]benchSample(nil), benchResults.samples...)
+}
+
+// ExportResultsCSV writes every recorded benchmark sample to path as CSV, one row per timed call
+// The header is machine,run,algorithm,n,iteration,wall_ns; run numbers the benchmark
+// runs since the last ClearResults. The file is replaced if it exists.
+//
+//export ExportResultsCSV
+func ExportResultsCSV(path *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if path == nil {
+		return StatusInvalidArg
+	}
+	err := writeResultsFile(C.GoString(path), func(w *bufio.Writer) error {
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"machine", "run", "algorithm", "n", "iteration", "wall_ns"})
+		for _, s := range storedSamples() {
+			cw.Write([]string{s.Machine, strconv.FormatUint(s.Run, 10), s.Algorithm,
+				strconv.FormatUint(s.N, 10), strconv.Itoa(s.Iteration), strconv.FormatInt(s.WallNS, 10)})
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+	if err != nil {
+		return failWith(StatusInternal, "export: %v", err)
+	}
+	return StatusOK
+}
+
+// ExportResultsNDJSON writes every recorded benchmark sample to path as newline-delimited JSON
+// Each line is an object with the columns of ExportResultsCSV.
+//
+//export ExportResultsNDJSON
+func ExportResultsNDJSON(path *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if path == nil {
+		return StatusInvalidArg
+	}
+	err := writeResultsFile(C.GoString(path), func(w *bufio.Writer) error {
+		enc := json.NewEncoder(w)
+		for _, s := range storedSamples() {
+			if err := enc.Encode(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return failWith(StatusInternal, "export: %v", err)
+	}
+	return StatusOK
+}
+
+// ClearResults forgets the recorded benchmark samples and restarts the run numbering
+//
+//export ClearResults
+func ClearResults() {
+	defer recoverPanic()
+	benchResults.Lock()
+	defer benchResults.Unlock()
+	benchResults.runs = 0
+	benchResults.dropped = false
+	benchResults.samples = nil
+}
+
+// writeResultsFile creates path and fills it through a buffered writer
+func writeResultsFile(path string, fill func(*bufio.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := fill(w); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
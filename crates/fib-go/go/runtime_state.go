@@ -0,0 +1,64 @@
+package main
+
+/*
+#include "fib_types.h"
+
+// The state lives on the C side so GetRuntimeState can be called at any time,
+// including before the Go runtime finished starting, without waiting for it.
+static fib_runtime_state fib_runtime_state_value = FIB_RUNTIME_STARTING;
+
+fib_runtime_state GetRuntimeState(void) {
+	return __atomic_load_n(&fib_runtime_state_value, __ATOMIC_ACQUIRE);
+}
+
+static void fib_set_runtime_state(fib_runtime_state state) {
+	__atomic_store_n(&fib_runtime_state_value, state, __ATOMIC_RELEASE);
+}
+*/
+import "C"
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Runtime states reported by GetRuntimeState (see fib_types.h)
+const (
+	RuntimeStarting    C.fib_runtime_state = C.FIB_RUNTIME_STARTING
+	RuntimeIdle        C.fib_runtime_state = C.FIB_RUNTIME_IDLE
+	RuntimeLive        C.fib_runtime_state = C.FIB_RUNTIME_LIVE
+	RuntimeInitialized C.fib_runtime_state = C.FIB_RUNTIME_INITIALIZED
+)
+
+// idleSettings holds the GC percentage and GOMAXPROCS in force before parkRuntime
+var idleSettings struct {
+	gcPercent int
+	maxProcs  int
+}
+
+func init() {
+	if deferredStartup {
+		parkRuntime()
+		return
+	}
+	setRuntimeState(RuntimeLive)
+}
+
+// setRuntimeState publishes the state returned by GetRuntimeState
+func setRuntimeState(state C.fib_runtime_state) {
+	C.fib_set_runtime_state(state)
+}
+
+// parkRuntime keeps a deferred-startup runtime quiet until FibInit: one P, no GC cycles
+func parkRuntime() {
+	idleSettings.gcPercent = debug.SetGCPercent(-1)
+	idleSettings.maxProcs = runtime.GOMAXPROCS(1)
+	setRuntimeState(RuntimeIdle)
+}
+
+// wakeRuntime restores the settings saved by parkRuntime
+func wakeRuntime() {
+	debug.SetGCPercent(idleSettings.gcPercent)
+	runtime.GOMAXPROCS(idleSettings.maxProcs)
+	setRuntimeState(RuntimeLive)
+}
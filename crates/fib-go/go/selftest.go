@@ -0,0 +1,236 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+const (
+	// maxSelfTestIterations bounds one SelfTest call
+	maxSelfTestIterations = 1_000_000
+	// selfTestMaxN bounds the random n of the big-integer checks, keeping iterations in the microseconds
+	selfTestMaxN = 4096
+	// selfTestPisanoMaxM bounds the moduli of the Pisano checks, whose first use of a modulus is O(m)
+	selfTestPisanoMaxM = 4096
+	// maxSelfTestFailures bounds the failures listed in the report; failure_count has the total
+	maxSelfTestFailures = 100
+)
+
+// selfTestBoundaries are the n drawn more often: the ends of the uint64, 128-bit and float64 ranges
+var selfTestBoundaries = []uint64{
+	0, 1, 2,
+	fib.MaxSafeN - 1, fib.MaxSafeN, fib.MaxSafeN + 1,
+	fib.MaxSafeN128, fib.MaxSafeN128 + 1,
+	1476, 1477,
+}
+
+// selfTestFailure is one check that did not hold
+type selfTestFailure struct {
+	Check  string `json:"check"`
+	N      uint64 `json:"n"`
+	M      string `json:"m,omitempty"` // modulus of the modular checks or second index of gcd, decimal
+	Detail string `json:"detail"`
+}
+
+// selfTestReport is the SelfTest document
+type selfTestReport struct {
+	Seed         uint64            `json:"seed"`
+	Iterations   uint64            `json:"iterations"`
+	Checks       uint64            `json:"checks"`
+	Passed       bool              `json:"passed"`
+	FailureCount uint64            `json:"failure_count"`
+	Failures     []selfTestFailure `json:"failures"`
+}
+
+// selfTest runs the differential checks of SelfTest
+type selfTest struct {
+	report     selfTestReport
+	algorithms []algorithmInfo
+	bigNames   []string
+}
+
+func (t *selfTest) expect(ok bool, f selfTestFailure) {
+	t.report.Checks++
+	if ok {
+		return
+	}
+	t.report.FailureCount++
+	if len(t.report.Failures) < maxSelfTestFailures {
+		t.report.Failures = append(t.report.Failures, f)
+	}
+}
+
+// checkAlgorithms compares every uint64 algorithm that can represent F(n) and every big-integer algorithm with want
+func (t *selfTest) checkAlgorithms(n uint64, want *big.Int) {
+	recursiveLimit := min(recursiveMaxN.Load(), verifyRecursiveMaxN)
+	for _, algo := range t.algorithms {
+		if n > algo.MaxSafeN || (algo.ID == AlgoRecursive && n > recursiveLimit) {
+			continue
+		}
+		got := algo.fn(n)
+		t.expect(want.IsUint64() && got == want.Uint64(), selfTestFailure{
+			Check: algo.Name, N: n, Detail: fmt.Sprintf("got %d, want %s", got, want),
+		})
+	}
+	for _, name := range t.bigNames {
+		got, err := bigAlgorithms[name](context.Background(), n)
+		if err != nil {
+			t.expect(false, selfTestFailure{Check: name, N: n, Detail: err.Error()})
+			continue
+		}
+		t.expect(got.Cmp(want) == 0, selfTestFailure{
+			Check: name, N: n, Detail: fmt.Sprintf("got %s, want %s", got, want),
+		})
+	}
+}
+
+// checkModular checks the modular exports and identities for F(n) = want against moduli drawn from rng
+func (t *selfTest) checkModular(rng *rand.Rand, n uint64, want *big.Int) {
+	m := rng.Uint64() | 1
+	mod := func(x *big.Int, m uint64) uint64 {
+		return new(big.Int).Mod(x, new(big.Int).SetUint64(m)).Uint64()
+	}
+	wantMod := mod(want, m)
+	ms := fmt.Sprint(m)
+	got := fib.Mod(n, m)
+	t.expect(got == wantMod, selfTestFailure{
+		Check: "mod", N: n, M: ms, Detail: fmt.Sprintf("got %d, want %d", got, wantMod),
+	})
+
+	// Cassini: F(n-1)F(n+1) - F(n)^2 = (-1)^n, with F(-1) = 1
+	prev := uint64(1) % m
+	if n > 0 {
+		prev = fib.Mod(n-1, m)
+	}
+	lhs := new(big.Int).Mul(new(big.Int).SetUint64(prev), new(big.Int).SetUint64(fib.Mod(n+1, m)))
+	lhs.Sub(lhs, new(big.Int).Mul(new(big.Int).SetUint64(got), new(big.Int).SetUint64(got)))
+	sign := big.NewInt(1)
+	if n%2 == 1 {
+		sign.SetInt64(-1)
+	}
+	t.expect(mod(lhs, m) == mod(sign, m), selfTestFailure{
+		Check: "cassini", N: n, M: ms, Detail: fmt.Sprintf("F(n-1)F(n+1) - F(n)^2 = %d, want %d", mod(lhs, m), mod(sign, m)),
+	})
+
+	// Doubling: F(2n) = F(n)(2F(n+1) - F(n))
+	f1 := new(big.Int).SetUint64(fib.Mod(n+1, m))
+	rhs := new(big.Int).Sub(f1.Lsh(f1, 1), new(big.Int).SetUint64(got))
+	rhs.Mul(rhs, new(big.Int).SetUint64(got))
+	t.expect(fib.Mod(2*n, m) == mod(rhs, m), selfTestFailure{
+		Check: "doubling_identity", N: n, M: ms, Detail: fmt.Sprintf("F(2n) = %d, F(n)(2F(n+1) - F(n)) = %d", fib.Mod(2*n, m), mod(rhs, m)),
+	})
+
+	// Pisano: F(n) and F(n + period) agree modulo a small m
+	small := 1 + rng.Uint64N(selfTestPisanoMaxM)
+	wantSmall := mod(want, small)
+	fast := fib.ModFast(n, small)
+	t.expect(fast == wantSmall, selfTestFailure{
+		Check: "mod_fast", N: n, M: fmt.Sprint(small), Detail: fmt.Sprintf("got %d, want %d", fast, wantSmall),
+	})
+	period := fib.PisanoPeriod(small)
+	shifted := fib.Mod(n+period, small)
+	t.expect(shifted == wantSmall, selfTestFailure{
+		Check: "pisano", N: n, M: fmt.Sprint(small), Detail: fmt.Sprintf("F(n + %d) mod m = %d, F(n) mod m = %d", period, shifted, wantSmall),
+	})
+
+	// Big modulus beyond 64 bits
+	bm := new(big.Int).Lsh(new(big.Int).SetUint64(rng.Uint64()), 64)
+	bm.Or(bm, new(big.Int).SetUint64(rng.Uint64()|1))
+	bigGot, bigWant := fib.BigMod(n, bm), new(big.Int).Mod(want, bm)
+	t.expect(bigGot.Cmp(bigWant) == 0, selfTestFailure{
+		Check: "big_mod", N: n, M: bm.String(), Detail: fmt.Sprintf("got %s, want %s", bigGot, bigWant),
+	})
+}
+
+// checkGCD checks gcd(F(m), F(n)) = F(gcd(m, n)) for F(n) = want and a random m, with Euclid on the big integers
+func (t *selfTest) checkGCD(rng *rand.Rand, n uint64, want *big.Int) {
+	m := rng.Uint64N(selfTestMaxN + 1)
+	brute := new(big.Int).GCD(nil, nil, want, fib.BigIterative(m))
+	got := fib.BigGCD(m, n)
+	t.expect(got.Cmp(brute) == 0, selfTestFailure{
+		Check: "gcd", N: n, M: fmt.Sprint(m), Detail: fmt.Sprintf("F(gcd(m, n)) = %s, gcd(F(m), F(n)) = %s", got, brute),
+	})
+	if small, ok := fib.GCD(m, n); ok {
+		t.expect(brute.IsUint64() && small == brute.Uint64(), selfTestFailure{
+			Check: "gcd_uint64", N: n, M: fmt.Sprint(m), Detail: fmt.Sprintf("got %d, want %s", small, brute),
+		})
+	}
+}
+
+// selfTestGo runs iterations rounds of checks on n drawn from a PCG seeded with seed
+// A quarter of the rounds use a boundary value, the others a uniform n in
+// [0, 93] or [0, 4096]. The reference is F(n) by big-integer addition.
+func selfTestGo(iterations, seed uint64) selfTestReport {
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	t := selfTest{
+		report:     selfTestReport{Seed: seed, Iterations: iterations, Failures: []selfTestFailure{}},
+		algorithms: registeredAlgorithms(),
+	}
+	for name := range bigAlgorithms {
+		t.bigNames = append(t.bigNames, name)
+	}
+	slices.Sort(t.bigNames)
+
+	for i := uint64(0); i < iterations; i++ {
+		var n uint64
+		switch rng.IntN(4) {
+		case 0:
+			n = selfTestBoundaries[rng.IntN(len(selfTestBoundaries))]
+		case 1:
+			n = rng.Uint64N(fib.MaxSafeN + 1)
+		default:
+			n = rng.Uint64N(selfTestMaxN + 1)
+		}
+		want := fib.BigIterative(n)
+		t.checkAlgorithms(n, want)
+		t.checkModular(rng, n, want)
+		t.checkGCD(rng, n, want)
+	}
+	t.report.Passed = t.report.FailureCount == 0
+	return t.report
+}
+
+// SelfTest cross-checks every algorithm and the modular identities on random n
+// Each of the iterations draws n, a boundary value (0, 1, 92, 93, 186, 1476 and
+// their neighbours) a quarter of the time, and compares every uint64 algorithm
+// able to represent F(n), every big-integer algorithm, FibMod, FibModFast, the big-modulus reduction,
+// the Pisano period, the Cassini and doubling identities and FibGCD against
+// Euclid on the big integers with F(n) computed by addition. The same seed replays the same inputs on any host. Returns
+// {"seed", "iterations", "checks", "passed", "failure_count", "failures":
+// [{"check", "n", "m", "detail"}]} listing the first 100 failures; the string is
+// owned by the caller and must be released with FreeCString. Returns NULL with
+// StatusInvalidArg if iterations is 0 and StatusLimitExceeded past 1000000.
+//
+//export SelfTest
+func SelfTest(iterations, seed C.uint64_t) *C.char {
+	defer recoverPanic()
+	return documentCString(selfTestDocument(iterations, seed))
+}
+
+// selfTestDocument checks the arguments of SelfTest and encodes its report
+func selfTestDocument(iterations, seed C.uint64_t) (string, C.fib_status, error) {
+	if iterations == 0 {
+		return "", StatusInvalidArg, errors.New("SelfTest: iterations must be > 0")
+	}
+	if iterations > maxSelfTestIterations {
+		return "", StatusLimitExceeded, fmt.Errorf("SelfTest: iterations must be <= %d", maxSelfTestIterations)
+	}
+	data, err := json.Marshal(selfTestGo(uint64(iterations), uint64(seed)))
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(data), StatusOK, nil
+}
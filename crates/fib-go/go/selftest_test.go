@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+func TestSelfTestPasses(t *testing.T) {
+	report := selfTestGo(200, 42)
+	if !report.Passed || report.FailureCount != 0 {
+		t.Fatalf("SelfTest failed %d checks: %+v", report.FailureCount, report.Failures)
+	}
+	if report.Checks == 0 {
+		t.Fatal("SelfTest ran no check")
+	}
+	if again := selfTestGo(200, 42); again.Checks != report.Checks {
+		t.Errorf("seed 42 ran %d checks, then %d", report.Checks, again.Checks)
+	}
+}
+
+func TestSelfTestReportsFailures(t *testing.T) {
+	broken := algorithmInfo{Name: "off_by_one", MaxSafeN: fib.MaxSafeN, fn: func(n uint64) uint64 { return fib.Iterative(n) + 1 }}
+	st := selfTest{algorithms: []algorithmInfo{broken}}
+	for range 2 {
+		for n := uint64(0); n <= fib.MaxSafeN; n++ {
+			st.checkAlgorithms(n, fib.BigIterative(n))
+		}
+	}
+	if want := uint64(2 * (fib.MaxSafeN + 1)); st.report.Checks != want || st.report.FailureCount != want {
+		t.Fatalf("%d checks and %d failures, want %d of each", st.report.Checks, st.report.FailureCount, want)
+	}
+	if len(st.report.Failures) != maxSelfTestFailures {
+		t.Errorf("%d failures listed, want the first %d", len(st.report.Failures), maxSelfTestFailures)
+	}
+	if f := st.report.Failures[10]; f.Check != "off_by_one" || f.N != 10 || f.Detail != "got 56, want 55" {
+		t.Errorf("failure %+v, want off_by_one at n = 10", f)
+	}
+}
+
+func TestSelfTestDocument(t *testing.T) {
+	if _, status, _ := selfTestDocument(0, 1); status != StatusInvalidArg {
+		t.Errorf("0 iterations: status %d, want StatusInvalidArg", status)
+	}
+	if _, status, _ := selfTestDocument(maxSelfTestIterations+1, 1); status != StatusLimitExceeded {
+		t.Errorf("%d iterations: status %d, want StatusLimitExceeded", maxSelfTestIterations+1, status)
+	}
+	doc, status, err := selfTestDocument(10, 7)
+	if status != StatusOK {
+		t.Fatalf("status %d: %v", status, err)
+	}
+	var report selfTestReport
+	if err := json.Unmarshal([]byte(doc), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Seed != 7 || report.Iterations != 10 || !report.Passed {
+		t.Errorf("report %+v, want 10 passing iterations of seed 7", report)
+	}
+}
@@ -0,0 +1,327 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"unsafe"
+)
+
+// Binary forms of the benchmark results and big-integer values, for hosts that
+// move large result sets: Protocol Buffers with the messages of
+// proto/fib/v1/fib.proto and CBOR (RFC 8949). These exports follow the two-call
+// protocol of the *Buf exports, returning the size required.
+
+// copyBytesToBuffer writes data into buf if it fits and returns the size required
+func copyBytesToBuffer(data []byte, buf *C.uint8_t, length C.size_t) C.size_t {
+	if buf != nil && int(length) >= len(data) {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(buf)), len(data)), data)
+	}
+	return C.size_t(len(data))
+}
+
+// decodeBenchResult decodes a RunBenchmarkMatrix report or a RunBenchmarkJSON record
+// A document with a "results" key is a report.
+func decodeBenchResult(doc string) (report *benchReport, record *benchRecord, err error) {
+	var probe struct {
+		Results json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(doc), &probe); err != nil {
+		return nil, nil, err
+	}
+	if probe.Results != nil {
+		report = &benchReport{}
+		return report, nil, decodeStrict(doc, report)
+	}
+	record = &benchRecord{}
+	return nil, record, decodeStrict(doc, record)
+}
+
+// marshalBenchOptions encodes a fib.v1.BenchmarkOptions message
+func marshalBenchOptions(o benchOptions) []byte {
+	var b []byte
+	b = appendUint(b, 1, o.WarmupIters)
+	b = appendUint(b, 2, o.MeasureIters)
+	b = appendUint(b, 3, o.Count)
+	b = appendString(b, 4, o.Format)
+	b = appendUint(b, 5, boolBit(o.ColdStart))
+	b = appendUint(b, 6, boolBit(o.CPUTime))
+	b = appendUint(b, 7, boolBit(o.PerfCounters))
+	if o.PinCPU != nil {
+		// optional field: present even when 0
+		b = binary.AppendUvarint(appendTag(b, 8, wireVarint), uint64(int64(*o.PinCPU)))
+	}
+	return b
+}
+
+// marshalBenchRecord encodes a fib.v1.BenchmarkRecord message
+func marshalBenchRecord(r benchRecord) []byte {
+	var b []byte
+	b = appendString(b, 1, r.Algorithm)
+	b = appendInt(b, 2, int64(r.ID))
+	b = appendUint(b, 3, r.N)
+	b = appendInt(b, 4, int64(r.Status))
+	b = appendUint(b, 5, uint64(r.Iterations))
+	b = appendInt(b, 6, r.MinNS)
+	b = appendInt(b, 7, r.MaxNS)
+	b = appendDouble(b, 8, r.MeanNS)
+	b = appendDouble(b, 9, r.StddevNS)
+	b = appendInt(b, 10, r.P50NS)
+	b = appendInt(b, 11, r.P90NS)
+	b = appendInt(b, 12, r.P99NS)
+	b = appendInt(b, 13, r.P999NS)
+	for _, h := range r.Histogram {
+		b = appendRawBytes(b, 14, appendUint(appendUint(nil, 1, h.BelowNS), 2, h.Count))
+	}
+	b = appendDouble(b, 15, r.AllocsPerOp)
+	b = appendDouble(b, 16, r.BytesPerOp)
+	b = appendUint(b, 17, uint64(r.GCCycles))
+	b = appendUint(b, 18, r.GCPauseNS)
+	if c := r.ColdStart; c != nil {
+		var m []byte
+		m = appendInt(m, 1, c.FirstCallNS)
+		m = appendUint(m, 2, boolBit(c.FirstUse))
+		m = appendInt(m, 3, c.SinceLoadNS)
+		b = appendRawBytes(b, 19, m)
+	}
+	if c := r.CPUTime; c != nil {
+		b = appendRawBytes(b, 20, appendDouble(appendInt(nil, 1, c.TotalNS), 2, c.PerOpNS))
+	}
+	if p := r.PerfCounters; p != nil {
+		var m []byte
+		m = appendDouble(m, 1, p.InstructionsPerOp)
+		m = appendDouble(m, 2, p.CyclesPerOp)
+		m = appendDouble(m, 3, p.BranchMissesPerOp)
+		m = appendDouble(m, 4, p.CacheMissesPerOp)
+		m = appendDouble(m, 5, p.IPC)
+		b = appendRawBytes(b, 21, m)
+	}
+	b = appendString(b, 22, r.PerfError)
+	b = appendString(b, 23, r.PinError)
+	return b
+}
+
+// marshalBenchReport encodes a fib.v1.BenchmarkReport message
+func marshalBenchReport(r benchReport) []byte {
+	b := appendBytes(nil, 1, marshalBenchOptions(r.Options))
+	for _, rec := range r.Results {
+		b = appendRawBytes(b, 2, marshalBenchRecord(rec))
+	}
+	return b
+}
+
+func boolBit(v bool) uint64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// CBOR major types
+const (
+	cborUint   = 0 << 5
+	cborNegInt = 1 << 5
+	cborBytes  = 2 << 5
+	cborText   = 3 << 5
+	cborArray  = 4 << 5
+	cborMap    = 5 << 5
+	cborTag    = 6 << 5
+	cborSimple = 7 << 5
+)
+
+// cborPositiveBignum is the tag of an unsigned bignum, a byte string holding the big-endian magnitude
+const cborPositiveBignum = 2
+
+// appendCBORHead appends the initial bytes of a data item of the given major type and argument
+func appendCBORHead(b []byte, major byte, v uint64) []byte {
+	switch {
+	case v < 24:
+		return append(b, major|byte(v))
+	case v <= math.MaxUint8:
+		return append(b, major|24, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(v))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), v)
+}
+
+// appendCBORBig appends a non-negative integer, as a bignum only when it exceeds 64 bits
+func appendCBORBig(b []byte, x *big.Int) []byte {
+	if x.IsUint64() {
+		return appendCBORHead(b, cborUint, x.Uint64())
+	}
+	magnitude := x.Bytes()
+	b = appendCBORHead(b, cborTag, cborPositiveBignum)
+	return append(appendCBORHead(b, cborBytes, uint64(len(magnitude))), magnitude...)
+}
+
+// appendCBORFloat appends f as a single-precision float when that is exact, double otherwise
+func appendCBORFloat(b []byte, f float64) []byte {
+	if f32 := float32(f); float64(f32) == f {
+		return binary.BigEndian.AppendUint32(append(b, cborSimple|26), math.Float32bits(f32))
+	}
+	return binary.BigEndian.AppendUint64(append(b, cborSimple|27), math.Float64bits(f))
+}
+
+// appendCBORJSON transcodes a value decoded by encoding/json with UseNumber
+// Map keys are sorted bytewise by their encoding, the deterministic order of
+// RFC 8949 section 4.2.1, and integers take their shortest form.
+func appendCBORJSON(b []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, cborSimple|22), nil
+	case bool:
+		if v {
+			return append(b, cborSimple|21), nil
+		}
+		return append(b, cborSimple|20), nil
+	case string:
+		return append(appendCBORHead(b, cborText, uint64(len(v))), v...), nil
+	case json.Number:
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return appendCBORHead(b, cborUint, u), nil
+		}
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			if i < 0 {
+				return appendCBORHead(b, cborNegInt, uint64(-1-i)), nil
+			}
+			return appendCBORHead(b, cborUint, 0), nil // -0
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendCBORFloat(b, f), nil
+	case []any:
+		b = appendCBORHead(b, cborArray, uint64(len(v)))
+		for _, item := range v {
+			var err error
+			if b, err = appendCBORJSON(b, item); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]any:
+		type entry struct{ key, value []byte }
+		entries := make([]entry, 0, len(v))
+		for k, item := range v {
+			value, err := appendCBORJSON(nil, item)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry{append(appendCBORHead(nil, cborText, uint64(len(k))), k...), value})
+		}
+		slices.SortFunc(entries, func(x, y entry) int { return bytes.Compare(x.key, y.key) })
+		b = appendCBORHead(b, cborMap, uint64(len(entries)))
+		for _, e := range entries {
+			b = append(append(b, e.key...), e.value...)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("cbor: unsupported %T", v)
+}
+
+// jsonToCBOR transcodes one JSON document
+func jsonToCBOR(doc string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(doc)))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("trailing data after the JSON value")
+	}
+	return appendCBORJSON(nil, v)
+}
+
+// ResultToProto encodes a benchmark result as Protocol Buffers into buf
+// resultJSON is a RunBenchmarkMatrix report, encoded as fib.v1.BenchmarkReport,
+// or a RunBenchmarkJSON record, encoded as fib.v1.BenchmarkRecord (see
+// proto/fib/v1/fib.proto). Returns the number of bytes required; nothing is
+// written if length is smaller. Returns 0 and records StatusInvalidArg for a
+// NULL or malformed document.
+//
+//export ResultToProto
+func ResultToProto(resultJSON *C.char, buf *C.uint8_t, length C.size_t) C.size_t {
+	defer recoverPanic()
+	if resultJSON == nil {
+		setLastError(C.int32_t(StatusInvalidArg), "ResultToProto: NULL result")
+		return 0
+	}
+	report, record, err := decodeBenchResult(C.GoString(resultJSON))
+	if err != nil {
+		setLastError(C.int32_t(StatusInvalidArg), fmt.Sprintf("ResultToProto: %v", err))
+		return 0
+	}
+	if report != nil {
+		return copyBytesToBuffer(marshalBenchReport(*report), buf, length)
+	}
+	return copyBytesToBuffer(marshalBenchRecord(*record), buf, length)
+}
+
+// ResultToCBOR transcodes a JSON result document to CBOR (RFC 8949) into buf
+// Any document works, e.g. from RunBenchmarkMatrix, QueryRuns or GetTelemetryJSON.
+// Objects become maps with the deterministic key order of RFC 8949 and numbers
+// the shortest integer or exact float form. Returns the number of bytes required;
+// nothing is written if length is smaller. Returns 0 and records StatusInvalidArg
+// for a NULL or malformed document.
+//
+//export ResultToCBOR
+func ResultToCBOR(resultJSON *C.char, buf *C.uint8_t, length C.size_t) C.size_t {
+	defer recoverPanic()
+	if resultJSON == nil {
+		setLastError(C.int32_t(StatusInvalidArg), "ResultToCBOR: NULL result")
+		return 0
+	}
+	data, err := jsonToCBOR(C.GoString(resultJSON))
+	if err != nil {
+		setLastError(C.int32_t(StatusInvalidArg), fmt.Sprintf("ResultToCBOR: %v", err))
+		return 0
+	}
+	return copyBytesToBuffer(data, buf, length)
+}
+
+// BigToProto encodes a big result as a fib.v1.BigInteger message into buf
+// The message holds the big-endian magnitude. Returns the number of bytes
+// required; nothing is written if length is smaller, 0 for an invalid handle.
+//
+//export BigToProto
+func BigToProto(h C.uintptr_t, buf *C.uint8_t, length C.size_t) C.size_t {
+	defer recoverPanic()
+	x := bigFromHandle(h)
+	if x == nil {
+		return 0
+	}
+	// The field is written even for 0, so the message is never empty
+	return copyBytesToBuffer(appendRawBytes(nil, 1, x.Bytes()), buf, length)
+}
+
+// BigToCBOR encodes a big result as a CBOR integer into buf
+// Values past 64 bits are unsigned bignums (tag 2), which CBOR decoders map to
+// their big-integer type. Returns the number of bytes required; nothing is
+// written if length is smaller, 0 for an invalid handle.
+//
+//export BigToCBOR
+func BigToCBOR(h C.uintptr_t, buf *C.uint8_t, length C.size_t) C.size_t {
+	defer recoverPanic()
+	x := bigFromHandle(h)
+	if x == nil {
+		return 0
+	}
+	return copyBytesToBuffer(appendCBORBig(nil, x), buf, length)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestJSONToCBORIntegers(t *testing.T) {
+	for doc, want := range map[string][]byte{
+		"0":                    {0x00},
+		"-0":                   {0x00},
+		"23":                   {0x17},
+		"24":                   {0x18, 0x18},
+		"-1":                   {0x20},
+		"-25":                  {0x38, 0x18},
+		"18446744073709551615": {0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"-9223372036854775808": {0x3b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"[-1, -0, 0]":          {0x83, 0x20, 0x00, 0x00},
+		"1.5":                  {0xfa, 0x3f, 0xc0, 0x00, 0x00},
+	} {
+		got, err := jsonToCBOR(doc)
+		if err != nil {
+			t.Errorf("jsonToCBOR(%s): %v", doc, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("jsonToCBOR(%s) = %x, want %x", doc, got, want)
+		}
+	}
+}
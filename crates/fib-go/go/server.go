@@ -0,0 +1,289 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// defaultServerAlgorithm is used when a request names no algorithm; it is exact for every n and stops with its request
+const defaultServerAlgorithm = "big_doubling"
+
+// serverMaxN bounds n in the server modes even when max_n is unlimited; F(n) has about 0.69n bits
+const serverMaxN = 10_000_000
+
+// serverUncancellableMaxN is the tighter bound of the big-integer algorithms that ignore ctx and timeout_ms
+const serverUncancellableMaxN = 1_000_000
+
+// bigAlgorithms are the big-integer algorithms of the server modes, by name, beside the uint64 registry
+var bigAlgorithms = map[string]func(context.Context, uint64) (*big.Int, error){
+	"big_doubling":          bigDoublingGo,
+	"big_iterative":         fib.BigIterativeContext,
+	"big_matrix":            fib.BigMatrixContext,
+	"big_doubling_parallel": fib.BigDoublingParallelContext,
+	"big_doubling_square":   fib.BigDoublingSquareContext,
+	"limb_doubling":         fib.LimbDoublingContext,
+	"auto":                  uncancellable(fib.Auto),
+	"big_binet":             uncancellable(func(n uint64) *big.Int { return fib.BigBinet(n, 0) }),
+}
+
+// cancellableBigAlgorithms are the bigAlgorithms that stop when their ctx is done
+var cancellableBigAlgorithms = map[string]bool{
+	"big_doubling":          true,
+	"big_iterative":         true,
+	"big_matrix":            true,
+	"big_doubling_parallel": true,
+	"big_doubling_square":   true,
+	"limb_doubling":         true,
+}
+
+// serverLimit returns the largest n the server modes compute with the algorithm called name
+// A name outside bigAlgorithms, such as "", gets the limit of the other endpoints.
+func serverLimit(name string) uint64 {
+	limit := min(dispatchMaxN.Load(), serverMaxN)
+	if _, ok := bigAlgorithms[name]; ok && !cancellableBigAlgorithms[name] {
+		limit = min(limit, serverUncancellableMaxN)
+	}
+	return limit
+}
+
+// uncancellable adapts a big-integer algorithm that runs to completion once started
+func uncancellable(fn func(uint64) *big.Int) func(context.Context, uint64) (*big.Int, error) {
+	return func(ctx context.Context, n uint64) (*big.Int, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return fn(n), nil
+	}
+}
+
+// apiResult is the outcome of one computation of the server modes
+type apiResult struct {
+	N         uint64 `json:"n"`
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"` // decimal, exact
+	ElapsedNS int64  `json:"elapsed_ns"`
+}
+
+// apiError is the body of a failed request
+type apiError struct {
+	Status C.fib_status `json:"status"`
+	Error  string       `json:"error"`
+}
+
+// computeNamed calculates F(n) with the algorithm called name for the server modes
+// uint64 algorithms refuse n above their max_safe_n with StatusOverflow instead
+// of wrapping. n is capped by max_n and by serverMaxN, or serverUncancellableMaxN
+// for the big-integer algorithms other than big_doubling, the only one that
+// honours timeout_ms and stops when ctx is done.
+func computeNamed(ctx context.Context, name string, n uint64) (apiResult, C.fib_status, error) {
+	if name == "" {
+		name = defaultServerAlgorithm
+	}
+	r := apiResult{N: n, Algorithm: name}
+	if limit := serverLimit(name); n > limit {
+		return r, StatusLimitExceeded, fmt.Errorf("n = %d exceeds the limit of %s, %d", n, name, limit)
+	}
+	if _, ok := bigAlgorithms[name]; ok {
+		z, elapsed, status, err := computeBigNamed(ctx, name, n)
+		r.ElapsedNS = elapsed.Nanoseconds()
+		if status != StatusOK {
+			return r, status, err
+		}
+		r.Value = z.String()
+		return r, StatusOK, nil
+	}
+	algo, ok := algorithmByName(name)
+	if !ok {
+		return r, StatusInvalidArg, fmt.Errorf("unknown algorithm %q", name)
+	}
+	if n > algo.MaxSafeN {
+		return r, StatusOverflow, fmt.Errorf("F(%d) does not fit in %s's uint64, max_safe_n is %d", n, name, algo.MaxSafeN)
+	}
+	span := startSpan("server", name, n)
+	start := time.Now()
+	value, status := computeAlgorithm(algo.ID, algo.fn, n)
+	r.ElapsedNS = time.Since(start).Nanoseconds()
+	span.end(bits.Len64(value), status)
+	if status != StatusOK {
+		return r, status, fmt.Errorf("%s", statusText(status))
+	}
+	r.Value = strconv.FormatUint(value, 10)
+	return r, StatusOK, nil
+}
+
+// computeBigNamed calculates F(n) with the big-integer algorithm called name, applying serverLimit and timeout_ms
+func computeBigNamed(ctx context.Context, name string, n uint64) (*big.Int, time.Duration, C.fib_status, error) {
+	if name == "" {
+		name = defaultServerAlgorithm
+	}
+	fn, ok := bigAlgorithms[name]
+	if !ok {
+		return nil, 0, StatusInvalidArg, fmt.Errorf("unknown big-integer algorithm %q", name)
+	}
+	if limit := serverLimit(name); n > limit {
+		return nil, 0, StatusLimitExceeded, fmt.Errorf("n = %d exceeds the limit of %s, %d", n, name, limit)
+	}
+	if ms := callTimeoutMS.Load(); ms > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, millis(ms))
+		defer cancel()
+	}
+	span := startSpan("server", name, n)
+	start := time.Now()
+	z, err := fn(ctx, n)
+	elapsed := time.Since(start)
+	if err != nil {
+		span.end(0, statusOf(err))
+		return nil, elapsed, statusOf(err), err
+	}
+	span.end(z.BitLen(), StatusOK)
+	return z, elapsed, StatusOK, nil
+}
+
+// algorithmByName returns the registry entry called name
+func algorithmByName(name string) (algorithmInfo, bool) {
+	for _, a := range registeredAlgorithms() {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return algorithmInfo{}, false
+}
+
+// httpStatusOf maps a failing library status to an HTTP status code
+func httpStatusOf(status C.fib_status) int {
+	switch status {
+	case StatusInvalidArg, StatusNotFound:
+		return http.StatusBadRequest
+	case StatusOverflow, StatusLimitExceeded:
+		return http.StatusUnprocessableEntity
+	case StatusTimeout:
+		return http.StatusGatewayTimeout
+	case StatusCancelled:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON sends v with the given HTTP status
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// newAPIMux routes the endpoints of StartHTTPServer
+func newAPIMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /fib/{n}", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.ParseUint(r.PathValue("n"), 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, apiError{StatusInvalidArg, "n must be an unsigned 64-bit integer"})
+			return
+		}
+		result, status, err := computeNamed(r.Context(), r.URL.Query().Get("algo"), n)
+		if status != StatusOK {
+			writeJSON(w, httpStatusOf(status), apiError{status, err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	})
+	mux.HandleFunc("GET /algorithms", func(w http.ResponseWriter, r *http.Request) {
+		registry := registeredAlgorithms()
+		names := make([]string, 0, len(registry)+len(bigAlgorithms))
+		for _, a := range registry {
+			names = append(names, a.Name)
+		}
+		for name := range bigAlgorithms {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		writeJSON(w, http.StatusOK, names)
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		report := health()
+		code := http.StatusOK
+		if !report.Healthy {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, report)
+	})
+	addStreamRoutes(mux)
+	addGoldenRoutes(mux)
+	return mux
+}
+
+// apiServer is the REST server started by StartHTTPServer
+var apiServer struct {
+	sync.Mutex
+	srv *http.Server
+}
+
+// StartHTTPServer serves the library over HTTP on addr ("host:port") until StopHTTPServer
+// GET /fib/{n}?algo=doubling returns {"n", "algorithm", "value", "elapsed_ns"}
+// with the value as a decimal string and elapsed_ns covering the computation
+// only; algo is a ListAlgorithms name (refusing n past max_safe_n instead of
+// wrapping) or big_doubling, big_iterative, big_matrix, big_doubling_parallel,
+// big_doubling_square, limb_doubling, big_binet or auto, with big_doubling the
+// default. n is capped at max_n and 10,000,000, or 1,000,000 for the big-integer
+// algorithms other than big_doubling, which ignore timeout_ms. GET /algorithms lists
+// the names and GET /healthz returns GetHealthJSON. GET /sequence?from=&to=
+// streams F(from..to) as JSON lines and GET /fib/{n}/stream?algo=&format=&chunk_size=
+// sends a big-integer F(n) as a chunked body, decimal or "bytes" (big-endian),
+// for results too large for one message. GET /golden-ratio?digits=k returns
+// GoldenRatioDigits and GET /ratio/{n} returns {"n", "ratio", "distance"} of
+// FibRatio. Failures answer {"status", "error"}
+// with a 4xx or 5xx code. Returns StatusInvalidArg for a
+// NULL addr, StatusInvalidState if a server is already running and
+// StatusInternal if addr cannot be listened on.
+//
+//export StartHTTPServer
+func StartHTTPServer(addr *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if addr == nil {
+		return StatusInvalidArg
+	}
+	apiServer.Lock()
+	defer apiServer.Unlock()
+	if apiServer.srv != nil {
+		return failWith(StatusInvalidState, "StartHTTPServer: already serving on %s", apiServer.srv.Addr)
+	}
+	srv, err := startHTTPServer("http", C.GoString(addr), newAPIMux())
+	if err != nil {
+		return failWith(StatusInternal, "http server: %v", err)
+	}
+	apiServer.srv = srv
+	return StatusOK
+}
+
+// StopHTTPServer stops the server started by StartHTTPServer
+// Running requests get a few seconds to finish. Returns StatusInvalidState if no server is running.
+//
+//export StopHTTPServer
+func StopHTTPServer() (status C.fib_status) {
+	defer recoverStatus(&status)
+	apiServer.Lock()
+	defer apiServer.Unlock()
+	if apiServer.srv == nil {
+		return failWith(StatusInvalidState, "StopHTTPServer: no server is running")
+	}
+	stopHTTPServer(apiServer.srv)
+	apiServer.srv = nil
+	return StatusOK
+}
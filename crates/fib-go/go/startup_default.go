@@ -0,0 +1,6 @@
+//go:build !fib_deferred
+
+package main
+
+// deferredStartup is set by the fib_deferred build tag (see startup_deferred.go)
+const deferredStartup = false
@@ -0,0 +1,10 @@
+//go:build fib_deferred
+
+package main
+
+// deferredStartup parks the runtime from load until FibInit, so a host that
+// statically links the c-archive does not see GC or scheduler activity before
+// it chooses to initialize the library. Go offers no hook to postpone the
+// runtime bootstrap itself: it still runs on its own thread when the archive
+// is loaded, and GetRuntimeState reports when it is done.
+const deferredStartup = true
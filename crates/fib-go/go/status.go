@@ -0,0 +1,22 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+// Status codes returned by the exports that can fail (see fib_types.h)
+const (
+	StatusOK             C.fib_status = C.FIB_STATUS_OK
+	StatusOverflow       C.fib_status = C.FIB_STATUS_OVERFLOW
+	StatusInvalidArg     C.fib_status = C.FIB_STATUS_INVALID_ARG
+	StatusLimitExceeded  C.fib_status = C.FIB_STATUS_LIMIT_EXCEEDED
+	StatusNotFound       C.fib_status = C.FIB_STATUS_NOT_FOUND
+	StatusBufferTooSmall C.fib_status = C.FIB_STATUS_BUFFER_TOO_SMALL
+	StatusInternal       C.fib_status = C.FIB_STATUS_INTERNAL
+	StatusInvalidState   C.fib_status = C.FIB_STATUS_INVALID_STATE
+	StatusCancelled      C.fib_status = C.FIB_STATUS_CANCELLED
+	StatusTimeout        C.fib_status = C.FIB_STATUS_TIMEOUT
+	StatusInexact        C.fib_status = C.FIB_STATUS_INEXACT
+)
@@ -0,0 +1,147 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// The result store is a file of newline-delimited JSON records, one per saved run.
+// It keeps the library free of a database dependency while staying easy to
+// inspect, append to from several sessions and load into pandas.
+
+// storedRun is one record of the result store
+type storedRun struct {
+	SavedAt  time.Time       `json:"saved_at"`
+	Revision string          `json:"revision"`
+	Host     string          `json:"host"`
+	Report   json.RawMessage `json:"report"`
+}
+
+// storeMu serializes the store accesses of this process
+var storeMu sync.Mutex
+
+// SaveRun appends a benchmark report to the result store at path, keyed by git revision and host
+// reportJSON is any JSON document, typically from RunBenchmarkMatrix; host may be
+// NULL for the machine name. The store is created if missing. Returns
+// StatusInvalidArg for NULL arguments or a report that is not valid JSON.
+//
+//export SaveRun
+func SaveRun(path, revision, host, reportJSON *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if path == nil || revision == nil || reportJSON == nil {
+		return StatusInvalidArg
+	}
+	run := storedRun{SavedAt: time.Now().UTC(), Revision: C.GoString(revision), Host: machineName()}
+	if host != nil {
+		run.Host = C.GoString(host)
+	}
+	report := []byte(C.GoString(reportJSON))
+	if !json.Valid(report) {
+		return failWith(StatusInvalidArg, "SaveRun: the report is not valid JSON")
+	}
+	var compact bytes.Buffer
+	json.Compact(&compact, report)
+	run.Report = compact.Bytes()
+	if err := appendRun(C.GoString(path), run); err != nil {
+		return failWith(StatusInternal, "SaveRun: %v", err)
+	}
+	return StatusOK
+}
+
+// appendRun writes run as one line at the end of the store
+func appendRun(path string, run storedRun) error {
+	line, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	// A single write keeps lines whole when other processes append too
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// QueryRuns returns the runs of the result store at path matching revision and host as a JSON array
+// A NULL or empty revision or host matches every run; runs keep the order they were
+// saved in. Returns NULL and records StatusNotFound if the store does not exist.
+// The string is owned by the caller and must be released with FreeCString.
+//
+//export QueryRuns
+func QueryRuns(path, revision, host *C.char) *C.char {
+	defer recoverPanic()
+	return documentCString(queryRunsDocument(path, revision, host))
+}
+
+// queryRunsDocument encodes the runs QueryRuns returns
+func queryRunsDocument(path, revision, host *C.char) (string, C.fib_status, error) {
+	if path == nil {
+		return "", StatusInvalidArg, errors.New("QueryRuns: NULL path")
+	}
+	var rev, hostName string
+	if revision != nil {
+		rev = C.GoString(revision)
+	}
+	if host != nil {
+		hostName = C.GoString(host)
+	}
+	runs, err := queryRunsGo(C.GoString(path), rev, hostName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", StatusNotFound, err
+	}
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	data, err := json.Marshal(runs)
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(data), StatusOK, nil
+}
+
+// queryRunsGo loads the runs of the store matching revision and host, "" matching any
+func queryRunsGo(path, revision, host string) ([]storedRun, error) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	runs := []storedRun{}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<30)
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var run storedRun
+		if err := json.Unmarshal(sc.Bytes(), &run); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if (revision == "" || run.Revision == revision) && (host == "" || run.Host == host) {
+			runs = append(runs, run)
+		}
+	}
+	return runs, sc.Err()
+}
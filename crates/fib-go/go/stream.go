@@ -0,0 +1,208 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+const (
+	// defaultChunkSize is the chunk size of StreamBigResult unless the request sets one
+	defaultChunkSize = 64 << 10
+	// maxChunkSize keeps a chunk well under the 4 MiB gRPC message limit
+	maxChunkSize = 1 << 20
+)
+
+// errStreamCancelled and errStreamTimeout end a stream whose context is done
+var (
+	errStreamCancelled = errors.New("stream cancelled")
+	errStreamTimeout   = errors.New("stream deadline exceeded")
+)
+
+// streamContextStatus converts a done context into a status and error
+func streamContextStatus(ctx context.Context) (C.fib_status, error) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return StatusTimeout, errStreamTimeout
+	}
+	return StatusCancelled, errStreamCancelled
+}
+
+// streamSequence calls yield with F(from), F(from+1), ..., F(to) as exact decimals
+// At most maxStreamItems values are produced per call and to is bounded like the
+// n of the other server endpoints, by max_n and serverMaxN.
+// An error of yield, such as a gone client, ends the stream with StatusCancelled.
+func streamSequence(ctx context.Context, from, to uint64, yield func(n uint64, value string) error) (C.fib_status, error) {
+	switch {
+	case from > to:
+		return StatusInvalidArg, fmt.Errorf("from = %d is after to = %d", from, to)
+	case to-from >= maxStreamItems:
+		return StatusLimitExceeded, fmt.Errorf("at most %d items per call", maxStreamItems)
+	case to > serverLimit(""):
+		return StatusLimitExceeded, fmt.Errorf("to = %d exceeds the server limit %d", to, serverLimit(""))
+	}
+	a, b := fib.BigPair(from)
+	for n := from; ; n++ {
+		if ctx.Err() != nil {
+			return streamContextStatus(ctx)
+		}
+		if err := yield(n, a.String()); err != nil {
+			return StatusCancelled, err
+		}
+		if n == to {
+			return StatusOK, nil
+		}
+		a, b = b, new(big.Int).Add(a, b)
+	}
+}
+
+// bigResultFormats encode a big-integer result for streamBigResult
+var bigResultFormats = map[string]func(*big.Int) []byte{
+	"decimal": func(z *big.Int) []byte { return z.Append(nil, 10) },
+	"bytes":   (*big.Int).Bytes, // big-endian magnitude
+}
+
+// streamBigResult computes F(n) with the big-integer algorithm name and calls yield with its encoding
+// format is "decimal" (the default) or "bytes"; every chunk but the last has
+// chunkSize bytes (defaultChunkSize if 0). total is the size of the whole encoding.
+func streamBigResult(ctx context.Context, name string, n uint64, format string, chunkSize int, yield func(offset, total int, chunk []byte) error) (C.fib_status, error) {
+	if format == "" {
+		format = "decimal"
+	}
+	encode, ok := bigResultFormats[format]
+	switch {
+	case !ok:
+		return StatusInvalidArg, fmt.Errorf("format must be \"decimal\" or \"bytes\", got %q", format)
+	case chunkSize < 0 || chunkSize > maxChunkSize:
+		return StatusInvalidArg, fmt.Errorf("chunk_size must be in [1, %d]", maxChunkSize)
+	case chunkSize == 0:
+		chunkSize = defaultChunkSize
+	}
+	z, _, status, err := computeBigNamed(ctx, name, n)
+	if status != StatusOK {
+		return status, err
+	}
+	data := encode(z)
+	for offset := 0; offset < len(data) || offset == 0; offset += chunkSize {
+		if ctx.Err() != nil {
+			return streamContextStatus(ctx)
+		}
+		if err := yield(offset, len(data), data[offset:min(offset+chunkSize, len(data))]); err != nil {
+			return StatusCancelled, err
+		}
+	}
+	return StatusOK, nil
+}
+
+// queryUint parses an optional unsigned query parameter
+func queryUint(r *http.Request, key string, def uint64) (uint64, error) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return def, nil
+	}
+	v, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an unsigned 64-bit integer", key)
+	}
+	return v, nil
+}
+
+// addStreamRoutes registers the streaming endpoints of StartHTTPServer
+func addStreamRoutes(mux *http.ServeMux) {
+	// GET /sequence?from=&to= streams one {"n", "value"} JSON line per item; a
+	// failure after the first line ends the stream with a {"status", "error"} line.
+	mux.HandleFunc("GET /sequence", func(w http.ResponseWriter, r *http.Request) {
+		from, err := queryUint(r, "from", 0)
+		var to uint64
+		if err == nil {
+			to, err = queryUint(r, "to", from)
+		}
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, apiError{StatusInvalidArg, err.Error()})
+			return
+		}
+		flusher, _ := w.(http.Flusher)
+		enc := json.NewEncoder(w)
+		started := false
+		status, err := streamSequence(r.Context(), from, to, func(n uint64, value string) error {
+			if !started {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				started = true
+			}
+			if err := enc.Encode(sequenceItem{n, value}); err != nil {
+				return err
+			}
+			if flusher != nil && (n-from)%64 == 63 {
+				flusher.Flush()
+			}
+			return nil
+		})
+		switch {
+		case status == StatusOK:
+		case !started:
+			writeJSON(w, httpStatusOf(status), apiError{status, err.Error()})
+		default:
+			enc.Encode(apiError{status, err.Error()})
+		}
+	})
+
+	// GET /fib/{n}/stream?algo=&format=&chunk_size= sends F(n) as a chunked body,
+	// flushed chunk by chunk; a failure after the headers aborts the response.
+	mux.HandleFunc("GET /fib/{n}/stream", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.ParseUint(r.PathValue("n"), 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, apiError{StatusInvalidArg, "n must be an unsigned 64-bit integer"})
+			return
+		}
+		chunkSize, err := queryUint(r, "chunk_size", 0)
+		if err != nil || chunkSize > maxChunkSize {
+			writeJSON(w, http.StatusBadRequest, apiError{StatusInvalidArg, fmt.Sprintf("chunk_size must be in [1, %d]", maxChunkSize)})
+			return
+		}
+		query := r.URL.Query()
+		flusher, _ := w.(http.Flusher)
+		started := false
+		status, err := streamBigResult(r.Context(), query.Get("algo"), n, query.Get("format"), int(chunkSize), func(offset, total int, chunk []byte) error {
+			if !started {
+				contentType := "text/plain; charset=us-ascii"
+				if query.Get("format") == "bytes" {
+					contentType = "application/octet-stream"
+				}
+				w.Header().Set("Content-Type", contentType)
+				w.Header().Set("Content-Length", strconv.Itoa(total))
+				started = true
+			}
+			if _, err := w.Write(chunk); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return nil
+		})
+		switch {
+		case status == StatusOK:
+		case !started:
+			writeJSON(w, httpStatusOf(status), apiError{status, err.Error()})
+		default:
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
+// sequenceItem is one line of GET /sequence
+type sequenceItem struct {
+	N     uint64 `json:"n"`
+	Value string `json:"value"`
+}
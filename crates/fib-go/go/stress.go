@@ -0,0 +1,137 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxStressThreads bounds the goroutines FibConcurrentStress may spawn
+const maxStressThreads = 4096
+
+// FibConcurrentStress runs threads goroutines that each compute F(n) iterations times with one algorithm
+// The aggregate throughput, in calls per second over the wall-clock time, is written to
+// throughput. Returns StatusInvalidArg for an unknown algorithm, zero counts or a NULL
+// out-parameter, and StatusLimitExceeded above 4096 threads or when an algorithm refuses n.
+//
+//export FibConcurrentStress
+func FibConcurrentStress(algorithmID C.fib_algorithm, n, threads, iterations C.uint64_t, throughput *C.double) (status C.fib_status) {
+	defer recoverStatus(&status)
+	fn := algorithmFunc(algorithmID)
+	if fn == nil || threads == 0 || iterations == 0 || throughput == nil {
+		return StatusInvalidArg
+	}
+	if threads > maxStressThreads {
+		return StatusLimitExceeded
+	}
+	if _, status := computeAlgorithm(algorithmID, fn, uint64(n)); status != StatusOK {
+		return status
+	}
+
+	opsPerSec := concurrentStressGo(algorithmID, fn, uint64(n), int(threads), uint64(iterations))
+	*throughput = C.double(opsPerSec)
+	return StatusOK
+}
+
+func concurrentStressGo(id C.fib_algorithm, fn func(uint64) uint64, n uint64, threads int, iterations uint64) float64 {
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		sink  atomic.Uint64 // keeps the results observable
+	)
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			var acc uint64
+			for j := uint64(0); j < iterations; j++ {
+				value, _ := computeAlgorithm(id, fn, n)
+				acc ^= value
+			}
+			sink.Add(acc)
+		}()
+	}
+
+	begin := time.Now()
+	close(start)
+	wg.Wait()
+	elapsed := time.Since(begin)
+
+	return float64(uint64(threads)*iterations) / elapsed.Seconds()
+}
+
+// RunThroughputBenchmark keeps threads goroutines computing F(n) with one algorithm for durationMs milliseconds
+// It reports the calls completed under that sustained load; threads 0 uses the
+// workers config key. result.status is StatusInvalidArg for an unknown algorithm
+// or a zero duration, StatusLimitExceeded above 4096 threads or when the algorithm refuses n.
+//
+//export RunThroughputBenchmark
+func RunThroughputBenchmark(algorithmID C.fib_algorithm, n, durationMs, threads C.uint64_t) (result C.fib_throughput_result) {
+	defer recoverStatus(&result.status)
+	fn := algorithmFunc(algorithmID)
+	if fn == nil || durationMs == 0 {
+		result.status = StatusInvalidArg
+		return result
+	}
+	workers := uint64(threads)
+	if workers == 0 {
+		workers = uint64(workerCount.Load())
+	}
+	if workers > maxStressThreads {
+		result.status = StatusLimitExceeded
+		return result
+	}
+	if _, status := computeAlgorithm(algorithmID, fn, uint64(n)); status != StatusOK {
+		result.status = status
+		return result
+	}
+
+	ops, elapsed := throughputGo(algorithmID, fn, uint64(n), int(workers), millis(uint64(durationMs)))
+	result.status = StatusOK
+	result.threads = C.uint64_t(workers)
+	result.operations = C.uint64_t(ops)
+	result.elapsed_ns = C.uint64_t(elapsed.Nanoseconds())
+	result.ops_per_sec = C.double(float64(ops) / elapsed.Seconds())
+	return result
+}
+
+// throughputGo runs the workers of RunThroughputBenchmark until duration has passed
+// Workers poll a stop flag between calls, so the wall time includes the last call of each.
+func throughputGo(id C.fib_algorithm, fn func(uint64) uint64, n uint64, threads int, duration time.Duration) (uint64, time.Duration) {
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		stop  atomic.Bool
+		total atomic.Uint64
+		sink  atomic.Uint64 // keeps the results observable
+	)
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			var ops, acc uint64
+			for !stop.Load() {
+				value, _ := computeAlgorithm(id, fn, n)
+				acc ^= value
+				ops++
+			}
+			total.Add(ops)
+			sink.Add(acc)
+		}()
+	}
+
+	begin := time.Now()
+	close(start)
+	timer := time.AfterFunc(duration, func() { stop.Store(true) })
+	wg.Wait()
+	timer.Stop()
+	return total.Load(), time.Since(begin)
+}
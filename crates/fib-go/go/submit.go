@@ -0,0 +1,65 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+)
+
+// lastJobID is the id handed out by the latest FibSubmit or FibJobSubmit; ids start at 1
+var lastJobID atomic.Uint64
+
+// submitSlots bounds the FibSubmit jobs whose callback has not returned yet to maxJobs
+var submitSlots = make(chan struct{}, maxJobs)
+
+// FibSubmit starts F(n) with algorithmID on a Go-managed worker and returns its job id at once
+// callback receives (job_id, status, result, userdata) exactly once, on a thread
+// owned by the library, after the computation ends; it may run before FibSubmit
+// returns. Returns 0 and records StatusInvalidArg for an unknown algorithm or a
+// NULL callback, or StatusLimitExceeded while 4096 submitted jobs are still
+// running, in which case callback is never invoked.
+//
+//export FibSubmit
+func FibSubmit(algorithmID C.fib_algorithm, n C.uint64_t, callback C.fib_job_callback, userdata unsafe.Pointer) C.uint64_t {
+	defer recoverPanic()
+	fn := algorithmFunc(algorithmID)
+	if fn == nil {
+		setLastError(C.int32_t(StatusInvalidArg), fmt.Sprintf("unknown algorithm %d", algorithmID))
+		return 0
+	}
+	if callback == nil {
+		setLastError(C.int32_t(StatusInvalidArg), "FibSubmit: callback is NULL")
+		return 0
+	}
+	select {
+	case submitSlots <- struct{}{}:
+	default:
+		setLastError(C.int32_t(StatusLimitExceeded), fmt.Sprintf("FibSubmit: %d jobs are still running", maxJobs))
+		return 0
+	}
+	id := lastJobID.Add(1)
+	go func() {
+		defer func() { <-submitSlots }()
+		value, status := computeJob(algorithmID, fn, uint64(n))
+		callJobFn(callback, id, status, value, userdata)
+	}()
+	return C.uint64_t(id)
+}
+
+// computeJob is computeAlgorithm reporting a panic as StatusInternal instead of crashing the worker
+// The status reaches the callback only; no foreign thread owns a last error here.
+func computeJob(id C.fib_algorithm, fn func(uint64) uint64, n uint64) (value uint64, status C.fib_status) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("FibSubmit job panicked", "panic", r)
+			value, status = 0, StatusInternal
+		}
+	}()
+	return computeAlgorithm(id, fn, n)
+}
@@ -0,0 +1,94 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// checkedSum stores the sum fn computes for n into out, refusing a wrapped sum
+func checkedSum(n C.uint64_t, out *C.uint64_t, fn func(uint64) (uint64, bool)) C.fib_status {
+	if out == nil {
+		return StatusInvalidArg
+	}
+	value, ok := fn(uint64(n))
+	if !ok {
+		return StatusOverflow
+	}
+	*out = C.uint64_t(value)
+	return StatusOK
+}
+
+// FibSum calculates F(0) + F(1) + ... + F(n) = F(n+2) - 1 - O(log n)
+// Stores the sum into out and returns StatusOK, StatusOverflow above n = 91 or StatusInvalidArg.
+//
+//export FibSum
+func FibSum(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return checkedSum(n, out, fib.Sum)
+}
+
+// FibSumEven calculates the sum of the even-indexed terms F(0) + F(2) + ... + F(2n) = F(2n+1) - 1
+// Stores the sum into out and returns StatusOK, StatusOverflow above n = 46 or StatusInvalidArg.
+//
+//export FibSumEven
+func FibSumEven(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return checkedSum(n, out, fib.SumEven)
+}
+
+// FibSumOdd calculates the sum of the odd-indexed terms F(1) + F(3) + ... + F(2n-1) = F(2n)
+// Stores the sum into out and returns StatusOK, StatusOverflow above n = 46 or StatusInvalidArg.
+//
+//export FibSumOdd
+func FibSumOdd(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return checkedSum(n, out, fib.SumOdd)
+}
+
+// FibSumSquares calculates F(0)² + F(1)² + ... + F(n)² = F(n) * F(n+1)
+// Stores the sum into out and returns StatusOK, StatusOverflow above n = 47 or StatusInvalidArg.
+//
+//export FibSumSquares
+func FibSumSquares(n C.uint64_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return checkedSum(n, out, fib.SumSquares)
+}
+
+// FibBigSum is the big-integer variant of FibSum, exact for every n
+// Returns a decimal C string owned by the caller; release it with FreeCString.
+//
+//export FibBigSum
+func FibBigSum(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	return C.CString(tracedBig("FibBigSum", "big_sum", uint64(n), fib.BigSum).String())
+}
+
+// FibBigSumEven is the big-integer variant of FibSumEven, exact for every n
+// Returns a decimal C string owned by the caller; release it with FreeCString.
+//
+//export FibBigSumEven
+func FibBigSumEven(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	return C.CString(tracedBig("FibBigSumEven", "big_sum_even", uint64(n), fib.BigSumEven).String())
+}
+
+// FibBigSumOdd is the big-integer variant of FibSumOdd, exact for every n
+// Returns a decimal C string owned by the caller; release it with FreeCString.
+//
+//export FibBigSumOdd
+func FibBigSumOdd(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	return C.CString(tracedBig("FibBigSumOdd", "big_sum_odd", uint64(n), fib.BigSumOdd).String())
+}
+
+// FibBigSumSquares is the big-integer variant of FibSumSquares, exact for every n
+// Returns a decimal C string owned by the caller; release it with FreeCString.
+//
+//export FibBigSumSquares
+func FibBigSumSquares(n C.uint64_t) *C.char {
+	defer recoverPanic()
+	return C.CString(tracedBig("FibBigSumSquares", "big_sum_squares", uint64(n), fib.BigSumSquares).String())
+}
@@ -0,0 +1,93 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// telemetryEnabled keeps the call counters of metrics.go running, set by the telemetry config key
+var telemetryEnabled atomic.Bool
+
+// setTelemetry turns the call counters on or off, keeping them on while the metrics server runs
+func setTelemetry(on bool) {
+	metricsServer.Lock()
+	defer metricsServer.Unlock()
+	telemetryEnabled.Store(on)
+	refreshMetricsEnabled()
+}
+
+// algorithmTelemetry is the entry of one algorithm called at least once
+type algorithmTelemetry struct {
+	Algorithm string `json:"algorithm"`
+	Calls     uint64 `json:"calls"`
+	Failures  uint64 `json:"failures"`
+	ComputeNS uint64 `json:"compute_ns"`
+}
+
+// telemetrySnapshot is the JSON object returned by GetTelemetryJSON
+type telemetrySnapshot struct {
+	Enabled        bool                 `json:"enabled"`
+	Algorithms     []algorithmTelemetry `json:"algorithms"`
+	Calls          uint64               `json:"calls"`
+	ComputeNS      uint64               `json:"compute_ns"`
+	ErrorsByStatus map[int]uint64       `json:"errors_by_status"`
+	MemoHits       uint64               `json:"memo_hits"`
+	MemoMisses     uint64               `json:"memo_misses"`
+	BigResults     uint64               `json:"big_results"`
+	BigResultBytes uint64               `json:"big_result_bytes"`
+}
+
+// GetTelemetryJSON returns the cumulative call counters of the process as a JSON object
+// Calls, failures and compute time per algorithm, failures by status code and
+// big-integer results with their bytes are counted while the telemetry config
+// key is on (or StartMetricsServer runs), at the cost of two clock reads per
+// dispatched call; the shared memo hits and misses are always counted. The
+// string must be released with FreeCString.
+//
+//export GetTelemetryJSON
+func GetTelemetryJSON() *C.char {
+	defer recoverPanic()
+	data, err := json.Marshal(telemetry())
+	if err != nil {
+		setLastError(C.int32_t(StatusInternal), err.Error())
+		return nil
+	}
+	return C.CString(string(data))
+}
+
+// telemetry reads the counters behind GetTelemetryJSON
+func telemetry() telemetrySnapshot {
+	s := telemetrySnapshot{
+		Enabled:        metricsEnabled.Load(),
+		Algorithms:     []algorithmTelemetry{},
+		ErrorsByStatus: map[int]uint64{},
+		BigResults:     bigResults.Load(),
+		BigResultBytes: bigResultBytes.Load(),
+	}
+	for _, a := range registeredAlgorithms() {
+		m := &callMetrics[a.ID]
+		calls := m.calls.Load()
+		if calls == 0 {
+			continue
+		}
+		t := algorithmTelemetry{a.Name, calls, m.failures.Load(), m.sumNS.Load()}
+		s.Algorithms = append(s.Algorithms, t)
+		s.Calls += t.Calls
+		s.ComputeNS += t.ComputeNS
+	}
+	for code := range statusCounts {
+		if count := statusCounts[code].Load(); count > 0 {
+			s.ErrorsByStatus[code] = count
+		}
+	}
+	s.MemoHits, s.MemoMisses = fib.MemoStats()
+	return s
+}
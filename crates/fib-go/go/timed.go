@@ -0,0 +1,32 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import "time"
+
+// FibTimed calculates F(n) with the given algorithm and reports how long the computation took
+// The value wraps like the single-value exports; status is StatusInvalidArg for an unknown
+// algorithm and StatusLimitExceeded when the recursion cutoff refuses n.
+//
+//export FibTimed
+func FibTimed(algorithmID C.fib_algorithm, n C.uint64_t) (result C.fib_timed_result) {
+	defer recoverStatus(&result.status)
+	fn := algorithmFunc(algorithmID)
+	if fn == nil {
+		result.status = StatusInvalidArg
+		return result
+	}
+
+	start := time.Now()
+	value, status := computeAlgorithm(algorithmID, fn, uint64(n))
+	elapsed := time.Since(start)
+
+	result.value = C.uint64_t(value)
+	result.status = status
+	result.elapsed_ns = C.uint64_t(elapsed.Nanoseconds())
+	return result
+}
@@ -0,0 +1,69 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"os"
+	"runtime/trace"
+	"sync"
+)
+
+// executionTrace is the runtime/trace capture started by TraceStart
+var executionTrace struct {
+	sync.Mutex
+	file *os.File
+}
+
+// TraceStart begins writing a Go execution trace to the file at path, replacing it
+// The trace shows goroutine scheduling, cgo calls, syscalls and GC phases until
+// TraceStop; open it with `go tool trace <path>`. Returns StatusInvalidArg for a
+// NULL path, StatusInvalidState if a trace is already running (from this library
+// or another runtime/trace user) and StatusInternal if the file cannot be created.
+//
+//export TraceStart
+func TraceStart(path *C.char) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if path == nil {
+		return StatusInvalidArg
+	}
+	executionTrace.Lock()
+	defer executionTrace.Unlock()
+	if executionTrace.file != nil || trace.IsEnabled() {
+		return failWith(StatusInvalidState, "TraceStart: a trace is already running")
+	}
+	f, err := os.Create(C.GoString(path))
+	if err != nil {
+		return failWith(StatusInternal, "trace: %v", err)
+	}
+	if err := trace.Start(f); err != nil {
+		f.Close()
+		return failWith(StatusInvalidState, "trace: %v", err)
+	}
+	executionTrace.file = f
+	return StatusOK
+}
+
+// TraceStop ends the trace started by TraceStart and closes its file
+// Returns StatusInvalidState if no trace is running and StatusInternal if the
+// file could not be written completely.
+//
+//export TraceStop
+func TraceStop() (status C.fib_status) {
+	defer recoverStatus(&status)
+	executionTrace.Lock()
+	defer executionTrace.Unlock()
+	if executionTrace.file == nil {
+		return failWith(StatusInvalidState, "TraceStop: no trace is running")
+	}
+	trace.Stop()
+	err := executionTrace.file.Close()
+	executionTrace.file = nil
+	if err != nil {
+		return failWith(StatusInternal, "trace: %v", err)
+	}
+	return StatusOK
+}
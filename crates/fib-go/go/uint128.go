@@ -0,0 +1,47 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// store128 validates the out-parameters and n, then stores fn(n) as two halves
+func store128(n C.uint64_t, hi, lo *C.uint64_t, fn func(uint64) fib.Uint128) C.fib_status {
+	if hi == nil || lo == nil {
+		return StatusInvalidArg
+	}
+	if uint64(n) > fib.MaxSafeN128 {
+		return StatusOverflow
+	}
+	value := fn(uint64(n))
+	*hi, *lo = C.uint64_t(value.Hi), C.uint64_t(value.Lo)
+	return StatusOK
+}
+
+// Fib128Iterative calculates F(n) for n <= 186 with two-limb arithmetic - O(n)
+// Stores the high and low 64-bit halves; returns StatusOverflow for n > 186.
+//
+//export Fib128Iterative
+func Fib128Iterative(n C.uint64_t, hi, lo *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return store128(n, hi, lo, fib.Iterative128)
+}
+
+// Fib128Matrix calculates F(n) for n <= 186 using two-limb matrix exponentiation - O(log n)
+//
+//export Fib128Matrix
+func Fib128Matrix(n C.uint64_t, hi, lo *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return store128(n, hi, lo, fib.Matrix128)
+}
+
+// Fib128Doubling calculates F(n) for n <= 186 using two-limb fast doubling - O(log n)
+//
+//export Fib128Doubling
+func Fib128Doubling(n C.uint64_t, hi, lo *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	return store128(n, hi, lo, fib.Doubling128)
+}
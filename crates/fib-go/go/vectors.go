@@ -0,0 +1,229 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// testVector is one golden value, F(N) = Expected
+type testVector struct {
+	N        uint64 `json:"n"`
+	Expected string `json:"expected"` // decimal
+}
+
+// vectorMismatch is a vector an algorithm got wrong
+type vectorMismatch struct {
+	N        uint64 `json:"n"`
+	Expected string `json:"expected"`
+	Got      string `json:"got"`
+}
+
+// vectorReport is the VerifyVectors document
+type vectorReport struct {
+	Algorithm  string           `json:"algorithm"`
+	Source     string           `json:"source"`
+	Vectors    int              `json:"vectors"`
+	Checked    int              `json:"checked"`
+	Skipped    int              `json:"skipped"` // past max_safe_n, the recursion cutoff or max_n
+	Passed     bool             `json:"passed"`
+	Mismatches []vectorMismatch `json:"mismatches"`
+}
+
+// loadedVectors holds the vectors of the last successful LoadTestVectors
+var loadedVectors struct {
+	sync.Mutex
+	source  string
+	vectors []testVector
+}
+
+// parseTestVectors reads vectors from data, a JSON array of {"n", "expected"}
+// objects if it starts with '[' and CSV rows "n,expected" otherwise. CSV lines
+// starting with '#' are comments and a first row "n,expected" is a header.
+// The result is sorted by n; a repeated n is an error.
+func parseTestVectors(data []byte) ([]testVector, error) {
+	var vectors []testVector
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := decodeStrict(string(trimmed), &vectors); err != nil {
+			return nil, err
+		}
+	} else {
+		r := csv.NewReader(bytes.NewReader(data))
+		r.Comment = '#'
+		r.FieldsPerRecord = 2
+		r.TrimLeadingSpace = true
+		for first := true; ; first = false {
+			row, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if first && strings.EqualFold(row[0], "n") {
+				continue
+			}
+			n, err := strconv.ParseUint(row[0], 10, 64)
+			if err != nil {
+				line, _ := r.FieldPos(0)
+				return nil, fmt.Errorf("line %d: n: %w", line, err)
+			}
+			vectors = append(vectors, testVector{n, row[1]})
+		}
+	}
+	if len(vectors) == 0 {
+		return nil, errors.New("no test vectors")
+	}
+	for _, v := range vectors {
+		if v.Expected == "" || strings.TrimLeft(v.Expected, "0123456789") != "" {
+			return nil, fmt.Errorf("F(%d): expected %q is not a decimal integer", v.N, v.Expected)
+		}
+	}
+	slices.SortStableFunc(vectors, func(a, b testVector) int { return cmp.Compare(a.N, b.N) })
+	for i := 1; i < len(vectors); i++ {
+		if vectors[i].N == vectors[i-1].N {
+			return nil, fmt.Errorf("F(%d) is listed twice", vectors[i].N)
+		}
+	}
+	return vectors, nil
+}
+
+// verifyVectorsGo checks the algorithm called name against vectors
+// Any ListAlgorithms name and the big-integer names of StartHTTPServer are
+// accepted. uint64 algorithms skip the vectors past their max_safe_n and naive
+// recursion the ones past the VerifyAlgorithms cutoff; every algorithm skips
+// those past max_n, and big-integer ones those past the server modes' limit.
+func verifyVectorsGo(name string, vectors []testVector) (vectorReport, C.fib_status, error) {
+	report := vectorReport{Algorithm: name, Vectors: len(vectors), Mismatches: []vectorMismatch{}}
+	check := func(v testVector, got string) {
+		report.Checked++
+		if got != v.Expected {
+			report.Mismatches = append(report.Mismatches, vectorMismatch{v.N, v.Expected, got})
+		}
+	}
+	maxN := dispatchMaxN.Load()
+	if _, ok := bigAlgorithms[name]; ok {
+		limit := serverLimit(name)
+		for _, v := range vectors {
+			if v.N > limit {
+				report.Skipped++
+				continue
+			}
+			z, _, status, err := computeBigNamed(context.Background(), name, v.N)
+			if status != StatusOK {
+				return report, status, fmt.Errorf("F(%d): %w", v.N, err)
+			}
+			check(v, z.String())
+		}
+	} else {
+		algo, ok := algorithmByName(name)
+		if !ok {
+			return report, StatusInvalidArg, fmt.Errorf("unknown algorithm %q", name)
+		}
+		limit := min(algo.MaxSafeN, maxN)
+		if algo.ID == AlgoRecursive {
+			limit = min(limit, recursiveMaxN.Load(), verifyRecursiveMaxN)
+		}
+		for _, v := range vectors {
+			if v.N > limit {
+				report.Skipped++
+				continue
+			}
+			check(v, strconv.FormatUint(algo.fn(v.N), 10))
+		}
+	}
+	report.Passed = len(report.Mismatches) == 0
+	return report, StatusOK, nil
+}
+
+// LoadTestVectors loads the golden test vectors VerifyVectors checks against from path
+// The file is the one shared by every implementation of this repository
+// (testdata/fib_vectors.csv): CSV rows "n,expected" with '#' comments and an
+// optional header, or a JSON array of {"n", "expected"} objects, expected being
+// F(n) in decimal. It replaces the vectors loaded before and *count, if not NULL,
+// receives their number. Returns StatusInvalidArg for a NULL path or a malformed
+// file, StatusNotFound if path does not exist and StatusInternal if it cannot be read.
+//
+//export LoadTestVectors
+func LoadTestVectors(path *C.char, count *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if path == nil {
+		return StatusInvalidArg
+	}
+	name := C.GoString(path)
+	data, err := os.ReadFile(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return failWith(StatusNotFound, "LoadTestVectors: %v", err)
+		}
+		return failWith(StatusInternal, "LoadTestVectors: %v", err)
+	}
+	vectors, err := parseTestVectors(data)
+	if err != nil {
+		return failWith(StatusInvalidArg, "LoadTestVectors: %s: %v", filepath.Base(name), err)
+	}
+	loadedVectors.Lock()
+	loadedVectors.source, loadedVectors.vectors = name, vectors
+	loadedVectors.Unlock()
+	if count != nil {
+		*count = C.uint64_t(len(vectors))
+	}
+	return StatusOK
+}
+
+// VerifyVectors checks an algorithm against the vectors of LoadTestVectors
+// algorithm is a ListAlgorithms name or big_doubling, big_iterative, big_matrix,
+// big_doubling_parallel, big_doubling_square, limb_doubling, big_binet or auto. Returns
+// {"algorithm", "source", "vectors", "checked", "skipped", "passed",
+// "mismatches": [{"n", "expected", "got"}]}; uint64 algorithms skip the vectors
+// past their max_safe_n, naive recursion the ones past n = 25, and every algorithm
+// those past max_n. The string is owned by the caller and must be released with
+// FreeCString. Returns NULL with StatusInvalidState if no vectors are loaded,
+// StatusInvalidArg for a NULL or unknown algorithm, or the status of a failed
+// big-integer computation (StatusTimeout past timeout_ms).
+//
+//export VerifyVectors
+func VerifyVectors(algorithm *C.char) *C.char {
+	defer recoverPanic()
+	return documentCString(vectorsDocument(algorithm))
+}
+
+// vectorsDocument checks algorithm against the loaded vectors and encodes the report of VerifyVectors
+func vectorsDocument(algorithm *C.char) (string, C.fib_status, error) {
+	if algorithm == nil {
+		return "", StatusInvalidArg, errors.New("VerifyVectors: NULL algorithm")
+	}
+	loadedVectors.Lock()
+	source, vectors := loadedVectors.source, loadedVectors.vectors
+	loadedVectors.Unlock()
+	if vectors == nil {
+		return "", StatusInvalidState, errors.New("VerifyVectors: no test vectors loaded, call LoadTestVectors first")
+	}
+	report, status, err := verifyVectorsGo(C.GoString(algorithm), vectors)
+	if status != StatusOK {
+		return "", status, fmt.Errorf("VerifyVectors: %v", err)
+	}
+	report.Source = source
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(data), StatusOK, nil
+}
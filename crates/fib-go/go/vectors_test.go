@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// goldenVectorsPath is the vector file shared by every implementation of the repository
+var goldenVectorsPath = filepath.Join("..", "..", "..", "testdata", "fib_vectors.csv")
+
+// vectorAlgorithmNames lists every name VerifyVectors accepts
+func vectorAlgorithmNames() []string {
+	var names []string
+	for _, a := range registeredAlgorithms() {
+		names = append(names, a.Name)
+	}
+	for name := range bigAlgorithms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestParseTestVectors(t *testing.T) {
+	csv := "# comment\nn,expected\n2,1\n0,0\n10,55\n"
+	vectors, err := parseTestVectors([]byte(csv))
+	if err != nil {
+		t.Fatalf("parseTestVectors(csv): %v", err)
+	}
+	want := []testVector{{0, "0"}, {2, "1"}, {10, "55"}}
+	if !slices.Equal(vectors, want) {
+		t.Errorf("parseTestVectors(csv) = %v, want %v", vectors, want)
+	}
+
+	vectors, err = parseTestVectors([]byte(`[{"n": 10, "expected": "55"}, {"n": 0, "expected": "0"}, {"n": 2, "expected": "1"}]`))
+	if err != nil {
+		t.Fatalf("parseTestVectors(json): %v", err)
+	}
+	if !slices.Equal(vectors, want) {
+		t.Errorf("parseTestVectors(json) = %v, want %v", vectors, want)
+	}
+
+	for _, bad := range []string{"1,1\n1,1\n", "x,1\n", "1\n", `[{"n": -1, "expected": "0"}]`} {
+		if _, err := parseTestVectors([]byte(bad)); err == nil {
+			t.Errorf("parseTestVectors(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestVerifyVectorsGolden(t *testing.T) {
+	data, err := os.ReadFile(goldenVectorsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vectors, err := parseTestVectors(data)
+	if err != nil {
+		t.Fatalf("parseTestVectors(%s): %v", goldenVectorsPath, err)
+	}
+	for _, name := range vectorAlgorithmNames() {
+		report, status, err := verifyVectorsGo(name, vectors)
+		if status != StatusOK {
+			t.Errorf("%s: status %d: %v", name, status, err)
+			continue
+		}
+		if report.Checked == 0 {
+			t.Errorf("%s: no vector checked", name)
+		}
+		if report.Checked+report.Skipped != len(vectors) {
+			t.Errorf("%s: %d checked + %d skipped, want %d vectors", name, report.Checked, report.Skipped, len(vectors))
+		}
+		if !report.Passed {
+			t.Errorf("%s: mismatches %v", name, report.Mismatches)
+		}
+	}
+}
+
+func TestVerifyVectorsMismatch(t *testing.T) {
+	vectors := []testVector{{10, "55"}, {20, "6766"}, {90, "2880067194370816120"}}
+	for _, name := range []string{"doubling", "big_doubling"} {
+		report, status, err := verifyVectorsGo(name, vectors)
+		if status != StatusOK {
+			t.Fatalf("%s: status %d: %v", name, status, err)
+		}
+		if report.Passed || len(report.Mismatches) != 1 {
+			t.Fatalf("%s: passed %v with mismatches %v, want the one of F(20)", name, report.Passed, report.Mismatches)
+		}
+		if m := report.Mismatches[0]; m.N != 20 || m.Got != "6765" {
+			t.Errorf("%s: mismatch %+v, want F(20) = 6765", name, m)
+		}
+	}
+	if _, status, _ := verifyVectorsGo("no_such_algorithm", vectors); status != StatusInvalidArg {
+		t.Errorf("unknown algorithm: status %d, want StatusInvalidArg", status)
+	}
+}
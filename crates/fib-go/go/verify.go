@@ -0,0 +1,71 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"math/big"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// lowWord returns x mod 2^64, matching the wrapping semantics of the uint64 algorithms
+func lowWord(x *big.Int) uint64 {
+	words := x.Bits()
+	if len(words) == 0 {
+		return 0
+	}
+	return uint64(words[0])
+}
+
+// verifyRecursiveMaxN keeps naive recursion from dominating the cost of VerifyAlgorithms
+const verifyRecursiveMaxN = 25
+
+// verifiedImplementations lists every Fibonacci implementation checked by VerifyAlgorithms,
+// beyond the uint64 algorithms of the dispatch table
+var verifiedImplementations = []func(uint64) uint64{
+	func(n uint64) uint64 { return lowWord(fib.BigIterative(n)) },
+	func(n uint64) uint64 { return lowWord(fib.BigMatrix(n)) },
+	func(n uint64) uint64 { return lowWord(fib.BigDoubling(n)) },
+	func(n uint64) uint64 { return fib.K(2, n) },
+	func(n uint64) uint64 { return fib.HoradamMatrix(0, 1, 1, ^uint64(0), n) },
+	func(n uint64) uint64 { return fib.HoradamDoubling(0, 1, 1, ^uint64(0), n) },
+}
+
+// VerifyAlgorithms computes F(0..maxN) with every implementation and compares them
+// Returns the first index where two implementations disagree, or -1 if all agree.
+// Naive recursion only takes part up to n = 25 (or the SetRecursiveMaxN cutoff if lower).
+//
+//export VerifyAlgorithms
+func VerifyAlgorithms(maxN C.uint64_t) C.int64_t {
+	defer recoverPanic()
+	return C.int64_t(verifyAlgorithmsGo(uint64(maxN)))
+}
+
+func verifyAlgorithmsGo(maxN uint64) int64 {
+	recursiveLimit := min(recursiveMaxN.Load(), verifyRecursiveMaxN)
+	algorithms := registeredAlgorithms()
+	for n := uint64(0); n <= maxN; n++ {
+		want := fib.Iterative(n)
+		for _, algo := range algorithms {
+			if algo.ID == AlgoRecursive && n > recursiveLimit {
+				continue
+			}
+			if algo.fn(n) != want {
+				return int64(n)
+			}
+		}
+		for _, fn := range verifiedImplementations {
+			if fn(n) != want {
+				return int64(n)
+			}
+		}
+		if n == ^uint64(0) {
+			break
+		}
+	}
+	return -1
+}
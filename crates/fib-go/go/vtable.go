@@ -0,0 +1,18 @@
+package main
+
+/*
+#include "../include/fib.h"
+#include "fib_vtable_init.h"
+
+// The initializer takes the address of every export, so it must live in a
+// file without exports, where the preamble may hold definitions.
+static const fib_vtable *fib_vtable_get(void) {
+	return &fib_vtable_instance;
+}
+*/
+import "C"
+
+// fibVTable returns the process-wide table behind GetFibVTable
+func fibVTable() *C.fib_vtable {
+	return C.fib_vtable_get()
+}
@@ -0,0 +1,159 @@
+package main
+
+/*
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// wallSunSunChunk is the number of candidates a SearchWallSunSun worker claims at a time
+const wallSunSunChunk = 4096
+
+// wallSunSunReport is the SearchWallSunSun document
+type wallSunSunReport struct {
+	Start        uint64   `json:"start_prime"`
+	End          uint64   `json:"end_prime"`
+	Workers      int      `json:"workers"`
+	Primes       uint64   `json:"primes"`
+	BigModulus   uint64   `json:"big_modulus_primes"` // primes above 2^32, whose p² needs math/big
+	WallSunSun   []uint64 `json:"wall_sun_sun_primes"`
+	ElapsedNs    int64    `json:"elapsed_ns"`
+	PrimesPerSec float64  `json:"primes_per_sec"`
+}
+
+// searchWallSunSunGo tests every prime of [start, end] on workers goroutines, checking ctx between chunks
+// A residue that p itself does not divide can only be a bug of the modular path and fails the search.
+func searchWallSunSunGo(ctx context.Context, start, end uint64, workers int) (wallSunSunReport, error) {
+	report := wallSunSunReport{Start: start, End: end, WallSunSun: []uint64{}}
+	lastChunk := (end - start) / wallSunSunChunk
+	workers = int(min(uint64(workers), lastChunk+1))
+	report.Workers = workers
+	var (
+		wg       sync.WaitGroup
+		next     atomic.Uint64 // first unclaimed chunk
+		primes   atomic.Uint64
+		bigPaths atomic.Uint64
+		mu       sync.Mutex
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+	}
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+
+	begin := time.Now()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var rem, prime big.Int
+			for {
+				k := next.Add(1) - 1
+				if k > lastChunk || failed() {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					fail(err)
+					return
+				}
+				lo := start + k*wallSunSunChunk
+				hi := lo + min(wallSunSunChunk-1, end-lo)
+				for p := lo; ; p++ {
+					if fib.IsPrime(p) {
+						primes.Add(1)
+						if p > 1<<32-1 {
+							bigPaths.Add(1)
+						}
+						_, residue := fib.WallSunSunResidue(p)
+						if rem.Mod(residue, prime.SetUint64(p)).Sign() != 0 {
+							fail(fmt.Errorf("F(p - (p/5)) mod p² = %s is not a multiple of p = %d", residue, p))
+							return
+						}
+						if residue.Sign() == 0 {
+							mu.Lock()
+							report.WallSunSun = append(report.WallSunSun, p)
+							mu.Unlock()
+						}
+					}
+					if p == hi {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(begin)
+	if firstErr != nil {
+		return report, firstErr
+	}
+
+	slices.Sort(report.WallSunSun)
+	report.Primes, report.BigModulus = primes.Load(), bigPaths.Load()
+	report.ElapsedNs = elapsed.Nanoseconds()
+	report.PrimesPerSec = float64(report.Primes) / elapsed.Seconds()
+	return report, nil
+}
+
+// SearchWallSunSun searches the primes of [startPrime, endPrime] for Wall-Sun-Sun primes
+// Each prime p, found with Baillie-PSW, has F(p - (p/5)) mod p² computed on a
+// pool of workers goroutines (0 uses the workers config key); p² moves to
+// math/big above p = 2^32. A zero residue makes p a Wall-Sun-Sun prime, of which
+// none is known. Returns {"start_prime", "end_prime", "workers", "primes",
+// "big_modulus_primes", "wall_sun_sun_primes", "elapsed_ns", "primes_per_sec"};
+// the string is owned by the caller and must be released with FreeCString.
+// Returns NULL with StatusInvalidArg if startPrime > endPrime,
+// StatusLimitExceeded above 4096 workers and StatusTimeout past timeout_ms.
+//
+//export SearchWallSunSun
+func SearchWallSunSun(startPrime, endPrime C.uint64_t, workers C.uint32_t) *C.char {
+	defer recoverPanic()
+	return documentCString(wallSunSunDocument(startPrime, endPrime, workers))
+}
+
+// wallSunSunDocument checks the arguments of SearchWallSunSun, runs the search and encodes its report
+func wallSunSunDocument(startPrime, endPrime C.uint64_t, workers C.uint32_t) (string, C.fib_status, error) {
+	if startPrime > endPrime {
+		return "", StatusInvalidArg, errors.New("SearchWallSunSun: startPrime must be <= endPrime")
+	}
+	n := int(workers)
+	if n == 0 {
+		n = int(workerCount.Load())
+	}
+	if n > maxStressThreads {
+		return "", StatusLimitExceeded, fmt.Errorf("SearchWallSunSun: workers must be <= %d", maxStressThreads)
+	}
+	ctx, cancel, _ := callContext(0)
+	defer cancel()
+	report, err := searchWallSunSunGo(ctx, uint64(startPrime), uint64(endPrime), n)
+	if err != nil {
+		return "", statusOf(err), fmt.Errorf("SearchWallSunSun: %v", err)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", StatusInternal, err
+	}
+	return string(data), StatusOK, nil
+}
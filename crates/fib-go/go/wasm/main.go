@@ -0,0 +1,144 @@
+//go:build wasip1
+
+// Command wasm builds the Fibonacci algorithms as a WASI reactor module.
+//
+// It is the WebAssembly counterpart of the cgo export layer: every function is
+// exported under a camelCase name with wasm i64/i32 parameters, so it can be
+// called from wasmtime, wasmer or JavaScript (u64 maps to BigInt) next to a
+// Rust wasm32 build of the same algorithms. Build it from the go/ directory:
+//
+//	GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o fib.wasm ./wasm
+//
+// Hosts must call the _initialize export once before any other.
+package main
+
+import "github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+
+// fibIterative calculates F(n) using the iterative method - O(n)
+//
+//go:wasmexport fibIterative
+func fibIterative(n uint64) uint64 {
+	return fib.Iterative(n)
+}
+
+// fibRecursive calculates F(n) using naive recursion - O(2^n)
+// Unlike the cgo export there is no cutoff; the host bounds n.
+//
+//go:wasmexport fibRecursive
+func fibRecursive(n uint64) uint64 {
+	return fib.Recursive(n)
+}
+
+// fibMemo calculates F(n) with the shared memo - O(n), O(1) once cached
+//
+//go:wasmexport fibMemo
+func fibMemo(n uint64) uint64 {
+	return fib.Memo(n)
+}
+
+// fibMatrix calculates F(n) using matrix exponentiation - O(log n)
+//
+//go:wasmexport fibMatrix
+func fibMatrix(n uint64) uint64 {
+	return fib.Matrix(n)
+}
+
+// fibDoubling calculates F(n) using the doubling method - O(log n)
+//
+//go:wasmexport fibDoubling
+func fibDoubling(n uint64) uint64 {
+	return fib.Doubling(n)
+}
+
+// fibDoublingIter calculates F(n) using the doubling method without recursion - O(log n)
+//
+//go:wasmexport fibDoublingIter
+func fibDoublingIter(n uint64) uint64 {
+	return fib.DoublingIter(n)
+}
+
+// fibMemoFast calculates F(n) with a slice-backed memo recycled via sync.Pool - O(n)
+//
+//go:wasmexport fibMemoFast
+func fibMemoFast(n uint64) uint64 {
+	return fib.MemoFast(n)
+}
+
+// fibLookup calculates F(n) from the embedded golden table - O(1)
+//
+//go:wasmexport fibLookup
+func fibLookup(n uint64) uint64 {
+	return fib.Lookup(n)
+}
+
+// lucasIterative calculates L(n) using the iterative method - O(n)
+//
+//go:wasmexport lucasIterative
+func lucasIterative(n uint64) uint64 {
+	return fib.LucasIterative(n)
+}
+
+// lucasMatrix calculates L(n) using matrix exponentiation - O(log n)
+//
+//go:wasmexport lucasMatrix
+func lucasMatrix(n uint64) uint64 {
+	return fib.LucasMatrix(n)
+}
+
+// lucasDoubling calculates L(n) using the Lucas doubling identities - O(log n)
+//
+//go:wasmexport lucasDoubling
+func lucasDoubling(n uint64) uint64 {
+	return fib.LucasDoubling(n)
+}
+
+// fibDigitCount returns the number of decimal digits of F(n) without materializing it
+//
+//go:wasmexport fibDigitCount
+func fibDigitCount(n uint64) uint64 {
+	return fib.DigitCount(n)
+}
+
+// fibMod calculates F(n) mod m using modular matrix exponentiation; m == 0 yields 0
+//
+//go:wasmexport fibMod
+func fibMod(n, m uint64) uint64 {
+	return fib.Mod(n, m)
+}
+
+// fibModFast calculates F(n) mod m after reducing n modulo the cached Pisano period of m (for m <= 2^24)
+//
+//go:wasmexport fibModFast
+func fibModFast(n, m uint64) uint64 {
+	return fib.ModFast(n, m)
+}
+
+// pisanoPeriod returns the period of the Fibonacci sequence modulo m - O(m); 0 for m > 2^24
+//
+//go:wasmexport pisanoPeriod
+func pisanoPeriod(m uint64) uint64 {
+	return fib.PisanoPeriod(m)
+}
+
+// fibK calculates the n-th k-bonacci number; returns 0 for k == 0 or k > 256
+//
+//go:wasmexport fibK
+func fibK(k, n uint64) uint64 {
+	if k == 0 || k > fib.MaxK {
+		return 0
+	}
+	return fib.K(int(k), n)
+}
+
+// isFibonacci returns 1 if value is a Fibonacci number, 0 otherwise
+//
+//go:wasmexport isFibonacci
+func isFibonacci(value uint64) int32 {
+	if fib.IsFibonacci(value) {
+		return 1
+	}
+	return 0
+}
+
+// main is required for a reactor but is not called by hosts
+func main() {}
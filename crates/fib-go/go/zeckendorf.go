@@ -0,0 +1,49 @@
+package main
+
+/*
+#include <stddef.h>
+#include <stdint.h>
+#include "fib_types.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/agbru/FibBenchmark/crates/fib-go/go/fib"
+)
+
+// ZeckendorfEncode writes the Zeckendorf indices of value into outIndices, largest first
+// Returns the number of indices; nothing is written if it exceeds capacity. 0 has no terms.
+//
+//export ZeckendorfEncode
+func ZeckendorfEncode(value C.uint64_t, outIndices *C.uint64_t, capacity C.size_t) C.size_t {
+	defer recoverPanic()
+	indices := fib.ZeckendorfEncode(uint64(value))
+	if outIndices != nil && len(indices) <= int(capacity) {
+		copy(unsafe.Slice((*uint64)(unsafe.Pointer(outIndices)), len(indices)), indices)
+	}
+	return C.size_t(len(indices))
+}
+
+// ZeckendorfDecode sums the Fibonacci numbers named by a Zeckendorf index list into out
+// Returns StatusInvalidArg unless indices are >= 2, strictly decreasing and non-consecutive,
+// and StatusOverflow if the sum does not fit in a uint64.
+//
+//export ZeckendorfDecode
+func ZeckendorfDecode(indices *C.uint64_t, count C.size_t, out *C.uint64_t) (status C.fib_status) {
+	defer recoverStatus(&status)
+	if out == nil || (indices == nil && count > 0) {
+		return StatusInvalidArg
+	}
+	var terms []uint64
+	if count > 0 {
+		terms = unsafe.Slice((*uint64)(unsafe.Pointer(indices)), count)
+	}
+	value, err := fib.ZeckendorfDecode(terms)
+	if err != nil {
+		return statusOf(err)
+	}
+	*out = C.uint64_t(value)
+	return StatusOK
+}
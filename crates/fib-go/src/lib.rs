@@ -20,6 +20,7 @@ use std::time::{Duration, Instant};
 #[cfg(not(use_rust_stub))]
 mod ffi {
     use std::ffi::CStr;
+    use std::os::raw::c_char;
 
     extern "C" {
         fn FibIterative(n: u64) -> u64;
@@ -27,7 +28,7 @@ mod ffi {
         fn FibMemo(n: u64) -> u64;
         fn FibMatrix(n: u64) -> u64;
         fn FibDoubling(n: u64) -> u64;
-        fn GetGoVersion() -> *const std::os::raw::c_char;
+        fn GetGoVersionBuf(buf: *mut c_char, len: usize) -> usize;
     }
 
     pub fn fib_iterative(n: u64) -> u64 {
@@ -52,11 +53,18 @@ mod ffi {
 
     pub fn get_version() -> String {
         unsafe {
-            let ptr = GetGoVersion();
-            if ptr.is_null() {
+            // Two-call protocol: query the size, then fill a Rust-owned buffer
+            let needed = GetGoVersionBuf(std::ptr::null_mut(), 0);
+            if needed == 0 {
                 return "unknown".to_string();
             }
-            CStr::from_ptr(ptr).to_string_lossy().into_owned()
+            let mut buf = vec![0u8; needed];
+            if GetGoVersionBuf(buf.as_mut_ptr() as *mut c_char, buf.len()) != needed {
+                return "unknown".to_string();
+            }
+            CStr::from_bytes_with_nul(&buf)
+                .map(|s| s.to_string_lossy().into_owned())
+                .unwrap_or_else(|_| "unknown".to_string())
         }
     }
 
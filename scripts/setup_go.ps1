@@ -69,7 +69,7 @@ $libPath = Join-Path $outDir "libfibgo.a"
 Push-Location $goDir
 try {
     $env:CGO_ENABLED = "1"
-    $result = go build -buildmode=c-archive -o $libPath fib.go 2>&1
+    $result = go build -buildmode=c-archive -o $libPath . 2>&1
     if ($LASTEXITCODE -eq 0) {
         Write-Host "✅ Go library built successfully!" -ForegroundColor Green
         Write-Host "   Output: $libPath" -ForegroundColor Gray
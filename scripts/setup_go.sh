@@ -82,7 +82,7 @@ LIB_PATH="$OUT_DIR/libfibgo.a"
 cd "$GO_DIR"
 export CGO_ENABLED=1
 
-if go build -buildmode=c-archive -o "$LIB_PATH" fib.go 2>&1; then
+if go build -buildmode=c-archive -o "$LIB_PATH" . 2>&1; then
     echo -e "${GREEN}✅ Go library built successfully!${NC}"
     echo "   Output: $LIB_PATH"
     